@@ -74,6 +74,10 @@ func (co *CallOptions) Recycle() {
 // Option is a series of options used at the beginning of a RPC call.
 type Option struct {
 	f func(o *CallOptions, di *strings.Builder)
+
+	// destService routes the call for clients built with client.WithDestServices,
+	// it is inspected before the option funcs are applied.
+	destService string
 }
 
 // F returns the function of the option.
@@ -89,10 +93,32 @@ func NewOption(f func(o *CallOptions, di *strings.Builder)) Option {
 	return Option{f: f}
 }
 
+// WithDestService specifies the destination service of this call.
+// It only takes effect for clients built with client.WithDestServices and
+// selects which sub-client handles the call; it is a no-op otherwise.
+func WithDestService(name string) Option {
+	return Option{destService: name, f: func(o *CallOptions, di *strings.Builder) {
+		di.WriteString("WithDestService(")
+		di.WriteString(name)
+		di.WriteString("),")
+	}}
+}
+
+// GetDestService returns the destination service carried by the given call
+// options, set by WithDestService, or "" if there is none.
+func GetDestService(opts []Option) string {
+	for i := range opts {
+		if opts[i].destService != "" {
+			return opts[i].destService
+		}
+	}
+	return ""
+}
+
 // WithHostPort specifies the target address for a RPC call.
 // The given address will overwrite the result from Resolver.
 func WithHostPort(hostport string) Option {
-	return Option{func(o *CallOptions, di *strings.Builder) {
+	return Option{f: func(o *CallOptions, di *strings.Builder) {
 		di.WriteString("WithHostPort(")
 		di.WriteString(hostport)
 		di.WriteString("),")
@@ -114,10 +140,24 @@ func setInstance(svr remoteinfo.RemoteInfo, hostport string) error {
 	return nil
 }
 
+// WithInstance specifies the target instance for a RPC call, bypassing the
+// Resolver and load balancing entirely, e.g. for replaying a request against
+// a specific pod. Connection pools, circuit breakers and middlewares still
+// apply as usual.
+func WithInstance(ins discovery.Instance) Option {
+	return Option{f: func(o *CallOptions, di *strings.Builder) {
+		di.WriteString("WithInstance(")
+		di.WriteString(ins.Address().String())
+		di.WriteString("),")
+
+		o.svr.SetInstance(ins)
+	}}
+}
+
 // WithURL specifies the target for a RPC call with url.
 // The given url will be resolved to hostport and overwrites the result from Resolver.
 func WithURL(url string) Option {
-	return Option{func(o *CallOptions, di *strings.Builder) {
+	return Option{f: func(o *CallOptions, di *strings.Builder) {
 		di.WriteString("WithURL(")
 		di.WriteString(url)
 		di.WriteString("),")
@@ -136,7 +176,7 @@ func WithURL(url string) Option {
 
 // WithHTTPHost specifies host in http header(work when RPC over http).
 func WithHTTPHost(host string) Option {
-	return Option{func(o *CallOptions, di *strings.Builder) {
+	return Option{f: func(o *CallOptions, di *strings.Builder) {
 		o.svr.SetTag(rpcinfo.HTTPHost, host)
 	}}
 }
@@ -145,7 +185,7 @@ func WithHTTPHost(host string) Option {
 // FIXME: callopt.WithRPCTimeout works only when client.WithRPCTimeout or
 // client.WithTimeoutProvider is specified.
 func WithRPCTimeout(d time.Duration) Option {
-	return Option{func(o *CallOptions, di *strings.Builder) {
+	return Option{f: func(o *CallOptions, di *strings.Builder) {
 		di.WriteString("WithRPCTimeout()")
 
 		o.configs.SetRPCTimeout(d)
@@ -159,7 +199,7 @@ func WithRPCTimeout(d time.Duration) Option {
 
 // WithConnectTimeout specifies the connection timeout for a RPC call.
 func WithConnectTimeout(d time.Duration) Option {
-	return Option{func(o *CallOptions, di *strings.Builder) {
+	return Option{f: func(o *CallOptions, di *strings.Builder) {
 		di.WriteString("WithConnectTimeout()")
 
 		o.configs.SetConnectTimeout(d)
@@ -25,6 +25,7 @@ import (
 
 	"github.com/cloudwego/kitex/internal/client"
 	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/discovery"
 	"github.com/cloudwego/kitex/pkg/fallback"
 	"github.com/cloudwego/kitex/pkg/http"
 	"github.com/cloudwego/kitex/pkg/retry"
@@ -62,6 +63,14 @@ func TestApply(t *testing.T) {
 	applyRes, _ = Apply([]Option{option}, rpcConfig, remoteInfo, client.NewConfigLocks(), http.NewDefaultResolver())
 	test.Assert(t, applyRes == fmt.Sprintf("[WithHostPort(%s),]", mockHostPort))
 
+	// WithInstance
+	ins := discovery.NewInstance("tcp", mockHostPort, discovery.DefaultWeight, map[string]string{"tag": "v"})
+	option = WithInstance(ins)
+	applyRes, _ = Apply([]Option{option}, rpcConfig, remoteInfo, client.NewConfigLocks(), http.NewDefaultResolver())
+	test.Assert(t, applyRes == fmt.Sprintf("[WithInstance(%s),]", mockHostPort))
+	gotIns := remoteInfo.GetInstance()
+	test.Assert(t, gotIns == ins)
+
 	// WithURL
 	option = WithURL(mockURL)
 	applyRes, _ = Apply([]Option{option}, rpcConfig, remoteInfo, client.NewConfigLocks(), http.NewDefaultResolver())
@@ -0,0 +1,70 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package unarycall
+
+import (
+	"time"
+
+	"github.com/cloudwego/kitex/client/callopt"
+	"github.com/cloudwego/kitex/pkg/fallback"
+	"github.com/cloudwego/kitex/pkg/retry"
+)
+
+// These options are directly translated from callopt.Option(s). If you can't find the option with the
+// same name in callopt.Option(s), most probably it means it's not for unary calls.
+
+// WithHostPort specifies the target address for an RPC call.
+func WithHostPort(hostPort string) Option {
+	return ConvertOptionFrom(callopt.WithHostPort(hostPort))
+}
+
+// WithURL specifies the target for a RPC call with url.
+// The given url will be resolved to hostport and overwrites the result from Resolver.
+func WithURL(url string) Option {
+	return ConvertOptionFrom(callopt.WithURL(url))
+}
+
+// WithHTTPHost specifies host in http header(work when RPC over http).
+func WithHTTPHost(host string) Option {
+	return ConvertOptionFrom(callopt.WithHTTPHost(host))
+}
+
+// WithRPCTimeout specifies the RPC timeout for a RPC call.
+func WithRPCTimeout(d time.Duration) Option {
+	return ConvertOptionFrom(callopt.WithRPCTimeout(d))
+}
+
+// WithConnectTimeout specifies the connection timeout for a RPC call.
+func WithConnectTimeout(d time.Duration) Option {
+	return ConvertOptionFrom(callopt.WithConnectTimeout(d))
+}
+
+// WithTag sets the tags for service discovery for an RPC call.
+func WithTag(key, val string) Option {
+	return ConvertOptionFrom(callopt.WithTag(key, val))
+}
+
+// WithRetryPolicy sets the retry policy for a RPC call.
+// Retry is meaningless for streams, so the option is unary-only.
+func WithRetryPolicy(p retry.Policy) Option {
+	return ConvertOptionFrom(callopt.WithRetryPolicy(p))
+}
+
+// WithFallback sets the fallback policy for a RPC call.
+func WithFallback(fb *fallback.Policy) Option {
+	return ConvertOptionFrom(callopt.WithFallback(fb))
+}
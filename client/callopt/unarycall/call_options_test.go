@@ -0,0 +1,35 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package unarycall
+
+import (
+	"testing"
+
+	"github.com/cloudwego/kitex/client/callopt"
+	"github.com/cloudwego/kitex/internal/test"
+)
+
+func TestGetCallOptions(t *testing.T) {
+	test.Assert(t, len(GetCallOptions(nil)) == 0)
+	opts := GetCallOptions([]Option{WithTag("k", "v"), WithHTTPHost("h")})
+	test.Assert(t, len(opts) == 2)
+}
+
+func TestConvertOptionFrom(t *testing.T) {
+	opt := ConvertOptionFrom(callopt.WithHTTPHost("h"))
+	test.Assert(t, opt.GetCallOption().F() != nil)
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package unarycall provides the call options available for unary calls only.
+// Using these typed options instead of the raw callopt.Option(s) makes
+// passing a unary-only option (e.g. retry policy, fallback) to a streaming
+// call a compile error rather than a silent no-op.
+package unarycall
+
+import (
+	"strings"
+
+	"github.com/cloudwego/kitex/client/callopt"
+)
+
+// Option is the option type used for unary calls.
+type Option struct {
+	f func(o *callopt.CallOptions, di *strings.Builder)
+}
+
+// GetCallOption returns a callopt.Option.
+// It's not supposed to be called by users directly.
+func (o Option) GetCallOption() callopt.Option {
+	return callopt.NewOption(o.f)
+}
+
+// ConvertOptionFrom converts a callopt.Option to a unarycall.Option.
+// It's convenient for creating a unarycall.Option from an existing callopt.Option.
+func ConvertOptionFrom(option callopt.Option) Option {
+	return Option{f: option.F()}
+}
+
+// GetCallOptions converts given unarycall.Option(s) to callopt.Option(s)
+func GetCallOptions(ops []Option) []callopt.Option {
+	options := make([]callopt.Option, 0, len(ops))
+	for _, opt := range ops {
+		options = append(options, opt.GetCallOption())
+	}
+	return options
+}
@@ -24,6 +24,7 @@ import (
 	"runtime/debug"
 	"strconv"
 	"sync/atomic"
+	"time"
 
 	"github.com/bytedance/gopkg/cloud/metainfo"
 	"github.com/cloudwego/localsession/backup"
@@ -45,6 +46,7 @@ import (
 	"github.com/cloudwego/kitex/pkg/proxy"
 	"github.com/cloudwego/kitex/pkg/remote"
 	"github.com/cloudwego/kitex/pkg/remote/bound"
+	"github.com/cloudwego/kitex/pkg/remote/connpool"
 	"github.com/cloudwego/kitex/pkg/remote/remotecli"
 	"github.com/cloudwego/kitex/pkg/retry"
 	"github.com/cloudwego/kitex/pkg/rpcinfo"
@@ -77,6 +79,10 @@ type kClient struct {
 
 	inited bool
 	closed bool
+
+	// closing and inflight serve CloseGracefully
+	closing  int32
+	inflight int32
 }
 
 // Set finalizer on kClient does not take effect, because kClient has a circular reference problem
@@ -99,6 +105,9 @@ func NewClient(svcInfo *serviceinfo.ServiceInfo, opts ...Option) (Client, error)
 	kc := &kcFinalizerClient{kClient: &kClient{}}
 	kc.svcInfo = svcInfo
 	kc.opt = client.NewOptions(opts)
+	if len(kc.opt.DestServices) > 0 {
+		return newMultiDestClient(svcInfo, opts, kc.opt)
+	}
 	if err := kc.init(); err != nil {
 		_ = kc.Close()
 		return nil, err
@@ -206,6 +215,9 @@ func (kc *kClient) initProxy() error {
 }
 
 func (kc *kClient) initConnPool() error {
+	if kc.opt.ConnLifecycleHooks != nil {
+		kc.opt.RemoteOpt.ConnPool = connpool.NewConnLifecycleHookPool(kc.opt.RemoteOpt.ConnPool, *kc.opt.ConnLifecycleHooks)
+	}
 	pool := kc.opt.RemoteOpt.ConnPool
 	kc.opt.CloseCallbacks = append(kc.opt.CloseCallbacks, pool.Close)
 
@@ -366,7 +378,12 @@ func (kc *kClient) Call(ctx context.Context, method string, request, response in
 	// merge backup context if no metainfo found in ctx
 	ctx = backup.RecoverCtxOnDemands(ctx, kc.opt.CtxBackupHandler)
 
+	if atomic.LoadInt32(&kc.closing) == 1 {
+		return kerrors.ErrClientClosing
+	}
 	validateForCall(ctx, kc.inited, kc.closed)
+	atomic.AddInt32(&kc.inflight, 1)
+	defer atomic.AddInt32(&kc.inflight, -1)
 	var ri rpcinfo.RPCInfo
 	var callOpts *callopt.CallOptions
 	ctx, ri, callOpts = kc.initRPCInfo(ctx, method, 0, nil, false)
@@ -549,6 +566,25 @@ func (kc *kClient) invokeHandleEndpoint() (endpoint.Endpoint, error) {
 	}, nil
 }
 
+// CloseGracefully stops new calls immediately, which will fail with
+// kerrors.ErrClientClosing, then waits for in-flight calls to finish before
+// releasing the client resources. When ctx expires first, the remaining
+// in-flight calls are aborted by the teardown and their count is returned.
+func (kc *kClient) CloseGracefully(ctx context.Context) (aborted int, err error) {
+	atomic.StoreInt32(&kc.closing, 1)
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for atomic.LoadInt32(&kc.inflight) > 0 {
+		select {
+		case <-ctx.Done():
+			aborted = int(atomic.LoadInt32(&kc.inflight))
+			return aborted, kc.Close()
+		case <-ticker.C:
+		}
+	}
+	return 0, kc.Close()
+}
+
 // Close is not concurrency safe.
 func (kc *kClient) Close() error {
 	defer func() {
@@ -0,0 +1,57 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/kerrors"
+)
+
+func TestCloseGracefullyNoInflight(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cli := newMockClient(t, ctrl).(*kcFinalizerClient)
+	aborted, err := cli.CloseGracefully(context.Background())
+	test.Assert(t, err == nil, err)
+	test.Assert(t, aborted == 0, aborted)
+
+	// new calls are rejected after close started
+	err = cli.Call(context.Background(), "mock", nil, nil)
+	test.Assert(t, errors.Is(err, kerrors.ErrClientClosing), err)
+}
+
+func TestCloseGracefullyAbortsOnDeadline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cli := newMockClient(t, ctrl).(*kcFinalizerClient)
+	atomic.AddInt32(&cli.inflight, 1) // a call that never finishes
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	aborted, err := cli.CloseGracefully(ctx)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, aborted == 1, aborted)
+}
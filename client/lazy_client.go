@@ -0,0 +1,94 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cloudwego/kitex/pkg/serviceinfo"
+)
+
+// lazyClient defers the construction of the underlying kClient until the
+// first Call, so that applications holding many downstream clients do not
+// pay the full resolver/proxy initialization cost at startup.
+type lazyClient struct {
+	svcInfo *serviceinfo.ServiceInfo
+	opts    []Option
+
+	cli    atomic.Value // Client
+	closed int32
+
+	mu sync.Mutex
+}
+
+// NewLazyClient creates a kitex.Client which defers initialization, including
+// service discovery and proxy setup, until the first Call.
+// If initialization fails, the error is returned by that Call and the next
+// Call will retry the initialization, so a temporarily unavailable registry
+// does not permanently poison the client.
+func NewLazyClient(svcInfo *serviceinfo.ServiceInfo, opts ...Option) (Client, error) {
+	if svcInfo == nil {
+		return nil, errors.New("NewLazyClient: no service info")
+	}
+	return &lazyClient{svcInfo: svcInfo, opts: opts}, nil
+}
+
+func (lc *lazyClient) Call(ctx context.Context, method string, request, response interface{}) error {
+	cli, err := lc.getClient()
+	if err != nil {
+		return err
+	}
+	return cli.Call(ctx, method, request, response)
+}
+
+func (lc *lazyClient) getClient() (Client, error) {
+	if atomic.LoadInt32(&lc.closed) == 1 {
+		return nil, errors.New("client is closed")
+	}
+	if cli := lc.cli.Load(); cli != nil {
+		return cli.(Client), nil
+	}
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	if cli := lc.cli.Load(); cli != nil {
+		return cli.(Client), nil
+	}
+	cli, err := NewClient(lc.svcInfo, lc.opts...)
+	if err != nil {
+		return nil, err
+	}
+	lc.cli.Store(cli)
+	return cli, nil
+}
+
+// Close releases the underlying client if it has been initialized.
+func (lc *lazyClient) Close() error {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	if !atomic.CompareAndSwapInt32(&lc.closed, 0, 1) {
+		return nil
+	}
+	if cli := lc.cli.Load(); cli != nil {
+		if closer, ok := cli.(interface{ Close() error }); ok {
+			return closer.Close()
+		}
+	}
+	return nil
+}
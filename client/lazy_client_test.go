@@ -0,0 +1,57 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/mocks"
+	"github.com/cloudwego/kitex/internal/test"
+)
+
+func TestNewLazyClientNoServiceInfo(t *testing.T) {
+	_, err := NewLazyClient(nil)
+	test.Assert(t, err != nil)
+}
+
+func TestLazyClientDeferredInitFailure(t *testing.T) {
+	// no WithDestService: construction succeeds, init error surfaces on Call
+	cli, err := NewLazyClient(mocks.ServiceInfo())
+	test.Assert(t, err == nil, err)
+	err = cli.Call(context.Background(), "mock", nil, nil)
+	test.Assert(t, err != nil)
+	test.Assert(t, err.Error() == "service name is required", err)
+	// the failed init is not cached
+	err = cli.Call(context.Background(), "mock", nil, nil)
+	test.Assert(t, err != nil)
+}
+
+func TestLazyClientInitOnFirstCall(t *testing.T) {
+	lc, err := NewLazyClient(mocks.ServiceInfo(), WithDestService("destService"))
+	test.Assert(t, err == nil, err)
+	test.Assert(t, lc.(*lazyClient).cli.Load() == nil)
+	// Call triggers initialization; discovery fails as no resolver target exists,
+	// but the underlying client must have been built by then.
+	_ = lc.Call(context.Background(), "mock", nil, nil)
+	test.Assert(t, lc.(*lazyClient).cli.Load() != nil)
+	err = lc.(*lazyClient).Close()
+	test.Assert(t, err == nil, err)
+	// Call after Close fails fast
+	err = lc.Call(context.Background(), "mock", nil, nil)
+	test.Assert(t, err != nil)
+}
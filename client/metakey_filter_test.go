@@ -0,0 +1,73 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bytedance/gopkg/cloud/metainfo"
+
+	"github.com/cloudwego/kitex/internal/test"
+)
+
+func TestMetaKeyFilterMW(t *testing.T) {
+	mw := newMetaKeyFilterMW([]string{"keep"}, []string{"drop"})
+	var inner context.Context
+	ep := mw(func(ctx context.Context, req, resp interface{}) error {
+		inner = ctx
+		return nil
+	})
+
+	ctx := metainfo.WithValue(context.Background(), "keep", "v")
+	ctx = metainfo.WithValue(ctx, "other", "v")
+	ctx = metainfo.WithPersistentValue(ctx, "drop", "v")
+	ctx = metainfo.WithPersistentValue(ctx, "keep", "v")
+	err := ep(ctx, nil, nil)
+	test.Assert(t, err == nil, err)
+
+	_, ok := metainfo.GetValue(inner, "keep")
+	test.Assert(t, ok)
+	_, ok = metainfo.GetValue(inner, "other")
+	test.Assert(t, !ok)
+	_, ok = metainfo.GetPersistentValue(inner, "keep")
+	test.Assert(t, ok)
+	_, ok = metainfo.GetPersistentValue(inner, "drop")
+	test.Assert(t, !ok)
+	// the caller's context is untouched
+	_, ok = metainfo.GetValue(ctx, "other")
+	test.Assert(t, ok)
+}
+
+func TestMetaKeyFilterMWDenyOnly(t *testing.T) {
+	mw := newMetaKeyFilterMW(nil, []string{"drop"})
+	var inner context.Context
+	ep := mw(func(ctx context.Context, req, resp interface{}) error {
+		inner = ctx
+		return nil
+	})
+
+	ctx := metainfo.WithValue(context.Background(), "drop", "v")
+	ctx = metainfo.WithValue(ctx, "other", "v")
+	err := ep(ctx, nil, nil)
+	test.Assert(t, err == nil, err)
+
+	_, ok := metainfo.GetValue(inner, "drop")
+	test.Assert(t, !ok)
+	_, ok = metainfo.GetValue(inner, "other")
+	test.Assert(t, ok)
+}
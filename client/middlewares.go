@@ -24,6 +24,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/bytedance/gopkg/cloud/metainfo"
+
 	"github.com/cloudwego/kitex/internal"
 	"github.com/cloudwego/kitex/pkg/discovery"
 	"github.com/cloudwego/kitex/pkg/endpoint"
@@ -146,6 +148,50 @@ func newResolveMWBuilder(lbf *lbcache.BalancerFactory) endpoint.MiddlewareBuilde
 }
 
 // newIOErrorHandleMW provides a hook point for io error handling.
+func newMetaKeyFilterMW(allow, deny []string) endpoint.Middleware {
+	var allowed, denied map[string]struct{}
+	if len(allow) > 0 {
+		allowed = make(map[string]struct{}, len(allow))
+		for _, k := range allow {
+			allowed[k] = struct{}{}
+		}
+	}
+	if len(deny) > 0 {
+		denied = make(map[string]struct{}, len(deny))
+		for _, k := range deny {
+			denied[k] = struct{}{}
+		}
+	}
+	forwardable := func(k string) bool {
+		if denied != nil {
+			if _, ok := denied[k]; ok {
+				return false
+			}
+		}
+		if allowed != nil {
+			if _, ok := allowed[k]; !ok {
+				return false
+			}
+		}
+		return true
+	}
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request, response interface{}) error {
+			for k := range metainfo.GetAllValues(ctx) {
+				if !forwardable(k) {
+					ctx = metainfo.DelValue(ctx, k)
+				}
+			}
+			for k := range metainfo.GetAllPersistentValues(ctx) {
+				if !forwardable(k) {
+					ctx = metainfo.DelPersistentValue(ctx, k)
+				}
+			}
+			return next(ctx, request, response)
+		}
+	}
+}
+
 func newIOErrorHandleMW(errHandle func(context.Context, error) error) endpoint.Middleware {
 	if errHandle == nil {
 		errHandle = DefaultClientErrorHandler
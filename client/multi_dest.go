@@ -0,0 +1,97 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/kitex/client/callopt"
+	"github.com/cloudwego/kitex/internal/client"
+	"github.com/cloudwego/kitex/pkg/serviceinfo"
+	"github.com/cloudwego/kitex/pkg/utils"
+)
+
+// WithDestServices instructs NewClient to build one sub-client per destination
+// service, so a single generated client can route per-call to different
+// destinations with isolated connection pools and circuit breaker statistics.
+// The map key is the destination service name and the value holds extra
+// options applied to that sub-client only, on top of the common options.
+// The destination of a call is selected with callopt.WithDestService; calls
+// without it go to the sub-client whose name equals the common dest service.
+func WithDestServices(dests map[string][]Option) Option {
+	return Option{F: func(o *client.Options, di *utils.Slice) {
+		di.Push(fmt.Sprintf("WithDestServices(%d)", len(dests)))
+
+		o.DestServices = dests
+	}}
+}
+
+// multiDestClient routes each call to one of several fully isolated
+// sub-clients keyed by destination service name.
+type multiDestClient struct {
+	defaultCli Client
+	clients    map[string]Client
+}
+
+func newMultiDestClient(svcInfo *serviceinfo.ServiceInfo, baseOpts []Option, opt *client.Options) (Client, error) {
+	mc := &multiDestClient{clients: make(map[string]Client, len(opt.DestServices))}
+	for name, destOpts := range opt.DestServices {
+		subOpts := make([]Option, 0, len(baseOpts)+len(destOpts)+1)
+		subOpts = append(subOpts, baseOpts...)
+		subOpts = append(subOpts, destOpts...)
+		dest := name
+		subOpts = append(subOpts, Option{F: func(o *client.Options, di *utils.Slice) {
+			di.Push(fmt.Sprintf("WithDestServices: dest=%s", dest))
+			o.Svr.ServiceName = dest
+			o.DestServices = nil
+		}})
+		cli, err := NewClient(svcInfo, subOpts...)
+		if err != nil {
+			_ = mc.Close()
+			return nil, fmt.Errorf("NewClient: build sub-client for dest service %q failed: %w", name, err)
+		}
+		mc.clients[name] = cli
+	}
+	// calls without callopt.WithDestService fall back to the common dest service
+	mc.defaultCli = mc.clients[opt.Svr.ServiceName]
+	return mc, nil
+}
+
+func (mc *multiDestClient) Call(ctx context.Context, method string, request, response interface{}) error {
+	cli := mc.defaultCli
+	if name := callopt.GetDestService(CallOptionsFromCtx(ctx)); name != "" {
+		if cli = mc.clients[name]; cli == nil {
+			return fmt.Errorf("unknown dest service %q", name)
+		}
+	} else if cli == nil {
+		return fmt.Errorf("no dest service specified for the call, use callopt.WithDestService")
+	}
+	return cli.Call(ctx, method, request, response)
+}
+
+// Close releases all sub-clients.
+func (mc *multiDestClient) Close() (err error) {
+	for _, cli := range mc.clients {
+		if closer, ok := cli.(interface{ Close() error }); ok {
+			if e := closer.Close(); e != nil && err == nil {
+				err = e
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,56 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/kitex/client/callopt"
+	"github.com/cloudwego/kitex/internal/mocks"
+	"github.com/cloudwego/kitex/internal/test"
+)
+
+func TestMultiDestClient(t *testing.T) {
+	cli, err := NewClient(mocks.ServiceInfo(),
+		WithDestService("serviceA"),
+		WithDestServices(map[string][]Option{
+			"serviceA": nil,
+			"serviceB": nil,
+		}))
+	test.Assert(t, err == nil, err)
+	mc, ok := cli.(*multiDestClient)
+	test.Assert(t, ok)
+	test.Assert(t, len(mc.clients) == 2)
+	test.Assert(t, mc.defaultCli == mc.clients["serviceA"])
+
+	// unknown dest fails fast before any sub-client is touched
+	ctx := NewCtxWithCallOptions(context.Background(), []callopt.Option{callopt.WithDestService("serviceC")})
+	err = cli.Call(ctx, "mock", nil, nil)
+	test.Assert(t, err != nil)
+	test.Assert(t, strings.Contains(err.Error(), "unknown dest service"), err)
+
+	err = mc.Close()
+	test.Assert(t, err == nil, err)
+}
+
+func TestGetDestService(t *testing.T) {
+	test.Assert(t, callopt.GetDestService(nil) == "")
+	opts := []callopt.Option{callopt.WithRPCTimeout(0), callopt.WithDestService("serviceB")}
+	test.Assert(t, callopt.GetDestService(opts) == "serviceB")
+}
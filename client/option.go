@@ -35,6 +35,8 @@ import (
 	"github.com/cloudwego/kitex/pkg/loadbalance"
 	"github.com/cloudwego/kitex/pkg/loadbalance/lbcache"
 	"github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/remote/codec"
+	"github.com/cloudwego/kitex/pkg/remote/codec/thrift"
 	"github.com/cloudwego/kitex/pkg/remote/trans/netpollmux"
 	"github.com/cloudwego/kitex/pkg/remote/trans/nphttp2/grpc"
 	"github.com/cloudwego/kitex/pkg/retry"
@@ -325,6 +327,35 @@ func WithPayloadCodec(c remote.PayloadCodec) Option {
 	}}
 }
 
+// WithFrugal enables frugal, the JIT-based thrift codec, for both encoding and decoding.
+// Types frugal cannot handle, e.g. ones generated without frugal tags, automatically
+// fall back to the fastCodec or Apache codec. SkipDecoder is enabled so frugal decoding
+// also works for the Buffered transport where the payload size is unknown.
+func WithFrugal() Option {
+	return Option{F: func(o *client.Options, di *utils.Slice) {
+		di.Push("WithFrugal()")
+
+		o.RemoteOpt.PayloadCodec = thrift.NewThriftCodecWithConfig(thrift.FrugalReadWrite | thrift.EnableSkipDecoder)
+	}}
+}
+
+// WithPayloadCompression compresses payloads larger than minSize bytes with the
+// named compressor, e.g. codec.CompressorGZip, before sending; minSize 0 means
+// codec.DefaultCompressMinSize. It takes effect on TTHeader transports: the
+// compressor name travels in a header key and the server auto-detects it, so
+// no server-side configuration is needed.
+// It replaces the default codec, so it cannot be combined with WithCodec.
+func WithPayloadCompression(compressor string, minSize int) Option {
+	return Option{F: func(o *client.Options, di *utils.Slice) {
+		di.Push(fmt.Sprintf("WithPayloadCompression(%s,%d)", compressor, minSize))
+
+		o.RemoteOpt.Codec = codec.NewDefaultCodecWithConfig(codec.CodecConfig{
+			PayloadCompressor: compressor,
+			CompressMinSize:   minSize,
+		})
+	}}
+}
+
 // WithConnReporterEnabled to enable reporting connection pool stats.
 func WithConnReporterEnabled() Option {
 	return Option{F: func(o *client.Options, di *utils.Slice) {
@@ -347,9 +378,13 @@ func WithFailureRetry(p *retry.FailurePolicy) Option {
 		if o.UnaryOptions.RetryMethodPolicies == nil {
 			o.UnaryOptions.RetryMethodPolicies = make(map[string]retry.Policy)
 		}
-		if o.UnaryOptions.RetryMethodPolicies[retry.Wildcard].MixedPolicy != nil ||
-			o.UnaryOptions.RetryMethodPolicies[retry.Wildcard].BackupPolicy != nil {
-			panic("MixedPolicy or BackupPolicy has been setup, cannot support Failure Retry at same time")
+		if o.UnaryOptions.RetryMethodPolicies[retry.Wildcard].MixedPolicy != nil {
+			panic("MixedPolicy has been setup, cannot support Failure Retry at same time")
+		}
+		if bp := o.UnaryOptions.RetryMethodPolicies[retry.Wildcard].BackupPolicy; bp != nil {
+			// a backup request policy is already configured, combine both into a mixed policy
+			o.UnaryOptions.RetryMethodPolicies[retry.Wildcard] = retry.BuildCombinedPolicy(p, bp)
+			return
 		}
 		o.UnaryOptions.RetryMethodPolicies[retry.Wildcard] = retry.BuildFailurePolicy(p)
 	}}
@@ -368,9 +403,13 @@ func WithBackupRequest(p *retry.BackupPolicy) Option {
 		if o.UnaryOptions.RetryMethodPolicies == nil {
 			o.UnaryOptions.RetryMethodPolicies = make(map[string]retry.Policy)
 		}
-		if o.UnaryOptions.RetryMethodPolicies[retry.Wildcard].MixedPolicy != nil ||
-			o.UnaryOptions.RetryMethodPolicies[retry.Wildcard].FailurePolicy != nil {
-			panic("MixedPolicy or BackupPolicy has been setup, cannot support Failure Retry at same time")
+		if o.UnaryOptions.RetryMethodPolicies[retry.Wildcard].MixedPolicy != nil {
+			panic("MixedPolicy has been setup, cannot support Backup Request at same time")
+		}
+		if fp := o.UnaryOptions.RetryMethodPolicies[retry.Wildcard].FailurePolicy; fp != nil {
+			// a failure retry policy is already configured, combine both into a mixed policy
+			o.UnaryOptions.RetryMethodPolicies[retry.Wildcard] = retry.BuildCombinedPolicy(fp, p)
+			return
 		}
 		o.UnaryOptions.RetryMethodPolicies[retry.Wildcard] = retry.BuildBackupRequest(p)
 	}}
@@ -28,6 +28,7 @@ import (
 	"github.com/cloudwego/kitex/internal/client"
 	"github.com/cloudwego/kitex/pkg/acl"
 	"github.com/cloudwego/kitex/pkg/diagnosis"
+	"github.com/cloudwego/kitex/pkg/endpoint"
 	"github.com/cloudwego/kitex/pkg/generic"
 	"github.com/cloudwego/kitex/pkg/klog"
 	"github.com/cloudwego/kitex/pkg/proxy"
@@ -137,6 +138,36 @@ func WithDialer(d remote.Dialer) Option {
 	}}
 }
 
+// WithMetaKeyFilter controls which transient/persistent metainfo keys are
+// forwarded downstream, to prevent unbounded header growth in long call chains.
+// If allow is not empty, only the allowed keys are forwarded; keys in deny are
+// always dropped. The filtering applies per call and does not modify the
+// caller's context.
+func WithMetaKeyFilter(allow, deny []string) Option {
+	return Option{F: func(o *client.Options, di *utils.Slice) {
+		di.Push(fmt.Sprintf("WithMetaKeyFilter(allow=%d,deny=%d)", len(allow), len(deny)))
+
+		o.MWBs = append(o.MWBs, func(ctx context.Context) endpoint.Middleware {
+			return newMetaKeyFilterMW(allow, deny)
+		})
+	}}
+}
+
+// WithContextDialer sets a context-based Dialer for creating connections.
+// It allows reaching downstreams through egress proxies, see the
+// remote/proxydialer package for bundled SOCKS5 and HTTP CONNECT dialers.
+func WithContextDialer(d remote.ContextDialer) Option {
+	return Option{F: func(o *client.Options, di *utils.Slice) {
+		o.Once.OnceOrPanic()
+		di.Push(fmt.Sprintf("WithContextDialer(%T)", d))
+
+		if d == nil {
+			panic("invalid ContextDialer: nil")
+		}
+		o.RemoteOpt.Dialer = remote.NewDialerWithContext(d)
+	}}
+}
+
 // WithConnPool sets the connection pool.
 // Note that this option can only be specified once. If more
 // than one pool is specified by this option, only the first
@@ -153,6 +184,24 @@ func WithConnPool(pool remote.ConnPool) Option {
 	}}
 }
 
+// WithConnLifecycleHooks sets callbacks observing the lifecycle of pooled
+// connections: onDial fires after a new connection is established, onClose
+// when the pool discards a connection, and onReuse when a pooled connection
+// is checked out for a request. Any of the callbacks may be nil.
+// It is useful for attaching custom socket options or recording
+// per-connection metrics without replacing the whole Dialer.
+func WithConnLifecycleHooks(onDial, onClose, onReuse remote.ConnHook) Option {
+	return Option{F: func(o *client.Options, di *utils.Slice) {
+		di.Push("WithConnLifecycleHooks()")
+
+		o.ConnLifecycleHooks = &remote.ConnLifecycleHooks{
+			OnDial:  onDial,
+			OnClose: onClose,
+			OnReuse: onReuse,
+		}
+	}}
+}
+
 // WithRetryContainer sets Container
 func WithRetryContainer(rc *retry.Container) Option {
 	return Option{F: func(o *client.Options, di *utils.Slice) {
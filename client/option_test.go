@@ -44,6 +44,8 @@ import (
 	"github.com/cloudwego/kitex/pkg/http"
 	"github.com/cloudwego/kitex/pkg/loadbalance"
 	"github.com/cloudwego/kitex/pkg/proxy"
+	"github.com/cloudwego/kitex/pkg/remote/codec"
+	"github.com/cloudwego/kitex/pkg/remote/codec/thrift"
 	"github.com/cloudwego/kitex/pkg/remote/trans/nphttp2/grpc"
 	"github.com/cloudwego/kitex/pkg/retry"
 	"github.com/cloudwego/kitex/pkg/rpcinfo"
@@ -99,7 +101,7 @@ func TestRetryOptionDebugInfo(t *testing.T) {
 	t.Run("FailurePolicy", func(t *testing.T) {
 		bp := retry.NewBackupPolicy(20)
 		expectPolicyStr := "WithBackupRequest({RetryDelayMS:20 StopPolicy:{MaxRetryTimes:1 MaxDurationMS:0 DisableChainStop:false " +
-			"DDLStop:false CBPolicy:{ErrorRate:0.1}} RetrySameNode:false})"
+			"DDLStop:false CBPolicy:{ErrorRate:0.1}} RetrySameNode:false SuppressOnCBHalfOpen:false})"
 		opt := WithBackupRequest(bp)
 		err := checkOneOptionDebugInfo(t, opt, expectPolicyStr)
 		test.Assert(t, err == nil, err)
@@ -128,19 +130,25 @@ func TestRetryOptionDebugInfo(t *testing.T) {
 }
 
 func TestRetryOption(t *testing.T) {
-	defer func() {
-		err := recover()
-		test.Assert(t, err != nil)
-	}()
 	fp := retry.NewFailurePolicy()
 	var options []client.Option
 	options = append(options, WithFailureRetry(fp))
 	bp := retry.NewBackupPolicy(20)
 	options = append(options, WithBackupRequest(bp))
 
-	client.NewOptions(options)
-	// both setup failure and backup retry, panic should happen
-	test.Assert(t, false)
+	// both failure retry and backup request are combined into a mixed policy
+	opts := client.NewOptions(options)
+	p := opts.UnaryOptions.RetryMethodPolicies[retry.Wildcard]
+	test.Assert(t, p.Enable)
+	test.Assert(t, p.Type == retry.MixedType)
+	test.Assert(t, p.MixedPolicy != nil)
+	test.Assert(t, p.MixedPolicy.RetryDelayMS == 20)
+
+	// same result regardless of option order
+	opts = client.NewOptions([]client.Option{WithBackupRequest(bp), WithFailureRetry(fp)})
+	p = opts.UnaryOptions.RetryMethodPolicies[retry.Wildcard]
+	test.Assert(t, p.Type == retry.MixedType)
+	test.Assert(t, p.MixedPolicy != nil)
 }
 
 func TestTransportProtocolOption(t *testing.T) {
@@ -487,6 +495,21 @@ func TestWithPayloadCodec(t *testing.T) {
 	test.DeepEqual(t, opts.RemoteOpt.PayloadCodec, mockPayloadCodec)
 }
 
+func TestWithFrugal(t *testing.T) {
+	opts := client.NewOptions([]client.Option{WithFrugal()})
+	test.Assert(t, thrift.IsThriftCodec(opts.RemoteOpt.PayloadCodec))
+	test.DeepEqual(t, opts.RemoteOpt.PayloadCodec,
+		thrift.NewThriftCodecWithConfig(thrift.FrugalReadWrite|thrift.EnableSkipDecoder))
+}
+
+func TestWithPayloadCompression(t *testing.T) {
+	opts := client.NewOptions([]client.Option{WithPayloadCompression(codec.CompressorGZip, 2048)})
+	test.DeepEqual(t, opts.RemoteOpt.Codec, codec.NewDefaultCodecWithConfig(codec.CodecConfig{
+		PayloadCompressor: codec.CompressorGZip,
+		CompressMinSize:   2048,
+	}))
+}
+
 func TestWithConnReporterEnabled(t *testing.T) {
 	opts := client.NewOptions([]client.Option{WithConnReporterEnabled()})
 	test.Assert(t, opts.RemoteOpt.EnableConnPoolReporter)
@@ -39,6 +39,7 @@ import (
 	"github.com/cloudwego/kitex/pkg/loadbalance/lbcache"
 	"github.com/cloudwego/kitex/pkg/proxy"
 	"github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/remote/codec"
 	"github.com/cloudwego/kitex/pkg/remote/codec/protobuf"
 	"github.com/cloudwego/kitex/pkg/remote/codec/thrift"
 	"github.com/cloudwego/kitex/pkg/remote/connpool"
@@ -58,6 +59,7 @@ import (
 func init() {
 	remote.PutPayloadCode(serviceinfo.Thrift, thrift.NewThriftCodec())
 	remote.PutPayloadCode(serviceinfo.Protobuf, protobuf.NewProtobufCodec())
+	remote.PutPayloadCode(serviceinfo.JSON, codec.JSON)
 }
 
 type UnaryOption struct {
@@ -169,6 +171,12 @@ type Options struct {
 
 	ACLRules []acl.RejectFunc
 
+	ConnLifecycleHooks *remote.ConnLifecycleHooks
+
+	// DestServices, if not empty, instructs NewClient to build one sub-client
+	// per destination service, see client.WithDestServices.
+	DestServices map[string][]Option
+
 	MWBs  []endpoint.MiddlewareBuilder
 	IMWBs []endpoint.MiddlewareBuilder
 
@@ -19,9 +19,11 @@ package server
 
 import (
 	"context"
+	"net"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/cloudwego/localsession/backup"
 
@@ -38,6 +40,7 @@ import (
 	"github.com/cloudwego/kitex/pkg/proxy"
 	"github.com/cloudwego/kitex/pkg/registry"
 	"github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/remote/codec"
 	"github.com/cloudwego/kitex/pkg/remote/codec/protobuf"
 	"github.com/cloudwego/kitex/pkg/remote/codec/thrift"
 	"github.com/cloudwego/kitex/pkg/remote/trans"
@@ -52,6 +55,7 @@ import (
 func init() {
 	remote.PutPayloadCode(serviceinfo.Thrift, thrift.NewThriftCodec())
 	remote.PutPayloadCode(serviceinfo.Protobuf, protobuf.NewProtobufCodec())
+	remote.PutPayloadCode(serviceinfo.JSON, codec.JSON)
 }
 
 type UnaryOption struct {
@@ -174,6 +178,79 @@ type Options struct {
 
 	RefuseTrafficWithoutServiceName bool
 	EnableContextTimeout            bool
+
+	// EnableHotRestart makes the server hand over its listener FD to a child
+	// process on SIGUSR2, see server.WithHotRestart.
+	EnableHotRestart bool
+
+	// EnableHealthCheck registers the built-in health service,
+	// see server.WithHealthCheck.
+	EnableHealthCheck bool
+
+	// EnableReflection registers the built-in reflection service,
+	// see server.WithReflectionService.
+	EnableReflection bool
+
+	// AdminAddr is the listen address of the admin HTTP server,
+	// empty to disable it, see server.WithAdminServer.
+	AdminAddr string
+
+	// PanicHandler maps a handler panic to the error returned to the client,
+	// see server.WithPanicHandler.
+	PanicHandler func(ctx context.Context, panicInfo interface{}, stack string) error
+
+	// TrafficMirror enables shadow-traffic mirroring, see server.WithTrafficMirror.
+	TrafficMirror *TrafficMirror
+
+	// GracefulShutdown orders Stop into a draining-aware sequence,
+	// see server.WithGracefulShutdown.
+	GracefulShutdown *GracefulShutdownPolicy
+
+	// ExtraListeners are additional listeners served besides RemoteOpt.Listener,
+	// see server.WithListeners.
+	ExtraListeners []net.Listener
+}
+
+// TrafficMirror carries the shadow-traffic settings, see server.WithTrafficMirror.
+type TrafficMirror struct {
+	Target     string
+	SampleRate float64
+}
+
+// ShutdownStage identifies a stage of the graceful shutdown sequence,
+// see server.WithGracefulShutdown.
+type ShutdownStage int
+
+const (
+	StageDeregister ShutdownStage = iota
+	StageWaitPropagation
+	StageDrain
+	StageExitHooks
+)
+
+// String implements fmt.Stringer.
+func (s ShutdownStage) String() string {
+	switch s {
+	case StageDeregister:
+		return "deregister"
+	case StageWaitPropagation:
+		return "wait_propagation"
+	case StageDrain:
+		return "drain"
+	case StageExitHooks:
+		return "exit_hooks"
+	}
+	return "unknown"
+}
+
+// GracefulShutdownPolicy configures the ordered shutdown sequence,
+// see server.WithGracefulShutdown.
+type GracefulShutdownPolicy struct {
+	// PropagationDelay is how long to wait after deregistering so that
+	// resolver caches stop routing new requests to this instance.
+	PropagationDelay time.Duration
+	// OnStage, if set, is called when each shutdown stage starts.
+	OnStage func(stage ShutdownStage)
 }
 
 type Limit struct {
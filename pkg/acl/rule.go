@@ -0,0 +1,140 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package acl
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/bytedance/gopkg/cloud/metainfo"
+
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+// DenyError is the reason returned by a Rule that rejects a request.
+// It implements kerrors.BizStatusErrorIface, so the status code is carried to
+// the client through the TTHeader/gRPC biz-status mechanism instead of being
+// flattened into a plain error string.
+type DenyError struct {
+	StatusCode int32
+	Reason     string
+}
+
+// Error implements the error interface.
+func (e *DenyError) Error() string {
+	return fmt.Sprintf("deny by acl rule: %s (status=%d)", e.Reason, e.StatusCode)
+}
+
+// BizStatusCode implements kerrors.BizStatusErrorIface.
+func (e *DenyError) BizStatusCode() int32 { return e.StatusCode }
+
+// BizMessage implements kerrors.BizStatusErrorIface.
+func (e *DenyError) BizMessage() string { return e.Reason }
+
+// BizExtra implements kerrors.BizStatusErrorIface.
+func (e *DenyError) BizExtra() map[string]string { return nil }
+
+// Is makes errors.Is(err, kerrors.ErrACL) hold for deny errors, so ApplyRules
+// keeps the typed error instead of wrapping it again.
+func (e *DenyError) Is(target error) bool { return target == kerrors.ErrACL }
+
+// Rule matches requests by caller service, method, metadata and source IP,
+// and rejects the matched ones. Matchers are combined with AND; a rule
+// without matchers matches every request. Build a RejectFunc with Deny:
+//
+//	server.WithACLRules(acl.NewRule().MatchMethod("Echo").MatchSourceCIDR("10.0.0.0/8").Deny(403, "forbidden"))
+type Rule struct {
+	matchers []func(ctx context.Context, request interface{}) bool
+}
+
+// NewRule creates an empty Rule.
+func NewRule() *Rule {
+	return &Rule{}
+}
+
+// MatchCallerService matches requests whose caller service equals name.
+func (r *Rule) MatchCallerService(name string) *Rule {
+	r.matchers = append(r.matchers, func(ctx context.Context, request interface{}) bool {
+		if ri := rpcinfo.GetRPCInfo(ctx); ri != nil && ri.From() != nil {
+			return ri.From().ServiceName() == name
+		}
+		return false
+	})
+	return r
+}
+
+// MatchMethod matches requests to the given method.
+func (r *Rule) MatchMethod(name string) *Rule {
+	r.matchers = append(r.matchers, func(ctx context.Context, request interface{}) bool {
+		if ri := rpcinfo.GetRPCInfo(ctx); ri != nil && ri.Invocation() != nil {
+			return ri.Invocation().MethodName() == name
+		}
+		return false
+	})
+	return r
+}
+
+// MatchMetadata matches requests carrying the transient metainfo key with the
+// given value; an empty value matches any value of the key.
+func (r *Rule) MatchMetadata(key, value string) *Rule {
+	r.matchers = append(r.matchers, func(ctx context.Context, request interface{}) bool {
+		v, ok := metainfo.GetValue(ctx, key)
+		return ok && (value == "" || v == value)
+	})
+	return r
+}
+
+// MatchSourceCIDR matches requests whose source address falls into the given
+// CIDR, e.g. "10.0.0.0/8". It panics on an invalid CIDR since rules are
+// built at initialization.
+func (r *Rule) MatchSourceCIDR(cidr string) *Rule {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(fmt.Errorf("invalid CIDR %q: %w", cidr, err))
+	}
+	r.matchers = append(r.matchers, func(ctx context.Context, request interface{}) bool {
+		ri := rpcinfo.GetRPCInfo(ctx)
+		if ri == nil || ri.From() == nil || ri.From().Address() == nil {
+			return false
+		}
+		host := ri.From().Address().String()
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		ip := net.ParseIP(strings.Trim(host, "[]"))
+		return ip != nil && ipNet.Contains(ip)
+	})
+	return r
+}
+
+// Deny finishes the rule: matched requests are rejected with a DenyError
+// carrying the given status code and reason.
+func (r *Rule) Deny(statusCode int32, reason string) RejectFunc {
+	matchers := r.matchers
+	denyErr := &DenyError{StatusCode: statusCode, Reason: reason}
+	return func(ctx context.Context, request interface{}) error {
+		for _, match := range matchers {
+			if !match(ctx, request) {
+				return nil
+			}
+		}
+		return denyErr
+	}
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package acl
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bytedance/gopkg/cloud/metainfo"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+	"github.com/cloudwego/kitex/pkg/utils"
+)
+
+func ruleCtx() context.Context {
+	from := rpcinfo.NewEndpointInfo("caller", "", utils.NewNetAddr("tcp", "10.1.2.3:8888"), nil)
+	ri := rpcinfo.NewRPCInfo(from, nil, rpcinfo.NewInvocation("svc", "Echo"), nil, nil)
+	return rpcinfo.NewCtxWithRPCInfo(context.Background(), ri)
+}
+
+func TestRuleDeny(t *testing.T) {
+	ctx := ruleCtx()
+
+	// all matchers hit, deny with typed error
+	reject := NewRule().MatchCallerService("caller").MatchMethod("Echo").MatchSourceCIDR("10.0.0.0/8").Deny(403, "forbidden")
+	err := ApplyRules(ctx, nil, []RejectFunc{reject})
+	test.Assert(t, errors.Is(err, kerrors.ErrACL), err)
+	bizErr, ok := kerrors.FromBizStatusError(err)
+	test.Assert(t, ok, err)
+	test.Assert(t, bizErr.BizStatusCode() == 403)
+	test.Assert(t, bizErr.BizMessage() == "forbidden")
+
+	// one matcher misses, pass
+	reject = NewRule().MatchCallerService("other").MatchMethod("Echo").Deny(403, "forbidden")
+	test.Assert(t, ApplyRules(ctx, nil, []RejectFunc{reject}) == nil)
+
+	reject = NewRule().MatchSourceCIDR("192.168.0.0/16").Deny(403, "forbidden")
+	test.Assert(t, ApplyRules(ctx, nil, []RejectFunc{reject}) == nil)
+}
+
+func TestRuleMatchMetadata(t *testing.T) {
+	ctx := metainfo.WithValue(ruleCtx(), "env", "canary")
+
+	reject := NewRule().MatchMetadata("env", "canary").Deny(401, "no canary")
+	test.Assert(t, reject(ctx, nil) != nil)
+
+	reject = NewRule().MatchMetadata("env", "").Deny(401, "no env")
+	test.Assert(t, reject(ctx, nil) != nil)
+
+	reject = NewRule().MatchMetadata("env", "prod").Deny(401, "no prod")
+	test.Assert(t, reject(ctx, nil) == nil)
+
+	reject = NewRule().MatchMetadata("other", "").Deny(401, "no other")
+	test.Assert(t, reject(ctx, nil) == nil)
+}
+
+func TestRuleInvalidCIDR(t *testing.T) {
+	test.Panic(t, func() {
+		NewRule().MatchSourceCIDR("not-a-cidr")
+	})
+}
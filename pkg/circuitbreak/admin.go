@@ -0,0 +1,88 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package circuitbreak
+
+import (
+	"github.com/bytedance/gopkg/cloud/circuitbreaker"
+)
+
+// manual override states, kept as strings so they read well in Dump output
+const (
+	forcedOpen   = "forced_open"
+	forcedClosed = "forced_closed"
+
+	adminOverridesKey = "admin_overrides"
+)
+
+// ForceOpen forces the breakers of key open on both the service and instance
+// level, rejecting requests immediately regardless of breaker statistics. It
+// is meant for on-call operations to isolate a bad downstream instantly; the
+// override stays until ForceClose or Reset is called.
+func (s *CBSuite) ForceOpen(key string) {
+	s.adminOverrides.Store(key, forcedOpen)
+}
+
+// ForceClose forces the breakers of key closed, letting requests through
+// regardless of breaker statistics. The override stays until ForceOpen or
+// Reset is called.
+func (s *CBSuite) ForceClose(key string) {
+	s.adminOverrides.Store(key, forcedClosed)
+}
+
+// Reset removes the manual override of key and resets the breaker counters,
+// handing control back to the automatic trip strategy from a clean state.
+func (s *CBSuite) Reset(key string) {
+	s.adminOverrides.Delete(key)
+	resetBreaker(s.servicePanel, key)
+	resetBreaker(s.instancePanel, key)
+}
+
+func resetBreaker(panel circuitbreaker.Panel, key string) {
+	if panel == nil {
+		return
+	}
+	if b, ok := panel.DumpBreakers()[key]; ok {
+		b.Reset()
+	}
+}
+
+// adminOverrideInfo collects the manual overrides for Dump, nil when none.
+func (s *CBSuite) adminOverrideInfo() map[string]interface{} {
+	var info map[string]interface{}
+	s.adminOverrides.Range(func(key string, value interface{}) bool {
+		if info == nil {
+			info = make(map[string]interface{})
+		}
+		info[key] = value
+		return true
+	})
+	return info
+}
+
+// adminPanel wraps a circuitbreaker.Panel to apply the suite's manual
+// overrides on the allow decision; everything else delegates to the panel.
+type adminPanel struct {
+	circuitbreaker.Panel
+	suite *CBSuite
+}
+
+func (p *adminPanel) IsAllowed(key string) bool {
+	if state, ok := p.suite.adminOverrides.Load(key); ok {
+		return state == forcedClosed
+	}
+	return p.Panel.IsAllowed(key)
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package circuitbreak
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+func TestForceOpenAndReset(t *testing.T) {
+	cb := NewCBSuite(RPCInfo2Key)
+	defer cb.Close()
+
+	ctx := prepareCtx()
+	key := RPCInfo2Key(rpcinfo.GetRPCInfo(ctx))
+	ep := cb.ServiceCBMW()(func(ctx context.Context, req, resp interface{}) error {
+		return nil
+	})
+
+	// without overrides the request passes
+	test.Assert(t, ep(ctx, nil, nil) == nil)
+
+	// forced open: rejected without any failure statistics
+	cb.ForceOpen(key)
+	err := ep(ctx, nil, nil)
+	test.Assert(t, errors.Is(err, kerrors.ErrServiceCircuitBreak), err)
+
+	// the override shows up in the dump
+	info := cb.Dump().(map[string]interface{})
+	overrides, ok := info[adminOverridesKey].(map[string]interface{})
+	test.Assert(t, ok)
+	test.Assert(t, overrides[key] == forcedOpen, overrides)
+
+	// reset hands control back to the automatic strategy
+	cb.Reset(key)
+	test.Assert(t, ep(ctx, nil, nil) == nil)
+	info = cb.Dump().(map[string]interface{})
+	test.Assert(t, info[adminOverridesKey] == nil)
+}
+
+func TestForceClose(t *testing.T) {
+	cb := NewCBSuite(RPCInfo2Key)
+	defer cb.Close()
+	ctx := prepareCtx()
+	key := RPCInfo2Key(rpcinfo.GetRPCInfo(ctx))
+	cb.UpdateServiceCBConfig(key, CBConfig{Enable: true, TripStrategy: ConsecutiveTrip, ConsecutiveFailures: 1})
+
+	ep := cb.ServiceCBMW()(func(ctx context.Context, req, resp interface{}) error {
+		return errMock
+	})
+	// first failure trips the breaker, the second request is rejected
+	err := ep(ctx, nil, nil)
+	test.Assert(t, errors.Is(err, errMock), err)
+	err = ep(ctx, nil, nil)
+	test.Assert(t, errors.Is(err, kerrors.ErrServiceCircuitBreak), err)
+
+	// forced closed: requests pass through the tripped breaker
+	cb.ForceClose(key)
+	err = ep(ctx, nil, nil)
+	test.Assert(t, errors.Is(err, errMock), err)
+}
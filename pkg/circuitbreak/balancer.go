@@ -0,0 +1,125 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package circuitbreak
+
+import (
+	"github.com/bytedance/gopkg/cloud/circuitbreaker"
+
+	"github.com/cloudwego/kitex/pkg/discovery"
+	"github.com/cloudwego/kitex/pkg/loadbalance"
+)
+
+// DefaultHalfOpenWeightRatio is the default fraction of an instance's weight
+// kept while its breaker is half-open.
+const DefaultHalfOpenWeightRatio = 0.1
+
+// NewBreakerAwareBalancer wraps a weighted balancer so that breaker decisions
+// of the suite's instance level shape traffic instead of only rejecting it:
+// instances with an open breaker are removed from the pick set, and instances
+// with a half-open breaker keep halfOpenWeightRatio of their weight, ramping
+// recovery traffic up smoothly rather than flipping between zero and full.
+// A non-positive ratio falls back to DefaultHalfOpenWeightRatio.
+// Instances with closed breakers go through untouched, so the underlying
+// balancer keeps its picker cache on the common path.
+func NewBreakerAwareBalancer(lb loadbalance.Loadbalancer, suite *CBSuite, halfOpenWeightRatio float64) loadbalance.Loadbalancer {
+	if halfOpenWeightRatio <= 0 {
+		halfOpenWeightRatio = DefaultHalfOpenWeightRatio
+	}
+	return &breakerAwareBalancer{lb: lb, suite: suite, halfOpenWeightRatio: halfOpenWeightRatio}
+}
+
+type breakerAwareBalancer struct {
+	lb                  loadbalance.Loadbalancer
+	suite               *CBSuite
+	halfOpenWeightRatio float64
+}
+
+// GetPicker implements the Loadbalancer interface.
+func (b *breakerAwareBalancer) GetPicker(e discovery.Result) loadbalance.Picker {
+	breakers := b.suite.InstancePanel().DumpBreakers()
+	if adjusted, changed := b.adjustInstances(e.Instances, breakers); changed {
+		// the degraded result must not be cached under the original key,
+		// it only holds until the breakers change state again
+		e = discovery.Result{Instances: adjusted}
+	}
+	return b.lb.GetPicker(e)
+}
+
+// Rebalance implements the Rebalancer interface.
+func (b *breakerAwareBalancer) Rebalance(change discovery.Change) {
+	if rb, ok := b.lb.(loadbalance.Rebalancer); ok {
+		rb.Rebalance(change)
+	}
+}
+
+// Delete implements the Rebalancer interface.
+func (b *breakerAwareBalancer) Delete(change discovery.Change) {
+	if rb, ok := b.lb.(loadbalance.Rebalancer); ok {
+		rb.Delete(change)
+	}
+}
+
+func (b *breakerAwareBalancer) Name() string {
+	return b.lb.Name() + "_breaker_aware"
+}
+
+func (b *breakerAwareBalancer) adjustInstances(
+	instances []discovery.Instance, breakers map[string]circuitbreaker.Breaker,
+) (adjusted []discovery.Instance, changed bool) {
+	if len(breakers) == 0 {
+		return instances, false
+	}
+	adjusted = make([]discovery.Instance, 0, len(instances))
+	for _, ins := range instances {
+		breaker, ok := breakers[ins.Address().String()]
+		if !ok {
+			adjusted = append(adjusted, ins)
+			continue
+		}
+		switch breaker.State() {
+		case circuitbreaker.Open:
+			changed = true
+		case circuitbreaker.HalfOpen:
+			weight := int(float64(ins.Weight()) * b.halfOpenWeightRatio)
+			if weight < 1 {
+				weight = 1
+			}
+			adjusted = append(adjusted, &weightedInstance{Instance: ins, weight: weight})
+			changed = true
+		default:
+			adjusted = append(adjusted, ins)
+		}
+	}
+	if !changed {
+		return instances, false
+	}
+	if len(adjusted) == 0 {
+		// all breakers are open, fail open and let the instance CB reject
+		return instances, false
+	}
+	return adjusted, true
+}
+
+// weightedInstance overrides the effective weight of a discovery instance.
+type weightedInstance struct {
+	discovery.Instance
+	weight int
+}
+
+func (w *weightedInstance) Weight() int {
+	return w.weight
+}
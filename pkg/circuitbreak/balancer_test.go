@@ -0,0 +1,89 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package circuitbreak
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bytedance/gopkg/cloud/circuitbreaker"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/discovery"
+	"github.com/cloudwego/kitex/pkg/loadbalance"
+)
+
+func TestBreakerAwareBalancer(t *testing.T) {
+	cb := NewCBSuite(RPCInfo2Key, WithCoolingTimeout(10*time.Millisecond))
+	defer cb.Close()
+	cb.UpdateInstanceCBConfig(CBConfig{Enable: true, TripStrategy: ConsecutiveTrip, ConsecutiveFailures: 1})
+
+	insA := discovery.NewInstance("tcp", "127.0.0.1:1", 10, nil)
+	insB := discovery.NewInstance("tcp", "127.0.0.1:2", 10, nil)
+	res := discovery.Result{Instances: []discovery.Instance{insA, insB}}
+	lb := NewBreakerAwareBalancer(loadbalance.NewWeightedRoundRobinBalancer(), cb, 0)
+	test.Assert(t, lb.Name() == "weight_round_robin_breaker_aware")
+
+	pick := func(n int) (countA, countB int) {
+		picker := lb.GetPicker(res)
+		for i := 0; i < n; i++ {
+			switch picker.Next(context.Background(), nil).Address().String() {
+			case insA.Address().String():
+				countA++
+			case insB.Address().String():
+				countB++
+			}
+		}
+		return
+	}
+
+	// both breakers closed: weights untouched
+	countA, countB := pick(100)
+	test.Assert(t, countA == countB, countA, countB)
+
+	// open breaker: the instance is removed from the pick set
+	panel := cb.InstancePanel()
+	panel.Fail(insB.Address().String())
+	test.Assert(t, panel.DumpBreakers()[insB.Address().String()].State() == circuitbreaker.Open)
+	countA, countB = pick(100)
+	test.Assert(t, countA == 100 && countB == 0, countA, countB)
+
+	// half-open breaker: the instance keeps a fraction of its weight
+	time.Sleep(20 * time.Millisecond)
+	panel.IsAllowed(insB.Address().String()) // flips the cooled-down breaker to half-open
+	test.Assert(t, panel.DumpBreakers()[insB.Address().String()].State() == circuitbreaker.HalfOpen)
+	countA, countB = pick(110)
+	test.Assert(t, countB > 0, countB)
+	test.Assert(t, countB*5 < countA, countA, countB)
+}
+
+func TestBreakerAwareBalancerAllOpen(t *testing.T) {
+	cb := NewCBSuite(RPCInfo2Key)
+	defer cb.Close()
+	cb.UpdateInstanceCBConfig(CBConfig{Enable: true, TripStrategy: ConsecutiveTrip, ConsecutiveFailures: 1})
+
+	ins := discovery.NewInstance("tcp", "127.0.0.1:1", 10, nil)
+	res := discovery.Result{Instances: []discovery.Instance{ins}}
+	lb := NewBreakerAwareBalancer(loadbalance.NewWeightedRoundRobinBalancer(), cb, 0)
+
+	cb.InstancePanel().Fail(ins.Address().String())
+	// every breaker is open: fail open and keep the original instances
+	picker := lb.GetPicker(res)
+	got := picker.Next(context.Background(), nil)
+	test.Assert(t, got.Address().String() == ins.Address().String())
+}
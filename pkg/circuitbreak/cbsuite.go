@@ -53,6 +53,14 @@ type CBConfig struct {
 	Enable    bool    `json:"enable"`
 	ErrRate   float64 `json:"err_rate"`
 	MinSample int64   `json:"min_sample"`
+
+	// TripStrategy selects how the breaker trips, empty means ErrorRateTrip;
+	// the fields below only take effect for the strategies that use them,
+	// see the TripStrategy consts.
+	TripStrategy         TripStrategy `json:"trip_strategy,omitempty"`
+	ConsecutiveFailures  int64        `json:"consecutive_failures,omitempty"`
+	ConseDurationMS      int64        `json:"conse_duration_ms,omitempty"`
+	ConseDurationSamples int64        `json:"conse_duration_samples,omitempty"`
 }
 
 // DeepCopy returns a full copy of CBConfig.
@@ -61,9 +69,13 @@ func (c *CBConfig) DeepCopy() *CBConfig {
 		return nil
 	}
 	return &CBConfig{
-		Enable:    c.Enable,
-		ErrRate:   c.ErrRate,
-		MinSample: c.MinSample,
+		Enable:               c.Enable,
+		ErrRate:              c.ErrRate,
+		MinSample:            c.MinSample,
+		TripStrategy:         c.TripStrategy,
+		ConsecutiveFailures:  c.ConsecutiveFailures,
+		ConseDurationMS:      c.ConseDurationMS,
+		ConseDurationSamples: c.ConseDurationSamples,
 	}
 }
 
@@ -74,7 +86,9 @@ func (c *CBConfig) Equals(other *CBConfig) bool {
 	if c == nil || other == nil {
 		return false
 	}
-	return c.Enable == other.Enable && c.ErrRate == other.ErrRate && c.MinSample == other.MinSample
+	return c.Enable == other.Enable && c.ErrRate == other.ErrRate && c.MinSample == other.MinSample &&
+		c.TripStrategy == other.TripStrategy && c.ConsecutiveFailures == other.ConsecutiveFailures &&
+		c.ConseDurationMS == other.ConseDurationMS && c.ConseDurationSamples == other.ConseDurationSamples
 }
 
 // GenServiceCBKeyFunc to generate circuit breaker key through rpcinfo.
@@ -99,11 +113,17 @@ type CBSuite struct {
 
 	genServiceCBKey GenServiceCBKeyFunc
 	serviceCBConfig *skipmap.StringMap // map[serviceCBKey]*CBConfig
+	adminOverrides  *skipmap.StringMap // map[cbKey]forcedOpen|forcedClosed
 
 	instanceCBConfig instanceCBConfig
 
 	events event.Queue
 
+	stateWatchers struct {
+		sync.RWMutex
+		handlers []StateChangeHandler
+	}
+
 	config CBSuiteConfig
 }
 
@@ -117,6 +137,7 @@ func NewCBSuite(genKey GenServiceCBKeyFunc, options ...CBSuiteOption) *CBSuite {
 			CBConfig: GetDefaultCBConfig(),
 		},
 		serviceCBConfig: skipmap.NewString(),
+		adminOverrides:  skipmap.NewString(),
 		config: CBSuiteConfig{
 			serviceGetErrorTypeFunc:  ErrorTypeOnServiceLevel,
 			instanceGetErrorTypeFunc: ErrorTypeOnInstanceLevel,
@@ -171,6 +192,14 @@ func (s *CBSuite) ServiceControl() *Control {
 	return s.serviceControl
 }
 
+// InstancePanel return cb Panel of instance
+func (s *CBSuite) InstancePanel() circuitbreaker.Panel {
+	if s.instancePanel == nil {
+		s.initInstanceCB()
+	}
+	return s.instancePanel
+}
+
 // UpdateServiceCBConfig is to update service CircuitBreaker config.
 // This func is suggested to be called in remote config module.
 func (s *CBSuite) UpdateServiceCBConfig(key string, cfg CBConfig) {
@@ -195,11 +224,15 @@ func (s *CBSuite) SetEventBusAndQueue(bus event.Bus, events event.Queue) {
 
 // Dump is to dump CircuitBreaker info for debug query.
 func (s *CBSuite) Dump() interface{} {
-	return map[string]interface{}{
+	info := map[string]interface{}{
 		serviceCBKey:  cbDebugInfo(s.servicePanel),
 		instanceCBKey: cbDebugInfo(s.instancePanel),
 		cbConfig:      s.configInfo(),
 	}
+	if overrides := s.adminOverrideInfo(); overrides != nil {
+		info[adminOverridesKey] = overrides
+	}
+	return info
 }
 
 // Close circuitbreaker.Panel to release associated resources.
@@ -227,13 +260,14 @@ func (s *CBSuite) initServiceCB() {
 	opts := circuitbreaker.Options{
 		ShouldTripWithKey: s.svcTripFunc,
 	}
-	s.servicePanel, _ = circuitbreaker.NewPanel(s.onServiceStateChange, opts)
+	s.applyProbeOptions(&opts)
+	panel, _ := circuitbreaker.NewPanel(s.onServiceStateChange, opts)
+	s.servicePanel = &adminPanel{Panel: panel, suite: s}
 
 	svcKey := func(ctx context.Context, request interface{}) (serviceCBKey string, enabled bool) {
 		ri := rpcinfo.GetRPCInfo(ctx)
 		serviceCBKey = s.genServiceCBKey(ri)
-		cbConfig, _ := s.serviceCBConfig.LoadOrStore(serviceCBKey, defaultCBConfig)
-		enabled = cbConfig.(*CBConfig).Enable
+		enabled = s.lookupServiceCBConfig(serviceCBKey).Enable
 		return
 	}
 	s.serviceControl = &Control{
@@ -252,7 +286,9 @@ func (s *CBSuite) initInstanceCB() {
 	opts := circuitbreaker.Options{
 		ShouldTripWithKey: s.insTripFunc,
 	}
-	s.instancePanel, _ = circuitbreaker.NewPanel(s.onInstanceStateChange, opts)
+	s.applyProbeOptions(&opts)
+	panel, _ := circuitbreaker.NewPanel(s.onInstanceStateChange, opts)
+	s.instancePanel = &adminPanel{Panel: panel, suite: s}
 
 	instanceKey := func(ctx context.Context, request interface{}) (instCBKey string, enabled bool) {
 		ri := rpcinfo.GetRPCInfo(ctx)
@@ -271,7 +307,21 @@ func (s *CBSuite) initInstanceCB() {
 	}
 }
 
+// applyProbeOptions maps the suite's half-open probe config onto the breaker
+// knobs: one probe every interval/quota and quota successes to close,
+// see WithHalfOpenProbe and WithCoolingTimeout.
+func (s *CBSuite) applyProbeOptions(opts *circuitbreaker.Options) {
+	if s.config.coolingTimeout > 0 {
+		opts.CoolingTimeout = s.config.coolingTimeout
+	}
+	if s.config.halfOpenProbeQuota > 0 && s.config.halfOpenProbeInterval > 0 {
+		opts.DetectTimeout = s.config.halfOpenProbeInterval / time.Duration(s.config.halfOpenProbeQuota)
+		opts.HalfOpenSuccesses = s.config.halfOpenProbeQuota
+	}
+}
+
 func (s *CBSuite) onStateChange(level, key string, oldState, newState circuitbreaker.State, m circuitbreaker.Metricer) {
+	s.notifyStateWatchers(level, key, oldState, newState, m)
 	if s.events == nil {
 		return
 	}
@@ -308,17 +358,14 @@ func (s *CBSuite) discoveryChangeHandler(e *event.Event) {
 }
 
 func (s *CBSuite) svcTripFunc(key string) circuitbreaker.TripFunc {
-	pi, _ := s.serviceCBConfig.LoadOrStore(key, defaultCBConfig)
-	p := pi.(*CBConfig)
-	return circuitbreaker.RateTripFunc(p.ErrRate, p.MinSample)
+	return tripFuncOfConfig(s.lookupServiceCBConfig(key))
 }
 
 func (s *CBSuite) insTripFunc(key string) circuitbreaker.TripFunc {
 	s.instanceCBConfig.RLock()
-	errRate := s.instanceCBConfig.ErrRate
-	minSample := s.instanceCBConfig.MinSample
+	cfg := s.instanceCBConfig.CBConfig
 	s.instanceCBConfig.RUnlock()
-	return circuitbreaker.RateTripFunc(errRate, minSample)
+	return tripFuncOfConfig(&cfg)
 }
 
 func cbDebugInfo(panel circuitbreaker.Panel) map[string]interface{} {
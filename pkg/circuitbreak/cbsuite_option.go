@@ -16,15 +16,42 @@
 
 package circuitbreak
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type CBSuiteConfig struct {
 	serviceGetErrorTypeFunc  GetErrorTypeFunc
 	instanceGetErrorTypeFunc GetErrorTypeFunc
+
+	coolingTimeout        time.Duration
+	halfOpenProbeQuota    int32
+	halfOpenProbeInterval time.Duration
 }
 
 type CBSuiteOption func(s *CBSuiteConfig)
 
+// WithCoolingTimeout sets how long an open breaker waits before it starts
+// half-open probing, for both the service and the instance level.
+func WithCoolingTimeout(timeout time.Duration) CBSuiteOption {
+	return func(cfg *CBSuiteConfig) {
+		cfg.coolingTimeout = timeout
+	}
+}
+
+// WithHalfOpenProbe makes half-open recovery explicit: after the cool-down
+// only `quota` probe requests per `interval` are let through, and the breaker
+// closes once `quota` consecutive probes succeed. State transitions are
+// observable through the event queue, see SetEventBusAndQueue.
+// Non-positive arguments keep the default probing behavior.
+func WithHalfOpenProbe(quota int32, interval time.Duration) CBSuiteOption {
+	return func(cfg *CBSuiteConfig) {
+		cfg.halfOpenProbeQuota = quota
+		cfg.halfOpenProbeInterval = interval
+	}
+}
+
 // WithServiceGetErrorType sets serviceControl.GetErrorType
 // Kitex will call customFunc to determine the error type for circuit breaker
 // Users are recommended to use WithWrappedServiceGetErrorType to keep most of the behaviors
@@ -65,6 +92,19 @@ func WithWrappedInstanceGetErrorType(f GetErrorTypeFunc) CBSuiteOption {
 	}
 }
 
+// WithErrorClassifier sets one classifier for the whole suite, replacing the
+// default error-type functions on both the SERVICE and the INSTANCE level.
+// The classifier decides whether an error counts as failure, timeout, success
+// or is ignored by the breaker; use IgnoreBizErrors to keep business
+// exceptions from tripping it. For level-specific classification use
+// WithServiceGetErrorType / WithInstanceGetErrorType instead.
+func WithErrorClassifier(f GetErrorTypeFunc) CBSuiteOption {
+	return func(cfg *CBSuiteConfig) {
+		cfg.serviceGetErrorTypeFunc = f
+		cfg.instanceGetErrorTypeFunc = f
+	}
+}
+
 // WrapErrorTypeFunc calls the customFunc if the originalFunc returns TypeSuccess
 // customFunc may selectively return another type based on business requirement
 func WrapErrorTypeFunc(customFunc, originalFunc GetErrorTypeFunc) GetErrorTypeFunc {
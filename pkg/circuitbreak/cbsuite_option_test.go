@@ -62,6 +62,20 @@ func TestWithWrappedServiceGetErrorType(t *testing.T) {
 	test.Assert(t, errType == TypeIgnorable)
 }
 
+func TestWithErrorClassifier(t *testing.T) {
+	cfg := &CBSuiteConfig{}
+
+	o := WithErrorClassifier(func(ctx context.Context, request, response interface{}, err error) ErrorType {
+		return TypeIgnorable
+	})
+	o(cfg)
+
+	errType := cfg.serviceGetErrorTypeFunc(context.Background(), nil, nil, errors.New("error"))
+	test.Assert(t, errType == TypeIgnorable)
+	errType = cfg.instanceGetErrorTypeFunc(context.Background(), nil, nil, errors.New("error"))
+	test.Assert(t, errType == TypeIgnorable)
+}
+
 func TestWithWrappedInstanceGetErrorType(t *testing.T) {
 	cfg := &CBSuiteConfig{}
 
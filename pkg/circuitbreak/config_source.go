@@ -0,0 +1,68 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package circuitbreak
+
+import (
+	"context"
+
+	"github.com/cloudwego/kitex/pkg/gofunc"
+)
+
+// ConfigChange is one config update pushed by a ConfigSource.
+type ConfigChange struct {
+	// Instance routes the change to the instance-level config when true;
+	// Key is ignored for instance-level changes.
+	Instance bool
+	// Key is the service breaker key the config applies to,
+	// wildcard keys are resolved like UpdateServiceCBConfig ones.
+	Key    string
+	Config CBConfig
+}
+
+// ConfigSource streams circuit breaker config changes, so config-center
+// extensions (etcd/nacos/apollo) can push threshold updates into a CBSuite
+// without custom glue in every service, see CBSuite.WatchConfigSource.
+type ConfigSource interface {
+	// Watch returns the change stream. The source closes the channel
+	// when it won't push any more changes.
+	Watch() <-chan ConfigChange
+}
+
+// WatchConfigSource applies every change streamed by src to the suite until
+// the returned stop func is called or the source closes its channel.
+func (s *CBSuite) WatchConfigSource(src ConfigSource) (stop func()) {
+	done := make(chan struct{})
+	gofunc.GoFunc(context.Background(), func() {
+		changes := src.Watch()
+		for {
+			select {
+			case change, ok := <-changes:
+				if !ok {
+					return
+				}
+				if change.Instance {
+					s.UpdateInstanceCBConfig(change.Config)
+				} else {
+					s.UpdateServiceCBConfig(change.Key, change.Config)
+				}
+			case <-done:
+				return
+			}
+		}
+	})
+	return func() { close(done) }
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package circuitbreak
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudwego/kitex/internal/test"
+)
+
+type mockConfigSource struct {
+	ch chan ConfigChange
+}
+
+func (m *mockConfigSource) Watch() <-chan ConfigChange {
+	return m.ch
+}
+
+func TestWatchConfigSource(t *testing.T) {
+	cb := NewCBSuite(RPCInfo2Key)
+	defer cb.Close()
+	src := &mockConfigSource{ch: make(chan ConfigChange)}
+	stop := cb.WatchConfigSource(src)
+
+	src.ch <- ConfigChange{Key: "svc_key", Config: CBConfig{Enable: true, ErrRate: 0.1, MinSample: 100}}
+	src.ch <- ConfigChange{Instance: true, Config: CBConfig{Enable: true, ErrRate: 0.2, MinSample: 100}}
+	waitCond(t, func() bool {
+		return cb.lookupServiceCBConfig("svc_key").ErrRate == 0.1
+	})
+	waitCond(t, func() bool {
+		cb.instanceCBConfig.RLock()
+		defer cb.instanceCBConfig.RUnlock()
+		return cb.instanceCBConfig.ErrRate == 0.2
+	})
+
+	// changes pushed after stop are discarded
+	stop()
+	time.Sleep(20 * time.Millisecond) // let the watcher goroutine observe the stop
+	select {
+	case src.ch <- ConfigChange{Key: "svc_key", Config: CBConfig{Enable: true, ErrRate: 0.9, MinSample: 100}}:
+	case <-time.After(200 * time.Millisecond):
+		// the watcher goroutine exited and nobody receives, which is fine too
+	}
+	time.Sleep(20 * time.Millisecond)
+	test.Assert(t, cb.lookupServiceCBConfig("svc_key").ErrRate == 0.1)
+}
+
+func TestWatchConfigSourceClosed(t *testing.T) {
+	cb := NewCBSuite(RPCInfo2Key)
+	defer cb.Close()
+	src := &mockConfigSource{ch: make(chan ConfigChange, 1)}
+	cb.WatchConfigSource(src)
+	src.ch <- ConfigChange{Key: "svc_key", Config: CBConfig{Enable: true, ErrRate: 0.3, MinSample: 100}}
+	close(src.ch)
+	waitCond(t, func() bool {
+		return cb.lookupServiceCBConfig("svc_key").ErrRate == 0.3
+	})
+}
+
+func waitCond(t *testing.T, cond func() bool) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		if cond() {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("condition not met in time")
+}
@@ -61,6 +61,42 @@ func ErrorTypeOnInstanceLevel(ctx context.Context, request, response interface{}
 	return TypeSuccess
 }
 
+// ErrorTypeOnServerLevel determines the error type with a server level criteria.
+// Handler timeouts count as timeout and other handler errors as failure, while
+// business exceptions are ignored since they don't indicate a degraded handler.
+func ErrorTypeOnServerLevel(ctx context.Context, request, response interface{}, err error) ErrorType {
+	if err != nil {
+		var we *errorWrapperWithType
+		if ok := errors.As(err, &we); ok {
+			return we.errType
+		}
+		var bizErr kerrors.BizStatusErrorIface
+		if errors.Is(err, kerrors.ErrBiz) || errors.As(err, &bizErr) {
+			return TypeIgnorable
+		}
+		if kerrors.IsTimeoutError(err) {
+			return TypeTimeout
+		}
+		return TypeFailure
+	}
+	return TypeSuccess
+}
+
+// IgnoreBizErrors wraps a classifier so that business exceptions are ignored
+// by the circuit breaker instead of counting as failures; all other errors
+// are classified by next.
+func IgnoreBizErrors(next GetErrorTypeFunc) GetErrorTypeFunc {
+	return func(ctx context.Context, request, response interface{}, err error) ErrorType {
+		if err != nil {
+			var bizErr kerrors.BizStatusErrorIface
+			if errors.Is(err, kerrors.ErrBiz) || errors.As(err, &bizErr) {
+				return TypeIgnorable
+			}
+		}
+		return next(ctx, request, response, err)
+	}
+}
+
 // FailIfError return TypeFailure if err is not nil, otherwise TypeSuccess.
 func FailIfError(ctx context.Context, request, response interface{}, err error) ErrorType {
 	if err != nil {
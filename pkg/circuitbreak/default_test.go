@@ -44,3 +44,11 @@ func TestNoDecoration(t *testing.T) {
 	err := errors.New("error")
 	test.Assert(t, errors.Is(NoDecoration(context.Background(), nil, err), err))
 }
+
+func TestIgnoreBizErrors(t *testing.T) {
+	f := IgnoreBizErrors(ErrorTypeOnServiceLevel)
+	test.Assert(t, f(context.Background(), nil, nil, nil) == TypeSuccess)
+	test.Assert(t, f(context.Background(), nil, nil, errors.New("")) == TypeFailure)
+	test.Assert(t, f(context.Background(), nil, nil, kerrors.NewBizStatusError(100, "biz")) == TypeIgnorable)
+	test.Assert(t, f(context.Background(), nil, nil, kerrors.ErrBiz.WithCause(errors.New("biz"))) == TypeIgnorable)
+}
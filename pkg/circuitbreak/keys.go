@@ -0,0 +1,102 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package circuitbreak
+
+import (
+	"strings"
+
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+// Built-in key strategies for GenServiceCBKeyFunc, so users don't have to
+// write key funcs by hand. Segments are joined with '/' like RPCInfo2Key,
+// and configs for the generated keys support wildcard lookup: a config stored
+// under "toService/*" applies to every method of that service unless a more
+// specific key is configured, and "*" is the global default.
+var (
+	// ServiceCBKey keys breakers by the destination service.
+	ServiceCBKey GenServiceCBKeyFunc = serviceCBKeyFunc
+
+	// ServiceMethodCBKey keys breakers by destination service and method.
+	ServiceMethodCBKey GenServiceCBKeyFunc = serviceMethodCBKeyFunc
+
+	// ServiceMethodInstanceCBKey keys breakers by destination service, method
+	// and instance address. The address is only known after an instance is
+	// picked, so this strategy suits suites whose middleware runs after load
+	// balancing; before that the address segment is empty.
+	ServiceMethodInstanceCBKey GenServiceCBKeyFunc = serviceMethodInstanceCBKeyFunc
+)
+
+func serviceCBKeyFunc(ri rpcinfo.RPCInfo) string {
+	if ri == nil {
+		return ""
+	}
+	return ri.To().ServiceName()
+}
+
+func serviceMethodCBKeyFunc(ri rpcinfo.RPCInfo) string {
+	if ri == nil {
+		return ""
+	}
+	return appendCBKey(ri.To().ServiceName(), ri.To().Method())
+}
+
+func serviceMethodInstanceCBKeyFunc(ri rpcinfo.RPCInfo) string {
+	if ri == nil {
+		return ""
+	}
+	var addr string
+	if a := ri.To().Address(); a != nil {
+		addr = a.String()
+	}
+	return appendCBKey(appendCBKey(ri.To().ServiceName(), ri.To().Method()), addr)
+}
+
+func appendCBKey(prefix, segment string) string {
+	var buf strings.Builder
+	buf.Grow(len(prefix) + len(segment) + 1)
+	buf.WriteString(prefix)
+	buf.WriteByte('/')
+	buf.WriteString(segment)
+	return buf.String()
+}
+
+// lookupServiceCBConfig resolves the config of a service breaker key: the
+// exact key wins, then wildcard keys with trailing segments replaced by "*"
+// from the most to the least specific, then the global "*", and finally the
+// package default. It lets platform-wide defaults be stored under wildcards
+// and overridden per method with UpdateServiceCBConfig.
+func (s *CBSuite) lookupServiceCBConfig(key string) *CBConfig {
+	if c, ok := s.serviceCBConfig.Load(key); ok {
+		return c.(*CBConfig)
+	}
+	for idx := strings.LastIndexByte(key, '/'); idx >= 0; idx = strings.LastIndexByte(key[:idx], '/') {
+		if c, ok := s.serviceCBConfig.Load(key[:idx] + "/*"); ok {
+			return c.(*CBConfig)
+		}
+	}
+	if c, ok := s.serviceCBConfig.Load(Wildcard); ok {
+		return c.(*CBConfig)
+	}
+	// no wildcard configured either, record the default under the exact key
+	// so it shows up in Dump like before
+	c, _ := s.serviceCBConfig.LoadOrStore(key, defaultCBConfig)
+	return c.(*CBConfig)
+}
+
+// Wildcard matches any breaker key segment in service CB configs.
+const Wildcard = "*"
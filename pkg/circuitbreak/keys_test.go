@@ -0,0 +1,96 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package circuitbreak
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+func TestBuiltinCBKeyFuncs(t *testing.T) {
+	from := rpcinfo.NewEndpointInfo("caller", "", nil, nil)
+	to := rpcinfo.NewEndpointInfo("callee", "method", addrMock, nil)
+	ri := rpcinfo.NewRPCInfo(from, to, nil, nil, nil)
+
+	test.Assert(t, ServiceCBKey(ri) == "callee")
+	test.Assert(t, ServiceMethodCBKey(ri) == "callee/method")
+	test.Assert(t, ServiceMethodInstanceCBKey(ri) == "callee/method/127.0.0.1:8888")
+
+	// the address segment is empty before an instance is picked
+	to = rpcinfo.NewEndpointInfo("callee", "method", nil, nil)
+	ri = rpcinfo.NewRPCInfo(from, to, nil, nil, nil)
+	test.Assert(t, ServiceMethodInstanceCBKey(ri) == "callee/method/")
+
+	test.Assert(t, ServiceCBKey(nil) == "")
+	test.Assert(t, ServiceMethodCBKey(nil) == "")
+	test.Assert(t, ServiceMethodInstanceCBKey(nil) == "")
+}
+
+func TestLookupServiceCBConfig(t *testing.T) {
+	cb := NewCBSuite(ServiceMethodCBKey)
+	defer cb.Close()
+
+	// nothing configured: fall back to the package default
+	cfg := cb.lookupServiceCBConfig("other/method")
+	test.Assert(t, *cfg == *defaultCBConfig)
+
+	// global wildcard
+	cb.UpdateServiceCBConfig(Wildcard, CBConfig{Enable: true, ErrRate: 0.1, MinSample: 100})
+	cfg = cb.lookupServiceCBConfig("callee/method")
+	test.Assert(t, cfg.ErrRate == 0.1, cfg)
+
+	// service-level wildcard beats the global one
+	cb.UpdateServiceCBConfig("callee/*", CBConfig{Enable: true, ErrRate: 0.2, MinSample: 100})
+	cfg = cb.lookupServiceCBConfig("callee/method")
+	test.Assert(t, cfg.ErrRate == 0.2, cfg)
+
+	// an exact key beats all wildcards
+	cb.UpdateServiceCBConfig("callee/method", CBConfig{Enable: true, ErrRate: 0.3, MinSample: 100})
+	cfg = cb.lookupServiceCBConfig("callee/method")
+	test.Assert(t, cfg.ErrRate == 0.3, cfg)
+
+	// sibling methods still resolve to the service wildcard
+	cfg = cb.lookupServiceCBConfig("callee/other")
+	test.Assert(t, cfg.ErrRate == 0.2, cfg)
+
+	// the most specific wildcard wins for multi-segment keys
+	cb.UpdateServiceCBConfig("callee/method/*", CBConfig{Enable: true, ErrRate: 0.4, MinSample: 100})
+	cfg = cb.lookupServiceCBConfig("callee/method/127.0.0.1:8888")
+	test.Assert(t, cfg.ErrRate == 0.4, cfg)
+	cfg = cb.lookupServiceCBConfig("callee/other/127.0.0.1:8888")
+	test.Assert(t, cfg.ErrRate == 0.2, cfg)
+}
+
+func TestWildcardServiceCBConfigEnables(t *testing.T) {
+	cb := NewCBSuite(ServiceMethodCBKey)
+	defer cb.Close()
+	cb.UpdateServiceCBConfig("callee/*", CBConfig{Enable: true, ErrRate: 0.5, MinSample: 10})
+
+	ctx := prepareCtx()
+	mws := cb.ServiceCBMW()
+	err := mws(func(ctx context.Context, request, response interface{}) error {
+		return nil
+	})(ctx, nil, nil)
+	test.Assert(t, err == nil, err)
+	// the breaker was created under the wildcard-resolved config
+	breakers := cb.ServicePanel().DumpBreakers()
+	_, ok := breakers["callee/method"]
+	test.Assert(t, ok, breakers)
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package circuitbreak
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/bytedance/gopkg/cloud/circuitbreaker"
+
+	"github.com/cloudwego/kitex/pkg/endpoint"
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+// ErrPanicIsolation is returned when a panic isolation breaker is open.
+var ErrPanicIsolation = kerrors.ErrCircuitBreak.WithCause(errors.New("panic isolation, handler panicked repeatedly"))
+
+const defaultConsecutivePanics = 5
+
+// PanicIsolator opens a breaker per key once the wrapped middleware chain or
+// handler panics consecutivePanics times in a row, so a poisoned request path
+// is short-circuited with a clear error instead of recovering panics at high
+// QPS. Recovered panics are returned as kerrors.ErrPanic with the stack
+// attached; the breaker cools down and closes again once probes stop
+// panicking.
+type PanicIsolator struct {
+	panel  circuitbreaker.Panel
+	genKey GenServiceCBKeyFunc
+}
+
+// NewPanicIsolator builds a PanicIsolator. genKey defaults to RPCInfo2Key
+// when nil and consecutivePanics falls back to a small default when
+// non-positive.
+func NewPanicIsolator(genKey GenServiceCBKeyFunc, consecutivePanics int64) *PanicIsolator {
+	if genKey == nil {
+		genKey = RPCInfo2Key
+	}
+	if consecutivePanics <= 0 {
+		consecutivePanics = defaultConsecutivePanics
+	}
+	p := &PanicIsolator{genKey: genKey}
+	p.panel, _ = circuitbreaker.NewPanel(nil, circuitbreaker.Options{
+		ShouldTrip: circuitbreaker.ConsecutiveTripFunc(consecutivePanics),
+	})
+	return p
+}
+
+// MW returns the panic isolation middleware. It should wrap the innermost
+// part of the chain that is suspected to panic.
+func (p *PanicIsolator) MW() endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request, response interface{}) (err error) {
+			key := p.genKey(rpcinfo.GetRPCInfo(ctx))
+			if !p.panel.IsAllowed(key) {
+				return ErrPanicIsolation
+			}
+			defer func() {
+				if r := recover(); r != nil {
+					p.panel.Fail(key)
+					err = kerrors.ErrPanic.WithCauseAndStack(
+						fmt.Errorf("[happened in PanicIsolator] %v", r), string(debug.Stack()))
+					return
+				}
+				// normal errors don't count, only panics trip this breaker
+				p.panel.Succeed(key)
+			}()
+			return next(ctx, request, response)
+		}
+	}
+}
+
+// Dump is to dump the isolation breaker states for debug query.
+func (p *PanicIsolator) Dump() interface{} {
+	return cbDebugInfo(p.panel)
+}
+
+// Close circuitbreaker.Panel to release associated resources.
+func (p *PanicIsolator) Close() error {
+	if p.panel != nil {
+		p.panel.Close()
+		p.panel = nil
+	}
+	return nil
+}
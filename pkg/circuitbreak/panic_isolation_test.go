@@ -0,0 +1,66 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package circuitbreak
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/kerrors"
+)
+
+func TestPanicIsolator(t *testing.T) {
+	p := NewPanicIsolator(nil, 3)
+	defer p.Close()
+
+	ctx := prepareCtx()
+	calls := 0
+	ep := p.MW()(func(ctx context.Context, req, resp interface{}) error {
+		calls++
+		panic("mock panic")
+	})
+
+	// panics below the threshold are recovered and returned as ErrPanic
+	for i := 0; i < 3; i++ {
+		err := ep(ctx, nil, nil)
+		test.Assert(t, errors.Is(err, kerrors.ErrPanic), err, i)
+	}
+	test.Assert(t, calls == 3, calls)
+
+	// the breaker is open now, the handler is not reached anymore
+	err := ep(ctx, nil, nil)
+	test.Assert(t, errors.Is(err, ErrPanicIsolation), err)
+	test.Assert(t, errors.Is(err, kerrors.ErrCircuitBreak), err)
+	test.Assert(t, calls == 3, calls)
+}
+
+func TestPanicIsolatorIgnoresErrors(t *testing.T) {
+	p := NewPanicIsolator(nil, 1)
+	defer p.Close()
+
+	ctx := prepareCtx()
+	ep := p.MW()(func(ctx context.Context, req, resp interface{}) error {
+		return errMock
+	})
+	// normal errors never trip the isolation breaker
+	for i := 0; i < 100; i++ {
+		err := ep(ctx, nil, nil)
+		test.Assert(t, errors.Is(err, errMock), err, i)
+	}
+}
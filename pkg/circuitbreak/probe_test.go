@@ -0,0 +1,54 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package circuitbreak
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bytedance/gopkg/cloud/circuitbreaker"
+
+	"github.com/cloudwego/kitex/internal/test"
+)
+
+func TestHalfOpenProbeQuota(t *testing.T) {
+	cb := NewCBSuite(RPCInfo2Key,
+		WithCoolingTimeout(50*time.Millisecond),
+		WithHalfOpenProbe(2, 100*time.Millisecond))
+	defer cb.Close()
+	cb.UpdateServiceCBConfig("svc_key", CBConfig{Enable: true, TripStrategy: ConsecutiveTrip, ConsecutiveFailures: 1})
+	panel := cb.ServicePanel()
+
+	panel.Fail("svc_key")
+	test.Assert(t, !panel.IsAllowed("svc_key"))
+	state := func() circuitbreaker.State { return panel.DumpBreakers()["svc_key"].State() }
+	test.Assert(t, state() == circuitbreaker.Open, state())
+
+	// after the cool-down exactly one probe per detect interval passes
+	time.Sleep(60 * time.Millisecond)
+	test.Assert(t, panel.IsAllowed("svc_key"))
+	test.Assert(t, state() == circuitbreaker.HalfOpen, state())
+	test.Assert(t, !panel.IsAllowed("svc_key"))
+	panel.Succeed("svc_key")
+	test.Assert(t, state() == circuitbreaker.HalfOpen, state())
+
+	// the second successful probe within the quota closes the breaker
+	time.Sleep(60 * time.Millisecond)
+	test.Assert(t, panel.IsAllowed("svc_key"))
+	panel.Succeed("svc_key")
+	test.Assert(t, state() == circuitbreaker.Closed, state())
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package circuitbreak
+
+import (
+	"context"
+
+	"github.com/bytedance/gopkg/cloud/circuitbreaker"
+	"github.com/bytedance/gopkg/collection/skipmap"
+
+	"github.com/cloudwego/kitex/pkg/endpoint"
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+const serverCBKey = "server"
+
+// CallerCBKey keys server-side breakers by the calling service, so one
+// misbehaving caller doesn't trip the breaker for everyone else.
+var CallerCBKey GenServiceCBKeyFunc = callerCBKeyFunc
+
+func callerCBKeyFunc(ri rpcinfo.RPCInfo) string {
+	if ri == nil {
+		return ""
+	}
+	return ri.From().ServiceName()
+}
+
+// ServerCBSuite is the server-side counterpart of CBSuite. It keeps one
+// breaker per caller and trips when that caller's requests consistently make
+// the handler fail or time out; tripped requests are rejected with
+// kerrors.ErrServerCircuitBreak before reaching the handler, so abusive
+// callers back off instead of piling onto a degraded handler. Use it with
+// server.WithMiddleware(cbs.ServerCBMW()).
+type ServerCBSuite struct {
+	panel   circuitbreaker.Panel
+	control *Control
+
+	genCallerCBKey GenServiceCBKeyFunc
+	callerCBConfig *skipmap.StringMap // map[callerCBKey]*CBConfig
+}
+
+// NewServerCBSuite builds a new ServerCBSuite. genKey decides how requests
+// map to breakers and defaults to CallerCBKey when nil.
+func NewServerCBSuite(genKey GenServiceCBKeyFunc) *ServerCBSuite {
+	s := &ServerCBSuite{
+		genCallerCBKey: genKey,
+		callerCBConfig: skipmap.NewString(),
+	}
+	if s.genCallerCBKey == nil {
+		s.genCallerCBKey = CallerCBKey
+	}
+	s.panel, _ = circuitbreaker.NewPanel(nil, circuitbreaker.Options{
+		ShouldTripWithKey: s.tripFunc,
+	})
+	s.control = &Control{
+		GetKey: func(ctx context.Context, request interface{}) (key string, enabled bool) {
+			ri := rpcinfo.GetRPCInfo(ctx)
+			key = s.genCallerCBKey(ri)
+			cfg, _ := s.callerCBConfig.LoadOrStore(key, defaultCBConfig)
+			enabled = cfg.(*CBConfig).Enable
+			return
+		},
+		GetErrorType: ErrorTypeOnServerLevel,
+		DecorateError: func(ctx context.Context, request interface{}, err error) error {
+			return kerrors.ErrServerCircuitBreak
+		},
+	}
+	return s
+}
+
+// ServerCBMW return the server level CircuitBreakerMW.
+func (s *ServerCBSuite) ServerCBMW() endpoint.Middleware {
+	if s == nil {
+		return endpoint.DummyMiddleware
+	}
+	return NewCircuitBreakerMW(*s.control, s.panel)
+}
+
+// UpdateCBConfig is to update the CircuitBreaker config of one caller key.
+// This func is suggested to be called in remote config module.
+func (s *ServerCBSuite) UpdateCBConfig(key string, cfg CBConfig) {
+	s.callerCBConfig.Store(key, &cfg)
+}
+
+// Panel return the cb Panel keyed by caller.
+func (s *ServerCBSuite) Panel() circuitbreaker.Panel {
+	return s.panel
+}
+
+// Dump is to dump CircuitBreaker info for debug query.
+func (s *ServerCBSuite) Dump() interface{} {
+	cfgMap := make(map[string]interface{})
+	s.callerCBConfig.Range(func(key string, value interface{}) bool {
+		cfgMap[key] = *value.(*CBConfig)
+		return true
+	})
+	return map[string]interface{}{
+		serverCBKey: cbDebugInfo(s.panel),
+		cbConfig:    cfgMap,
+	}
+}
+
+// Close circuitbreaker.Panel to release associated resources.
+func (s *ServerCBSuite) Close() error {
+	if s.panel != nil {
+		s.panel.Close()
+		s.panel = nil
+	}
+	return nil
+}
+
+func (s *ServerCBSuite) tripFunc(key string) circuitbreaker.TripFunc {
+	cfg, _ := s.callerCBConfig.LoadOrStore(key, defaultCBConfig)
+	return tripFuncOfConfig(cfg.(*CBConfig))
+}
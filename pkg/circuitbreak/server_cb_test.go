@@ -0,0 +1,102 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package circuitbreak
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+func ctxWithCaller(caller string) context.Context {
+	from := rpcinfo.NewEndpointInfo(caller, "", nil, nil)
+	to := rpcinfo.NewEndpointInfo("callee", "method", addrMock, nil)
+	ri := rpcinfo.NewRPCInfo(from, to, nil, nil, nil)
+	return rpcinfo.NewCtxWithRPCInfo(context.Background(), ri)
+}
+
+func TestServerCBSuite(t *testing.T) {
+	cbs := NewServerCBSuite(nil)
+	defer cbs.Close()
+	cbs.UpdateCBConfig("bad_caller", CBConfig{Enable: true, ErrRate: 0.5, MinSample: 10})
+
+	badCtx := ctxWithCaller("bad_caller")
+	goodCtx := ctxWithCaller("good_caller")
+	ep := cbs.ServerCBMW()(func(ctx context.Context, req, resp interface{}) error {
+		if rpcinfo.GetRPCInfo(ctx).From().ServiceName() == "bad_caller" {
+			return errMock
+		}
+		return nil
+	})
+
+	tripped := false
+	for i := 0; i < 100; i++ {
+		err := ep(badCtx, nil, nil)
+		if errors.Is(err, kerrors.ErrCircuitBreak) {
+			test.Assert(t, errors.Is(err, kerrors.ErrServerCircuitBreak), err)
+			tripped = true
+			break
+		}
+		test.Assert(t, errors.Is(err, errMock), err, i)
+	}
+	test.Assert(t, tripped)
+
+	// other callers are not affected by the tripped breaker
+	for i := 0; i < 10; i++ {
+		err := ep(goodCtx, nil, nil)
+		test.Assert(t, err == nil, err, i)
+	}
+
+	// the dump covers breaker states and per-caller configs
+	info, ok := cbs.Dump().(map[string]interface{})
+	test.Assert(t, ok)
+	test.Assert(t, info[serverCBKey] != nil)
+	cfgMap, ok := info[cbConfig].(map[string]interface{})
+	test.Assert(t, ok)
+	_, ok = cfgMap["bad_caller"].(CBConfig)
+	test.Assert(t, ok)
+}
+
+func TestServerCBSuiteDisabled(t *testing.T) {
+	cbs := NewServerCBSuite(nil)
+	defer cbs.Close()
+	cbs.UpdateCBConfig("caller", CBConfig{Enable: false})
+
+	ctx := ctxWithCaller("caller")
+	ep := cbs.ServerCBMW()(func(ctx context.Context, req, resp interface{}) error {
+		return errMock
+	})
+	for i := 0; i < 300; i++ {
+		err := ep(ctx, nil, nil)
+		test.Assert(t, errors.Is(err, errMock), err, i)
+	}
+}
+
+func TestErrorTypeOnServerLevel(t *testing.T) {
+	ctx := context.Background()
+	test.Assert(t, ErrorTypeOnServerLevel(ctx, nil, nil, nil) == TypeSuccess)
+	test.Assert(t, ErrorTypeOnServerLevel(ctx, nil, nil, errMock) == TypeFailure)
+	test.Assert(t, ErrorTypeOnServerLevel(ctx, nil, nil, kerrors.ErrRPCTimeout) == TypeTimeout)
+	bizErr := kerrors.NewBizStatusError(100, "biz")
+	test.Assert(t, ErrorTypeOnServerLevel(ctx, nil, nil, bizErr) == TypeIgnorable)
+	wrapped := WrapErrorWithType(errMock, TypeIgnorable)
+	test.Assert(t, ErrorTypeOnServerLevel(ctx, nil, nil, wrapped) == TypeIgnorable)
+}
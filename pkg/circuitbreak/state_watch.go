@@ -0,0 +1,75 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package circuitbreak
+
+import (
+	"github.com/bytedance/gopkg/cloud/circuitbreaker"
+
+	"github.com/cloudwego/kitex/pkg/diagnosis"
+)
+
+// Snapshot captures the breaker counters at the moment of a state transition.
+type Snapshot struct {
+	Successes   int64
+	Failures    int64
+	Timeouts    int64
+	ErrorRate   float64
+	ConseErrors int64
+}
+
+// StateChangeHandler is invoked on every breaker state transition of the
+// suite; level is "service" or "instance" and key is the breaker key.
+type StateChangeHandler func(level, key string, from, to circuitbreaker.State, snap Snapshot)
+
+// OnStateChange registers a watcher called on every breaker state transition,
+// e.g. to alert when a breaker opens. Watchers run on the breaker's execution
+// path and must not block. It is safe to register watchers at any time.
+func (s *CBSuite) OnStateChange(f StateChangeHandler) {
+	if f == nil {
+		return
+	}
+	s.stateWatchers.Lock()
+	s.stateWatchers.handlers = append(s.stateWatchers.handlers, f)
+	s.stateWatchers.Unlock()
+}
+
+// RegisterDiagnosis is the default stats exporter: it registers the suite's
+// breaker states and configs with the diagnosis service, so operators can
+// query them through the debug facilities.
+func (s *CBSuite) RegisterDiagnosis(ds diagnosis.Service) {
+	diagnosis.RegisterProbeFunc(ds, diagnosis.CircuitInfoKey, s.Dump)
+}
+
+func (s *CBSuite) notifyStateWatchers(level, key string, from, to circuitbreaker.State, m circuitbreaker.Metricer) {
+	s.stateWatchers.RLock()
+	handlers := s.stateWatchers.handlers
+	s.stateWatchers.RUnlock()
+	if len(handlers) == 0 {
+		return
+	}
+	successes, failures, timeouts := m.Counts()
+	snap := Snapshot{
+		Successes:   successes,
+		Failures:    failures,
+		Timeouts:    timeouts,
+		ErrorRate:   m.ErrorRate(),
+		ConseErrors: m.ConseErrors(),
+	}
+	for _, h := range handlers {
+		h(level, key, from, to, snap)
+	}
+}
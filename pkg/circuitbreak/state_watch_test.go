@@ -0,0 +1,88 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package circuitbreak
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bytedance/gopkg/cloud/circuitbreaker"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/diagnosis"
+)
+
+func TestOnStateChange(t *testing.T) {
+	cb := NewCBSuite(RPCInfo2Key)
+	defer cb.Close()
+	cb.UpdateServiceCBConfig("svc_key", CBConfig{Enable: true, TripStrategy: ConsecutiveTrip, ConsecutiveFailures: 1})
+
+	var mu sync.Mutex
+	type transition struct {
+		level, key string
+		from, to   circuitbreaker.State
+		snap       Snapshot
+	}
+	var got []transition
+	cb.OnStateChange(func(level, key string, from, to circuitbreaker.State, snap Snapshot) {
+		mu.Lock()
+		got = append(got, transition{level, key, from, to, snap})
+		mu.Unlock()
+	})
+	cb.OnStateChange(nil) // no-op
+
+	cb.ServicePanel().Fail("svc_key")
+	// the underlying breaker notifies asynchronously
+	for i := 0; i < 100; i++ {
+		mu.Lock()
+		n := len(got)
+		mu.Unlock()
+		if n > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	test.Assert(t, len(got) == 1, got)
+	test.Assert(t, got[0].level == serviceCBKey)
+	test.Assert(t, got[0].key == "svc_key")
+	test.Assert(t, got[0].from == circuitbreaker.Closed && got[0].to == circuitbreaker.Open, got[0])
+	test.Assert(t, got[0].snap.Failures == 1, got[0].snap)
+	test.Assert(t, got[0].snap.ConseErrors == 1, got[0].snap)
+}
+
+type mockDiagnosisService struct {
+	probes map[diagnosis.ProbeName]diagnosis.ProbeFunc
+}
+
+func (m *mockDiagnosisService) RegisterProbeFunc(name diagnosis.ProbeName, pf diagnosis.ProbeFunc) {
+	m.probes[name] = pf
+}
+
+func TestRegisterDiagnosis(t *testing.T) {
+	cb := NewCBSuite(RPCInfo2Key)
+	defer cb.Close()
+	ds := &mockDiagnosisService{probes: map[diagnosis.ProbeName]diagnosis.ProbeFunc{}}
+	cb.RegisterDiagnosis(ds)
+	pf := ds.probes[diagnosis.CircuitInfoKey]
+	test.Assert(t, pf != nil)
+	info, ok := pf().(map[string]interface{})
+	test.Assert(t, ok)
+	test.Assert(t, info[cbConfig] != nil)
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package circuitbreak
+
+import (
+	"context"
+	"time"
+
+	"github.com/bytedance/gopkg/cloud/circuitbreaker"
+
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+	"github.com/cloudwego/kitex/pkg/stats"
+)
+
+// TripStrategy selects how a circuit breaker decides to trip. It is part of
+// CBConfig, so the strategy can be chosen per service key with
+// UpdateServiceCBConfig and for the instance level with UpdateInstanceCBConfig.
+type TripStrategy string
+
+const (
+	// ErrorRateTrip trips when the error rate over the stat window reaches
+	// CBConfig.ErrRate with at least CBConfig.MinSample samples. It is the
+	// default and keeps the historical behavior of CBSuite.
+	ErrorRateTrip TripStrategy = "error_rate"
+
+	// ConsecutiveTrip trips after CBConfig.ConsecutiveFailures consecutive
+	// failures, catching hard-down destinations long before enough samples
+	// accumulate for the rate rule.
+	ConsecutiveTrip TripStrategy = "consecutive"
+
+	// SlidingWindowTrip combines the error-rate rule over the time-bucketed
+	// stat window with the consecutive-failure rules: it also trips when
+	// CBConfig.ConsecutiveFailures errors happen in a row, or when errors
+	// keep coming for CBConfig.ConseDurationMS with at least
+	// CBConfig.ConseDurationSamples of them.
+	SlidingWindowTrip TripStrategy = "sliding_window"
+)
+
+// defaultConsecutiveFailures is used when a consecutive strategy is selected
+// without a threshold.
+const defaultConsecutiveFailures = 5
+
+// tripFuncOfConfig builds the trip func for the strategy selected in p,
+// falling back to the error-rate rule for unknown strategies.
+func tripFuncOfConfig(p *CBConfig) circuitbreaker.TripFunc {
+	switch p.TripStrategy {
+	case ConsecutiveTrip:
+		threshold := p.ConsecutiveFailures
+		if threshold <= 0 {
+			threshold = defaultConsecutiveFailures
+		}
+		return circuitbreaker.ConsecutiveTripFunc(threshold)
+	case SlidingWindowTrip:
+		conseFailures := p.ConsecutiveFailures
+		if conseFailures <= 0 {
+			conseFailures = defaultConsecutiveFailures
+		}
+		return circuitbreaker.ConsecutiveTripFuncV2(p.ErrRate, p.MinSample,
+			time.Duration(p.ConseDurationMS)*time.Millisecond, p.ConseDurationSamples, conseFailures)
+	default:
+		return circuitbreaker.RateTripFunc(p.ErrRate, p.MinSample)
+	}
+}
+
+// SlowCallErrorType classifies calls slower than threshold as timeouts, so
+// slow-call rate trips the breaker like real timeouts. It only inspects
+// successful calls and is meant to be composed with the default
+// classification, e.g.
+//
+//	NewCBSuite(key, WithWrappedServiceGetErrorType(SlowCallErrorType(300*time.Millisecond)))
+func SlowCallErrorType(threshold time.Duration) GetErrorTypeFunc {
+	return func(ctx context.Context, request, response interface{}, err error) ErrorType {
+		if err != nil {
+			return TypeSuccess
+		}
+		if ri := rpcinfo.GetRPCInfo(ctx); ri != nil {
+			if start := ri.Stats().GetEvent(stats.RPCStart); start != nil && time.Since(start.Time()) >= threshold {
+				return TypeTimeout
+			}
+		}
+		return TypeSuccess
+	}
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package circuitbreak
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+	"github.com/cloudwego/kitex/pkg/stats"
+)
+
+// mockMetricer implements circuitbreaker.Metricer for trip func tests.
+type mockMetricer struct {
+	successes, failures, timeouts, conseErrors int64
+	conseTime                                  time.Duration
+}
+
+func (m *mockMetricer) Failures() int64           { return m.failures }
+func (m *mockMetricer) Successes() int64          { return m.successes }
+func (m *mockMetricer) Timeouts() int64           { return m.timeouts }
+func (m *mockMetricer) ConseErrors() int64        { return m.conseErrors }
+func (m *mockMetricer) ConseTime() time.Duration  { return m.conseTime }
+func (m *mockMetricer) Samples() int64            { return m.successes + m.failures + m.timeouts }
+func (m *mockMetricer) ErrorRate() float64 {
+	if m.Samples() == 0 {
+		return 0
+	}
+	return float64(m.failures+m.timeouts) / float64(m.Samples())
+}
+
+func (m *mockMetricer) Counts() (successes, failures, timeouts int64) {
+	return m.successes, m.failures, m.timeouts
+}
+
+func TestTripFuncOfConfig(t *testing.T) {
+	// default strategy keeps the error-rate rule
+	trip := tripFuncOfConfig(&CBConfig{ErrRate: 0.5, MinSample: 10})
+	test.Assert(t, !trip(&mockMetricer{successes: 1, failures: 8}))
+	test.Assert(t, trip(&mockMetricer{successes: 2, failures: 8}))
+
+	// consecutive strategy ignores the rate and watches the streak
+	trip = tripFuncOfConfig(&CBConfig{TripStrategy: ConsecutiveTrip, ConsecutiveFailures: 3})
+	test.Assert(t, !trip(&mockMetricer{failures: 100, conseErrors: 2}))
+	test.Assert(t, trip(&mockMetricer{failures: 3, conseErrors: 3}))
+	// threshold falls back to the default when unset
+	trip = tripFuncOfConfig(&CBConfig{TripStrategy: ConsecutiveTrip})
+	test.Assert(t, !trip(&mockMetricer{conseErrors: defaultConsecutiveFailures - 1}))
+	test.Assert(t, trip(&mockMetricer{conseErrors: defaultConsecutiveFailures}))
+
+	// sliding window trips on rate, duration or streak
+	trip = tripFuncOfConfig(&CBConfig{
+		TripStrategy: SlidingWindowTrip, ErrRate: 0.5, MinSample: 10,
+		ConsecutiveFailures: 5, ConseDurationMS: 100, ConseDurationSamples: 2,
+	})
+	test.Assert(t, trip(&mockMetricer{successes: 2, failures: 8}))
+	test.Assert(t, trip(&mockMetricer{conseErrors: 5}))
+	test.Assert(t, trip(&mockMetricer{conseErrors: 2, conseTime: 200 * time.Millisecond}))
+	test.Assert(t, !trip(&mockMetricer{successes: 8, failures: 2, conseErrors: 1}))
+}
+
+func TestCBSuiteTripStrategy(t *testing.T) {
+	cb := NewCBSuite(RPCInfo2Key)
+	defer cb.Close()
+	cb.UpdateServiceCBConfig("svc_key", CBConfig{Enable: true, TripStrategy: ConsecutiveTrip, ConsecutiveFailures: 2})
+	cb.UpdateInstanceCBConfig(CBConfig{Enable: true, TripStrategy: ConsecutiveTrip, ConsecutiveFailures: 2})
+	svc := cb.ServicePanel()
+	inst := cb.InstancePanel()
+	svc.Fail("svc_key")
+	svc.Fail("svc_key")
+	test.Assert(t, !svc.IsAllowed("svc_key"))
+	inst.Fail("inst_key")
+	inst.Fail("inst_key")
+	test.Assert(t, !inst.IsAllowed("inst_key"))
+}
+
+func TestSlowCallErrorType(t *testing.T) {
+	classify := SlowCallErrorType(10 * time.Millisecond)
+	// errors are left to the base classification
+	test.Assert(t, classify(context.Background(), nil, nil, errors.New("mock")) == TypeSuccess)
+	// no rpcinfo means nothing to measure
+	test.Assert(t, classify(context.Background(), nil, nil, nil) == TypeSuccess)
+
+	st := rpcinfo.AsMutableRPCStats(rpcinfo.NewRPCStats())
+	st.SetLevel(stats.LevelBase)
+	ri := rpcinfo.NewRPCInfo(nil, nil, nil, nil, st.ImmutableView())
+	ctx := rpcinfo.NewCtxWithRPCInfo(context.Background(), ri)
+	ri.Stats().Record(ctx, stats.RPCStart, stats.StatusInfo, "")
+	test.Assert(t, classify(ctx, nil, nil, nil) == TypeSuccess)
+	time.Sleep(15 * time.Millisecond)
+	test.Assert(t, classify(ctx, nil, nil, nil) == TypeTimeout)
+}
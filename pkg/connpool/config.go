@@ -22,8 +22,11 @@ import "time"
 type IdleConfig struct {
 	MinIdlePerAddress int
 	MaxIdlePerAddress int
-	MaxIdleGlobal     int
-	MaxIdleTimeout    time.Duration
+	// MaxActivePerAddress caps connections (idle and in-use) to one address,
+	// 0 means no limit. Getting a connection beyond the cap fails immediately.
+	MaxActivePerAddress int
+	MaxIdleGlobal       int
+	MaxIdleTimeout      time.Duration
 }
 
 const (
@@ -57,6 +60,14 @@ func CheckPoolConfig(config IdleConfig) *IdleConfig {
 		config.MaxIdlePerAddress = config.MinIdlePerAddress
 	}
 
+	// activePerAddress
+	if config.MaxActivePerAddress < 0 {
+		config.MaxActivePerAddress = 0
+	}
+	if config.MaxActivePerAddress > 0 && config.MaxActivePerAddress < config.MaxIdlePerAddress {
+		config.MaxActivePerAddress = config.MaxIdlePerAddress
+	}
+
 	// globalIdle
 	if config.MaxIdleGlobal <= 0 {
 		config.MaxIdleGlobal = defaultMaxIdleGlobal
@@ -45,6 +45,12 @@ func TestCheckPoolConfig(t *testing.T) {
 	cfg = CheckPoolConfig(IdleConfig{MaxIdlePerAddress: -1})
 	test.Assert(t, cfg.MaxIdlePerAddress == 1)
 
+	// maxActive
+	cfg = CheckPoolConfig(IdleConfig{MaxActivePerAddress: -1})
+	test.Assert(t, cfg.MaxActivePerAddress == 0)
+	cfg = CheckPoolConfig(IdleConfig{MaxActivePerAddress: 1, MaxIdlePerAddress: 2})
+	test.Assert(t, cfg.MaxActivePerAddress == 2)
+
 	// maxIdleGlobal
 	cfg = CheckPoolConfig(IdleConfig{MaxIdleGlobal: 9, MaxIdlePerAddress: 10, MinIdlePerAddress: 1})
 	test.Assert(t, cfg.MaxIdleGlobal == 10)
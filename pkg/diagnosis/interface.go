@@ -51,6 +51,7 @@ const (
 	DestServiceKey ProbeName = "dest_service"
 	ConnPoolKey    ProbeName = "conn_pool"
 	RetryPolicyKey ProbeName = "retry_policy"
+	CircuitInfoKey ProbeName = "circuit_info"
 )
 
 // WrapAsProbeFunc is to wrap probe data as ProbeFunc, the data is some infos that you want to diagnosis, like config info.
@@ -0,0 +1,145 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bytedance/gopkg/lang/fastrand"
+
+	"github.com/cloudwego/kitex/pkg/gofunc"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+const (
+	// DefaultCacheTTL is how long a cached result counts as fresh.
+	DefaultCacheTTL = 10 * time.Second
+	// DefaultMaxStaleness is how old a cached result may grow while being
+	// served stale; beyond it Resolve blocks on the underlying resolver.
+	DefaultMaxStaleness = 5 * time.Minute
+)
+
+// CacheOptions configures WithCache.
+type CacheOptions struct {
+	// TTL is how long a result is served without consulting the underlying
+	// resolver, DefaultCacheTTL when 0.
+	TTL time.Duration
+	// Jitter randomizes each entry's effective TTL by [0, Jitter) so entries
+	// don't refresh in lockstep, a tenth of TTL when 0.
+	Jitter time.Duration
+	// MaxStaleness caps how old a result may be served while a refresh runs
+	// in the background; older entries block on a synchronous resolve.
+	// DefaultMaxStaleness when 0.
+	MaxStaleness time.Duration
+}
+
+// WithCache wraps a resolver with stale-while-revalidate caching: results
+// fresher than TTL are served from cache, results older than TTL but within
+// MaxStaleness are served immediately while a background refresh runs, and
+// anything older blocks on the underlying resolver. It shields the registry
+// from per-request lookups and hides its latency spikes.
+func WithCache(resolver Resolver, opts CacheOptions) Resolver {
+	if opts.TTL <= 0 {
+		opts.TTL = DefaultCacheTTL
+	}
+	if opts.Jitter <= 0 {
+		opts.Jitter = opts.TTL / 10
+	}
+	if opts.MaxStaleness <= 0 {
+		opts.MaxStaleness = DefaultMaxStaleness
+	}
+	if opts.MaxStaleness < opts.TTL {
+		opts.MaxStaleness = opts.TTL
+	}
+	return &cachedResolver{resolver: resolver, opts: opts}
+}
+
+type cachedResolver struct {
+	resolver Resolver
+	opts     CacheOptions
+	entries  sync.Map // desc -> *cacheEntry
+}
+
+type cacheEntry struct {
+	result     Result
+	fetchedAt  int64 // unix nano
+	expiresAt  int64 // unix nano, fetchedAt + TTL + per-entry jitter
+	refreshing int32
+}
+
+// Target implements the Resolver interface.
+func (r *cachedResolver) Target(ctx context.Context, target rpcinfo.EndpointInfo) string {
+	return r.resolver.Target(ctx, target)
+}
+
+// Resolve implements the Resolver interface.
+func (r *cachedResolver) Resolve(ctx context.Context, desc string) (Result, error) {
+	now := time.Now().UnixNano()
+	e, ok := r.entries.Load(desc)
+	if !ok {
+		return r.refresh(ctx, desc)
+	}
+	entry := e.(*cacheEntry)
+	if now < atomic.LoadInt64(&entry.expiresAt) {
+		return entry.result, nil
+	}
+	if now-atomic.LoadInt64(&entry.fetchedAt) < int64(r.opts.MaxStaleness) {
+		// stale but acceptable: serve it now, refresh in the background
+		if atomic.CompareAndSwapInt32(&entry.refreshing, 0, 1) {
+			gofunc.GoFunc(context.Background(), func() {
+				defer atomic.StoreInt32(&entry.refreshing, 0)
+				r.refresh(context.Background(), desc)
+			})
+		}
+		return entry.result, nil
+	}
+	// too stale to serve, block on the underlying resolver
+	result, err := r.refresh(ctx, desc)
+	if err != nil {
+		return Result{}, err
+	}
+	return result, nil
+}
+
+// refresh resolves through the underlying resolver and stores the result.
+func (r *cachedResolver) refresh(ctx context.Context, desc string) (Result, error) {
+	result, err := r.resolver.Resolve(ctx, desc)
+	if err != nil {
+		return Result{}, err
+	}
+	now := time.Now().UnixNano()
+	ttl := int64(r.opts.TTL) + int64(fastrand.Int63n(int64(r.opts.Jitter)))
+	r.entries.Store(desc, &cacheEntry{
+		result:    result,
+		fetchedAt: now,
+		expiresAt: now + ttl,
+	})
+	return result, nil
+}
+
+// Diff implements the Resolver interface.
+func (r *cachedResolver) Diff(cacheKey string, prev, next Result) (Change, bool) {
+	return r.resolver.Diff(cacheKey, prev, next)
+}
+
+// Name implements the Resolver interface.
+func (r *cachedResolver) Name() string {
+	return "cached:" + r.resolver.Name()
+}
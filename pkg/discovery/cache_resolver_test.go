@@ -0,0 +1,96 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/kitex/internal/test"
+)
+
+func countingResolver(calls *int32, result Result) Resolver {
+	return SynthesizedResolver{
+		NameFunc: func() string { return "mock" },
+		ResolveFunc: func(ctx context.Context, key string) (Result, error) {
+			atomic.AddInt32(calls, 1)
+			return result, nil
+		},
+	}
+}
+
+func TestCachedResolverFresh(t *testing.T) {
+	var calls int32
+	good := Result{Instances: []Instance{NewInstance("tcp", "addr1", 10, nil)}}
+	r := WithCache(countingResolver(&calls, good), CacheOptions{TTL: time.Second})
+	test.Assert(t, r.Name() == "cached:mock")
+
+	for i := 0; i < 10; i++ {
+		res, err := r.Resolve(context.Background(), "svc")
+		test.Assert(t, err == nil, err)
+		test.Assert(t, len(res.Instances) == 1)
+	}
+	// fresh entries are served without hitting the underlying resolver
+	test.Assert(t, atomic.LoadInt32(&calls) == 1, calls)
+}
+
+func TestCachedResolverStaleWhileRevalidate(t *testing.T) {
+	var calls int32
+	good := Result{Instances: []Instance{NewInstance("tcp", "addr1", 10, nil)}}
+	r := WithCache(countingResolver(&calls, good), CacheOptions{
+		TTL:          10 * time.Millisecond,
+		Jitter:       time.Millisecond,
+		MaxStaleness: time.Minute,
+	})
+
+	_, err := r.Resolve(context.Background(), "svc")
+	test.Assert(t, err == nil, err)
+	time.Sleep(20 * time.Millisecond) // let the entry expire
+
+	// the stale entry answers immediately and triggers a background refresh
+	begin := time.Now()
+	res, err := r.Resolve(context.Background(), "svc")
+	test.Assert(t, err == nil, err)
+	test.Assert(t, len(res.Instances) == 1)
+	test.Assert(t, time.Since(begin) < 5*time.Millisecond)
+
+	for i := 0; i < 100 && atomic.LoadInt32(&calls) < 2; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	test.Assert(t, atomic.LoadInt32(&calls) == 2, calls)
+}
+
+func TestCachedResolverMaxStaleness(t *testing.T) {
+	var calls int32
+	good := Result{Instances: []Instance{NewInstance("tcp", "addr1", 10, nil)}}
+	r := WithCache(countingResolver(&calls, good), CacheOptions{
+		TTL:          time.Millisecond,
+		Jitter:       time.Millisecond,
+		MaxStaleness: 5 * time.Millisecond,
+	})
+
+	_, err := r.Resolve(context.Background(), "svc")
+	test.Assert(t, err == nil, err)
+	time.Sleep(10 * time.Millisecond) // beyond MaxStaleness
+
+	// too stale: Resolve blocks on the underlying resolver synchronously
+	_, err = r.Resolve(context.Background(), "svc")
+	test.Assert(t, err == nil, err)
+	test.Assert(t, atomic.LoadInt32(&calls) == 2, calls)
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+// DefaultResolveTimeout bounds how long the chain resolver waits on each
+// resolver before moving on to the next one.
+const DefaultResolveTimeout = 3 * time.Second
+
+var errEmptyResolveResult = errors.New("discovery: resolver returned no instance")
+
+// NewChainResolver composes a primary resolver with fallbacks: fallbacks are
+// queried in order when the primary errors, times out or returns no instance,
+// and the last successful result is served as a stale last resort when every
+// resolver fails, so losing the registry doesn't black-hole traffic.
+func NewChainResolver(primary Resolver, fallbacks ...Resolver) Resolver {
+	return NewChainResolverWithTimeout(DefaultResolveTimeout, primary, fallbacks...)
+}
+
+// NewChainResolverWithTimeout is NewChainResolver with a custom per-resolver
+// timeout.
+func NewChainResolverWithTimeout(timeout time.Duration, primary Resolver, fallbacks ...Resolver) Resolver {
+	resolvers := make([]Resolver, 0, 1+len(fallbacks))
+	resolvers = append(resolvers, primary)
+	resolvers = append(resolvers, fallbacks...)
+	names := make([]string, len(resolvers))
+	for i := range resolvers {
+		names[i] = resolvers[i].Name()
+	}
+	return &chainResolver{
+		resolvers: resolvers,
+		timeout:   timeout,
+		name:      "chain:" + strings.Join(names, ","),
+	}
+}
+
+type chainResolver struct {
+	resolvers []Resolver
+	timeout   time.Duration
+	name      string
+	lastGood  sync.Map // desc -> Result
+}
+
+// Target implements the Resolver interface; the primary resolver's
+// description keys the whole chain.
+func (r *chainResolver) Target(ctx context.Context, target rpcinfo.EndpointInfo) string {
+	return r.resolvers[0].Target(ctx, target)
+}
+
+// Resolve implements the Resolver interface.
+func (r *chainResolver) Resolve(ctx context.Context, desc string) (Result, error) {
+	var lastErr error
+	for _, resolver := range r.resolvers {
+		result, err := r.resolveOne(ctx, resolver, desc)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		r.lastGood.Store(desc, result)
+		return result, nil
+	}
+	if cached, ok := r.lastGood.Load(desc); ok {
+		// stale last resort: a previously good instance list beats no list
+		return cached.(Result), nil
+	}
+	return Result{}, lastErr
+}
+
+type resolveResult struct {
+	result Result
+	err    error
+}
+
+// resolveOne runs one resolver bounded by the chain's timeout; resolvers not
+// honoring the context are abandoned, not interrupted.
+func (r *chainResolver) resolveOne(ctx context.Context, resolver Resolver, desc string) (Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	done := make(chan resolveResult, 1)
+	go func() {
+		result, err := resolver.Resolve(ctx, desc)
+		if err == nil && len(result.Instances) == 0 {
+			err = errEmptyResolveResult
+		}
+		done <- resolveResult{result: result, err: err}
+	}()
+	select {
+	case res := <-done:
+		return res.result, res.err
+	case <-ctx.Done():
+		return Result{}, ctx.Err()
+	}
+}
+
+// Diff implements the Resolver interface.
+func (r *chainResolver) Diff(cacheKey string, prev, next Result) (Change, bool) {
+	return DefaultDiff(cacheKey, prev, next)
+}
+
+// Name implements the Resolver interface.
+func (r *chainResolver) Name() string {
+	return r.name
+}
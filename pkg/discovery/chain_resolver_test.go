@@ -0,0 +1,105 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/kitex/internal/test"
+)
+
+func staticResolver(name string, result Result, err error) Resolver {
+	return SynthesizedResolver{
+		NameFunc: func() string { return name },
+		ResolveFunc: func(ctx context.Context, key string) (Result, error) {
+			return result, err
+		},
+	}
+}
+
+func TestChainResolverFallback(t *testing.T) {
+	good := Result{Instances: []Instance{NewInstance("tcp", "addr1", 10, nil)}}
+
+	// primary errors: the fallback answers
+	r := NewChainResolver(
+		staticResolver("primary", Result{}, errors.New("registry down")),
+		staticResolver("fallback", good, nil),
+	)
+	test.Assert(t, r.Name() == "chain:primary,fallback", r.Name())
+	res, err := r.Resolve(context.Background(), "svc")
+	test.Assert(t, err == nil, err)
+	test.Assert(t, len(res.Instances) == 1)
+
+	// primary returns no instance: also falls through
+	r = NewChainResolver(
+		staticResolver("primary", Result{}, nil),
+		staticResolver("fallback", good, nil),
+	)
+	res, err = r.Resolve(context.Background(), "svc")
+	test.Assert(t, err == nil, err)
+	test.Assert(t, len(res.Instances) == 1)
+}
+
+func TestChainResolverStaleCache(t *testing.T) {
+	good := Result{Instances: []Instance{NewInstance("tcp", "addr1", 10, nil)}}
+	fail := errors.New("registry down")
+	healthy := true
+	primary := SynthesizedResolver{
+		NameFunc: func() string { return "primary" },
+		ResolveFunc: func(ctx context.Context, key string) (Result, error) {
+			if healthy {
+				return good, nil
+			}
+			return Result{}, fail
+		},
+	}
+
+	r := NewChainResolver(primary)
+	_, err := r.Resolve(context.Background(), "svc")
+	test.Assert(t, err == nil, err)
+
+	// the registry dies: the last good result keeps being served
+	healthy = false
+	res, err := r.Resolve(context.Background(), "svc")
+	test.Assert(t, err == nil, err)
+	test.Assert(t, len(res.Instances) == 1)
+
+	// a target never resolved has nothing stale to serve
+	_, err = r.Resolve(context.Background(), "other")
+	test.Assert(t, err == fail, err)
+}
+
+func TestChainResolverTimeout(t *testing.T) {
+	good := Result{Instances: []Instance{NewInstance("tcp", "addr1", 10, nil)}}
+	slow := SynthesizedResolver{
+		NameFunc: func() string { return "slow" },
+		ResolveFunc: func(ctx context.Context, key string) (Result, error) {
+			time.Sleep(200 * time.Millisecond)
+			return good, nil
+		},
+	}
+
+	r := NewChainResolverWithTimeout(20*time.Millisecond, slow, staticResolver("fallback", good, nil))
+	begin := time.Now()
+	res, err := r.Resolve(context.Background(), "svc")
+	test.Assert(t, err == nil, err)
+	test.Assert(t, len(res.Instances) == 1)
+	test.Assert(t, time.Since(begin) < 100*time.Millisecond)
+}
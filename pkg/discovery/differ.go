@@ -0,0 +1,135 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+// Differ computes the same changes as DefaultDiff against a retained
+// snapshot instead of two throwaway hash maps per refresh. For targets with
+// tens of thousands of instances, rebuilding those maps every refresh
+// interval dominates allocations and causes GC spikes; a Differ allocates
+// nothing on the steady-state no-change path. A Differ serves one target and
+// is not safe for concurrent use.
+type Differ struct {
+	entries map[string]differEntry
+	seq     uint64
+}
+
+type differEntry struct {
+	instance Instance
+	seen     uint64
+}
+
+// NewDiffer creates an empty Differ; the first Diff reports every instance
+// as added.
+func NewDiffer() *Differ {
+	return &Differ{entries: make(map[string]differEntry)}
+}
+
+// Diff computes the change from the retained snapshot to next and retains
+// next. The Change mirrors what DefaultDiff would report given the previous
+// result.
+func (d *Differ) Diff(cacheKey string, next Result) (Change, bool) {
+	d.seq++
+	ch := Change{
+		Result: Result{
+			Cacheable: next.Cacheable,
+			CacheKey:  cacheKey,
+			Instances: next.Instances,
+		},
+	}
+	for _, ins := range next.Instances {
+		addr := ins.Address().String()
+		prev, found := d.entries[addr]
+		if !found {
+			ch.Added = append(ch.Added, ins)
+		} else if prev.instance.Weight() != ins.Weight() {
+			ch.Updated = append(ch.Updated, ins)
+		} else {
+			// unchanged: keep the retained instance so snapshots share it
+			ins = prev.instance
+		}
+		d.entries[addr] = differEntry{instance: ins, seen: d.seq}
+	}
+	if len(d.entries) > len(next.Instances) {
+		for addr, e := range d.entries {
+			if e.seen != d.seq {
+				ch.Removed = append(ch.Removed, e.instance)
+				delete(d.entries, addr)
+			}
+		}
+	}
+	return ch, len(ch.Added)+len(ch.Updated)+len(ch.Removed) != 0
+}
+
+// WithIncrementalDiff wraps a resolver so Diff runs through per-target
+// Differs, trading DefaultDiff's per-refresh map churn for one retained
+// snapshot per target. Use it for very large clusters where refresh-time
+// allocations show up in GC pauses.
+func WithIncrementalDiff(resolver Resolver) Resolver {
+	return &incrementalDiffResolver{resolver: resolver}
+}
+
+type incrementalDiffResolver struct {
+	resolver Resolver
+	differs  sync.Map // cacheKey -> *lockedDiffer
+}
+
+type lockedDiffer struct {
+	mu sync.Mutex
+	d  *Differ
+}
+
+// Target implements the Resolver interface.
+func (r *incrementalDiffResolver) Target(ctx context.Context, target rpcinfo.EndpointInfo) string {
+	return r.resolver.Target(ctx, target)
+}
+
+// Resolve implements the Resolver interface.
+func (r *incrementalDiffResolver) Resolve(ctx context.Context, desc string) (Result, error) {
+	return r.resolver.Resolve(ctx, desc)
+}
+
+// Diff implements the Resolver interface; prev is ignored in favor of the
+// retained snapshot, which DefaultDiff semantics make equivalent.
+func (r *incrementalDiffResolver) Diff(cacheKey string, prev, next Result) (Change, bool) {
+	v, loaded := r.differs.Load(cacheKey)
+	if !loaded {
+		if v, loaded = r.differs.LoadOrStore(cacheKey, &lockedDiffer{d: NewDiffer()}); !loaded {
+			// seed the fresh differ so its first answer diffs against prev
+			// rather than reporting everything as added
+			ld := v.(*lockedDiffer)
+			ld.mu.Lock()
+			ld.d.Diff(cacheKey, prev)
+			ld.mu.Unlock()
+		}
+	}
+	ld := v.(*lockedDiffer)
+	ld.mu.Lock()
+	defer ld.mu.Unlock()
+	return ld.d.Diff(cacheKey, next)
+}
+
+// Name implements the Resolver interface.
+func (r *incrementalDiffResolver) Name() string {
+	return r.resolver.Name()
+}
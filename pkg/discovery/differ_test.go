@@ -0,0 +1,100 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+)
+
+func TestDifferMatchesDefaultDiff(t *testing.T) {
+	prev := Result{Instances: []Instance{
+		NewInstance("tcp", "1", 10, nil),
+		NewInstance("tcp", "2", 10, nil),
+		NewInstance("tcp", "3", 10, nil),
+	}}
+	next := Result{Cacheable: true, Instances: []Instance{
+		NewInstance("tcp", "1", 10, nil),
+		NewInstance("tcp", "3", 20, nil),
+		NewInstance("tcp", "4", 10, nil),
+	}}
+
+	d := NewDiffer()
+	d.Diff("key", prev)
+	got, changed := d.Diff("key", next)
+	want, _ := DefaultDiff("key", prev, next)
+
+	test.Assert(t, changed)
+	test.Assert(t, len(got.Added) == 1 && got.Added[0].Address().String() == "4")
+	test.Assert(t, len(got.Updated) == 1 && got.Updated[0].Address().String() == "3")
+	test.Assert(t, got.Updated[0].Weight() == 20)
+	test.Assert(t, len(got.Removed) == 1 && got.Removed[0].Address().String() == "2")
+	test.Assert(t, got.Result.Cacheable == want.Result.Cacheable)
+	test.Assert(t, got.Result.CacheKey == want.Result.CacheKey)
+
+	// the steady state reports no change
+	_, changed = d.Diff("key", next)
+	test.Assert(t, !changed)
+}
+
+func TestWithIncrementalDiff(t *testing.T) {
+	prev := Result{Instances: []Instance{NewInstance("tcp", "1", 10, nil)}}
+	next := Result{Instances: []Instance{
+		NewInstance("tcp", "1", 10, nil),
+		NewInstance("tcp", "2", 10, nil),
+	}}
+	r := WithIncrementalDiff(staticResolver("mock", next, nil))
+	test.Assert(t, r.Name() == "mock")
+
+	// the first Diff is seeded with prev, not reported as all-added
+	ch, changed := r.Diff("key", prev, next)
+	test.Assert(t, changed)
+	test.Assert(t, len(ch.Added) == 1 && ch.Added[0].Address().String() == "2")
+
+	_, changed = r.Diff("key", prev, next)
+	test.Assert(t, !changed)
+}
+
+func benchmarkInstances(n int) []Instance {
+	instances := make([]Instance, n)
+	for i := range instances {
+		instances[i] = NewInstance("tcp", fmt.Sprintf("10.%d.%d.%d:8888", i>>16&0xff, i>>8&0xff, i&0xff), 10, nil)
+	}
+	return instances
+}
+
+func BenchmarkDefaultDiffNoChange(b *testing.B) {
+	res := Result{Instances: benchmarkInstances(50000)}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		DefaultDiff("key", res, res)
+	}
+}
+
+func BenchmarkDifferNoChange(b *testing.B) {
+	res := Result{Instances: benchmarkInstances(50000)}
+	d := NewDiffer()
+	d.Diff("key", res)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d.Diff("key", res)
+	}
+}
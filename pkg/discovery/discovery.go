@@ -69,6 +69,55 @@ type Resolver interface {
 	Name() string
 }
 
+// Watcher is an optional interface for resolvers backed by registries that
+// push updates (etcd, nacos, zookeeper, xDS). When a resolver implements it,
+// the internal balancer cache subscribes to changes instead of relying only
+// on the periodic refresh, eliminating the refresh-interval latency.
+type Watcher interface {
+	// Watch subscribes to changes of the given target description. The
+	// returned channel delivers a Change whenever the instance list changes;
+	// implementations should close it when ctx is done.
+	Watch(ctx context.Context, desc string) (<-chan Change, error)
+}
+
+// NormalizeChange folds pairs of Removed and Added instances sharing an
+// address into Updated entries. Resolvers whose Diff reports a reweighted
+// instance as remove-plus-add would otherwise reset every state keyed by
+// address downstream (warmup ramps, circuit breaker stats, in-flight
+// counters), which hurts registries that adjust weights frequently.
+// Changes produced by DefaultDiff are already in this form.
+func NormalizeChange(ch Change) Change {
+	if len(ch.Added) == 0 || len(ch.Removed) == 0 {
+		return ch
+	}
+	added := make(map[string]Instance, len(ch.Added))
+	for _, ins := range ch.Added {
+		added[ins.Address().String()] = ins
+	}
+	removed := ch.Removed[:0:0]
+	for _, ins := range ch.Removed {
+		addr := ins.Address().String()
+		next, ok := added[addr]
+		if !ok {
+			removed = append(removed, ins)
+			continue
+		}
+		delete(added, addr)
+		if next.Weight() != ins.Weight() {
+			ch.Updated = append(ch.Updated, next)
+		}
+	}
+	ch.Removed = removed
+	kept := ch.Added[:0:0]
+	for _, ins := range ch.Added {
+		if _, ok := added[ins.Address().String()]; ok {
+			kept = append(kept, ins)
+		}
+	}
+	ch.Added = kept
+	return ch
+}
+
 // DefaultDiff provides a natural implementation for the Diff method of the Resolver interface.
 func DefaultDiff(cacheKey string, prev, next Result) (Change, bool) {
 	ch := Change{
@@ -74,3 +74,41 @@ func TestDefaultDiff(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeChange(t *testing.T) {
+	// a reweighted instance reported as remove+add becomes an update,
+	// genuine additions and removals are kept
+	got := NormalizeChange(Change{
+		Added: []Instance{
+			NewInstance("tcp", "1", 20, nil),
+			NewInstance("tcp", "5", 10, nil),
+		},
+		Removed: []Instance{
+			NewInstance("tcp", "1", 10, nil),
+			NewInstance("tcp", "4", 10, nil),
+		},
+	})
+	want := Change{
+		Added:   []Instance{NewInstance("tcp", "5", 10, nil)},
+		Updated: []Instance{NewInstance("tcp", "1", 20, nil)},
+		Removed: []Instance{NewInstance("tcp", "4", 10, nil)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizeChange() got = %v, want %v", got, want)
+	}
+
+	// a same-weight remove+add pair is dropped entirely
+	got = NormalizeChange(Change{
+		Added:   []Instance{NewInstance("tcp", "1", 10, nil)},
+		Removed: []Instance{NewInstance("tcp", "1", 10, nil)},
+	})
+	if len(got.Added)+len(got.Updated)+len(got.Removed) != 0 {
+		t.Errorf("NormalizeChange() got = %v, want empty", got)
+	}
+
+	// changes without overlap pass through untouched
+	in := Change{Added: []Instance{NewInstance("tcp", "1", 10, nil)}}
+	if got = NormalizeChange(in); !reflect.DeepEqual(got, in) {
+		t.Errorf("NormalizeChange() got = %v, want %v", got, in)
+	}
+}
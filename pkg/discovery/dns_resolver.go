@@ -0,0 +1,206 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+// IPPolicy controls which address families a DNS resolver returns.
+type IPPolicy int
+
+const (
+	// IPPolicyAll returns every resolved address, IPv4 and IPv6 alike.
+	IPPolicyAll IPPolicy = iota
+	// IPPolicyPreferIPv4 returns only IPv4 addresses when any exist.
+	IPPolicyPreferIPv4
+	// IPPolicyPreferIPv6 returns only IPv6 addresses when any exist.
+	IPPolicyPreferIPv6
+	// IPPolicyIPv4Only drops every IPv6 address.
+	IPPolicyIPv4Only
+	// IPPolicyIPv6Only drops every IPv4 address.
+	IPPolicyIPv6Only
+)
+
+// DefaultDNSCacheTTL is how long DNS answers are reused between lookups.
+// The Go runtime resolver does not expose per-record TTLs, so a fixed TTL
+// stands in for them.
+const DefaultDNSCacheTTL = 10 * time.Second
+
+var errNoResolvableAddr = errors.New("dns resolver: no resolvable address")
+
+// DNSOptions configures NewDNSResolver.
+type DNSOptions struct {
+	// Resolver performs the lookups, net.DefaultResolver when nil.
+	Resolver *net.Resolver
+	// SRV makes the resolver treat the target description as an SRV name
+	// (e.g. a Kubernetes headless service); ports and weights then come from
+	// the SRV records instead of the description.
+	SRV bool
+	// DefaultPort is used when neither the description nor an SRV record
+	// carries a port.
+	DefaultPort string
+	// IPPolicy selects the address families to return, IPPolicyAll when unset.
+	IPPolicy IPPolicy
+	// CacheTTL is how long answers are reused before the next lookup,
+	// DefaultDNSCacheTTL when 0; set negative to look up on every Resolve.
+	CacheTTL time.Duration
+}
+
+// NewDNSResolver creates a resolver backed by plain DNS: A/AAAA lookups for
+// host[:port] descriptions, or SRV lookups when opts.SRV is set. Answers are
+// cached for CacheTTL via WithCache so per-request resolution doesn't hammer
+// the DNS server; the refresh cadence beyond that is the balancer cache's
+// refresh interval.
+func NewDNSResolver(opts DNSOptions) Resolver {
+	if opts.Resolver == nil {
+		opts.Resolver = net.DefaultResolver
+	}
+	r := &dnsResolver{
+		opts:      opts,
+		lookupIP:  opts.Resolver.LookupIPAddr,
+		lookupSRV: opts.Resolver.LookupSRV,
+	}
+	if opts.CacheTTL < 0 {
+		return r
+	}
+	if opts.CacheTTL == 0 {
+		opts.CacheTTL = DefaultDNSCacheTTL
+	}
+	return WithCache(r, CacheOptions{TTL: opts.CacheTTL})
+}
+
+type dnsResolver struct {
+	opts DNSOptions
+	// lookup funcs are fields so tests can stub the DNS server away
+	lookupIP  func(ctx context.Context, host string) ([]net.IPAddr, error)
+	lookupSRV func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+}
+
+// Target implements the Resolver interface.
+func (r *dnsResolver) Target(ctx context.Context, target rpcinfo.EndpointInfo) string {
+	if addr := target.Address(); addr != nil {
+		return addr.String()
+	}
+	return target.ServiceName()
+}
+
+// Resolve implements the Resolver interface.
+func (r *dnsResolver) Resolve(ctx context.Context, desc string) (Result, error) {
+	if r.opts.SRV {
+		return r.resolveSRV(ctx, desc)
+	}
+	host, port, err := net.SplitHostPort(desc)
+	if err != nil {
+		host, port = desc, r.opts.DefaultPort
+	}
+	ips, err := r.lookupIP(ctx, host)
+	if err != nil {
+		return Result{}, err
+	}
+	ips = selectIPs(ips, r.opts.IPPolicy)
+	instances := make([]Instance, 0, len(ips))
+	for _, ip := range ips {
+		instances = append(instances, NewInstance("tcp", net.JoinHostPort(ip.IP.String(), port), DefaultWeight, nil))
+	}
+	if len(instances) == 0 {
+		return Result{}, errNoResolvableAddr
+	}
+	return Result{Cacheable: true, CacheKey: desc, Instances: instances}, nil
+}
+
+// resolveSRV expands SRV records into instances; each record's target is
+// resolved to addresses and its weight carried over.
+func (r *dnsResolver) resolveSRV(ctx context.Context, name string) (Result, error) {
+	_, srvs, err := r.lookupSRV(ctx, "", "", name)
+	if err != nil {
+		return Result{}, err
+	}
+	var instances []Instance
+	for _, srv := range srvs {
+		ips, err := r.lookupIP(ctx, srv.Target)
+		if err != nil {
+			continue // dead targets shouldn't fail the whole record set
+		}
+		weight := int(srv.Weight)
+		if weight <= 0 {
+			weight = DefaultWeight
+		}
+		port := strconv.Itoa(int(srv.Port))
+		if srv.Port == 0 && r.opts.DefaultPort != "" {
+			port = r.opts.DefaultPort
+		}
+		for _, ip := range selectIPs(ips, r.opts.IPPolicy) {
+			instances = append(instances, NewInstance("tcp", net.JoinHostPort(ip.IP.String(), port), weight, nil))
+		}
+	}
+	if len(instances) == 0 {
+		return Result{}, errNoResolvableAddr
+	}
+	return Result{Cacheable: true, CacheKey: name, Instances: instances}, nil
+}
+
+// selectIPs applies the address family policy to a lookup answer.
+func selectIPs(ips []net.IPAddr, policy IPPolicy) []net.IPAddr {
+	if policy == IPPolicyAll {
+		return ips
+	}
+	var v4, v6 []net.IPAddr
+	for _, ip := range ips {
+		if ip.IP.To4() != nil {
+			v4 = append(v4, ip)
+		} else {
+			v6 = append(v6, ip)
+		}
+	}
+	switch policy {
+	case IPPolicyIPv4Only:
+		return v4
+	case IPPolicyIPv6Only:
+		return v6
+	case IPPolicyPreferIPv4:
+		if len(v4) > 0 {
+			return v4
+		}
+		return v6
+	case IPPolicyPreferIPv6:
+		if len(v6) > 0 {
+			return v6
+		}
+		return v4
+	}
+	return ips
+}
+
+// Diff implements the Resolver interface.
+func (r *dnsResolver) Diff(cacheKey string, prev, next Result) (Change, bool) {
+	return DefaultDiff(cacheKey, prev, next)
+}
+
+// Name implements the Resolver interface.
+func (r *dnsResolver) Name() string {
+	if r.opts.SRV {
+		return "dns_srv"
+	}
+	return "dns"
+}
@@ -0,0 +1,113 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+)
+
+func testDNSResolver(opts DNSOptions) *dnsResolver {
+	r := &dnsResolver{opts: opts}
+	r.lookupIP = func(ctx context.Context, host string) ([]net.IPAddr, error) {
+		switch host {
+		case "svc.example.com", "pod1.svc.example.com":
+			return []net.IPAddr{{IP: net.ParseIP("10.0.0.1")}, {IP: net.ParseIP("fd00::1")}}, nil
+		case "pod2.svc.example.com":
+			return []net.IPAddr{{IP: net.ParseIP("10.0.0.2")}}, nil
+		}
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	r.lookupSRV = func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+		if name != "_grpc._tcp.svc.example.com" {
+			return "", nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+		}
+		return name, []*net.SRV{
+			{Target: "pod1.svc.example.com", Port: 8888, Weight: 20},
+			{Target: "pod2.svc.example.com", Port: 8889, Weight: 0},
+			{Target: "gone.svc.example.com", Port: 8890, Weight: 10},
+		}, nil
+	}
+	return r
+}
+
+func TestDNSResolver(t *testing.T) {
+	r := testDNSResolver(DNSOptions{})
+	res, err := r.Resolve(context.Background(), "svc.example.com:8888")
+	test.Assert(t, err == nil, err)
+	test.Assert(t, res.Cacheable)
+	test.Assert(t, res.CacheKey == "svc.example.com:8888")
+	test.Assert(t, len(res.Instances) == 2)
+	test.Assert(t, res.Instances[0].Address().String() == "10.0.0.1:8888")
+	test.Assert(t, res.Instances[1].Address().String() == "[fd00::1]:8888")
+
+	// a description without a port falls back to DefaultPort
+	r = testDNSResolver(DNSOptions{DefaultPort: "9999"})
+	res, err = r.Resolve(context.Background(), "svc.example.com")
+	test.Assert(t, err == nil, err)
+	test.Assert(t, res.Instances[0].Address().String() == "10.0.0.1:9999")
+
+	_, err = r.Resolve(context.Background(), "missing.example.com:8888")
+	test.Assert(t, err != nil)
+}
+
+func TestDNSResolverIPPolicy(t *testing.T) {
+	cases := []struct {
+		policy IPPolicy
+		addrs  []string
+	}{
+		{IPPolicyAll, []string{"10.0.0.1:80", "[fd00::1]:80"}},
+		{IPPolicyPreferIPv4, []string{"10.0.0.1:80"}},
+		{IPPolicyPreferIPv6, []string{"[fd00::1]:80"}},
+		{IPPolicyIPv4Only, []string{"10.0.0.1:80"}},
+		{IPPolicyIPv6Only, []string{"[fd00::1]:80"}},
+	}
+	for _, c := range cases {
+		r := testDNSResolver(DNSOptions{IPPolicy: c.policy})
+		res, err := r.Resolve(context.Background(), "svc.example.com:80")
+		test.Assert(t, err == nil, err)
+		test.Assert(t, len(res.Instances) == len(c.addrs), c.policy)
+		for i := range c.addrs {
+			test.Assert(t, res.Instances[i].Address().String() == c.addrs[i], c.policy)
+		}
+	}
+}
+
+func TestDNSResolverSRV(t *testing.T) {
+	r := testDNSResolver(DNSOptions{SRV: true, IPPolicy: IPPolicyIPv4Only})
+	test.Assert(t, r.Name() == "dns_srv")
+	res, err := r.Resolve(context.Background(), "_grpc._tcp.svc.example.com")
+	test.Assert(t, err == nil, err)
+	// the dead SRV target is skipped, the others expand to their addresses
+	test.Assert(t, len(res.Instances) == 2, len(res.Instances))
+	test.Assert(t, res.Instances[0].Address().String() == "10.0.0.1:8888")
+	test.Assert(t, res.Instances[0].Weight() == 20)
+	test.Assert(t, res.Instances[1].Address().String() == "10.0.0.2:8889")
+	// zero SRV weight falls back to the default weight
+	test.Assert(t, res.Instances[1].Weight() == DefaultWeight)
+}
+
+func TestNewDNSResolverCaching(t *testing.T) {
+	// the default construction wraps the resolver with the TTL cache
+	r := NewDNSResolver(DNSOptions{})
+	test.Assert(t, r.Name() == "cached:dns")
+	r = NewDNSResolver(DNSOptions{CacheTTL: -1})
+	test.Assert(t, r.Name() == "dns")
+}
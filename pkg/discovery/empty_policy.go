@@ -0,0 +1,96 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+// EmptyPolicy decides what an empty resolve result (zero instances, or an
+// error) turns into. Registries flap: a momentarily empty answer during a
+// leader election or rolling restart shouldn't immediately surface as
+// "no instance available".
+type EmptyPolicy int
+
+const (
+	// EmptyPolicyFailFast surfaces the empty result as an error right away,
+	// matching the behavior without a policy.
+	EmptyPolicyFailFast EmptyPolicy = iota
+	// EmptyPolicyUseLastKnown serves the last non-empty result instead; the
+	// error only surfaces when the target never resolved successfully.
+	EmptyPolicyUseLastKnown
+	// EmptyPolicyUseFallback asks the fallback resolver, then falls back to
+	// the last non-empty result like EmptyPolicyUseLastKnown.
+	EmptyPolicyUseFallback
+)
+
+// WithEmptyPolicy wraps a resolver with an EmptyPolicy. The fallback resolver
+// is only consulted under EmptyPolicyUseFallback and may be nil otherwise.
+func WithEmptyPolicy(resolver Resolver, policy EmptyPolicy, fallback Resolver) Resolver {
+	return &emptyPolicyResolver{resolver: resolver, policy: policy, fallback: fallback}
+}
+
+type emptyPolicyResolver struct {
+	resolver Resolver
+	policy   EmptyPolicy
+	fallback Resolver
+	lastGood sync.Map // desc -> Result
+}
+
+// Target implements the Resolver interface.
+func (r *emptyPolicyResolver) Target(ctx context.Context, target rpcinfo.EndpointInfo) string {
+	return r.resolver.Target(ctx, target)
+}
+
+// Resolve implements the Resolver interface.
+func (r *emptyPolicyResolver) Resolve(ctx context.Context, desc string) (Result, error) {
+	res, err := r.resolver.Resolve(ctx, desc)
+	if err == nil && len(res.Instances) == 0 {
+		err = errEmptyResolveResult
+	}
+	if err == nil {
+		r.lastGood.Store(desc, res)
+		return res, nil
+	}
+	switch r.policy {
+	case EmptyPolicyUseFallback:
+		if r.fallback != nil {
+			if res, ferr := r.fallback.Resolve(ctx, desc); ferr == nil && len(res.Instances) > 0 {
+				return res, nil
+			}
+		}
+		fallthrough
+	case EmptyPolicyUseLastKnown:
+		if last, ok := r.lastGood.Load(desc); ok {
+			return last.(Result), nil
+		}
+	}
+	return Result{}, err
+}
+
+// Diff implements the Resolver interface.
+func (r *emptyPolicyResolver) Diff(cacheKey string, prev, next Result) (Change, bool) {
+	return r.resolver.Diff(cacheKey, prev, next)
+}
+
+// Name implements the Resolver interface.
+func (r *emptyPolicyResolver) Name() string {
+	return r.resolver.Name()
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+)
+
+func TestEmptyPolicyFailFast(t *testing.T) {
+	r := WithEmptyPolicy(staticResolver("mock", Result{}, nil), EmptyPolicyFailFast, nil)
+	_, err := r.Resolve(context.Background(), "svc")
+	test.Assert(t, err == errEmptyResolveResult, err)
+}
+
+func TestEmptyPolicyUseLastKnown(t *testing.T) {
+	good := Result{Instances: []Instance{NewInstance("tcp", "addr1", 10, nil)}}
+	result := good
+	inner := SynthesizedResolver{
+		NameFunc: func() string { return "mock" },
+		ResolveFunc: func(ctx context.Context, key string) (Result, error) {
+			return result, nil
+		},
+	}
+	r := WithEmptyPolicy(inner, EmptyPolicyUseLastKnown, nil)
+	test.Assert(t, r.Name() == "mock")
+
+	_, err := r.Resolve(context.Background(), "svc")
+	test.Assert(t, err == nil, err)
+
+	// the registry flaps to empty: the last known result keeps being served
+	result = Result{}
+	res, err := r.Resolve(context.Background(), "svc")
+	test.Assert(t, err == nil, err)
+	test.Assert(t, len(res.Instances) == 1)
+
+	// a target that never resolved still fails
+	_, err = r.Resolve(context.Background(), "other")
+	test.Assert(t, err == errEmptyResolveResult, err)
+}
+
+func TestEmptyPolicyUseFallback(t *testing.T) {
+	good := Result{Instances: []Instance{NewInstance("tcp", "addr1", 10, nil)}}
+	r := WithEmptyPolicy(
+		staticResolver("mock", Result{}, nil),
+		EmptyPolicyUseFallback,
+		staticResolver("fallback", good, nil),
+	)
+	res, err := r.Resolve(context.Background(), "svc")
+	test.Assert(t, err == nil, err)
+	test.Assert(t, len(res.Instances) == 1)
+
+	// an empty fallback degrades to last-known, then to the error
+	r = WithEmptyPolicy(
+		staticResolver("mock", Result{}, nil),
+		EmptyPolicyUseFallback,
+		staticResolver("fallback", Result{}, nil),
+	)
+	_, err = r.Resolve(context.Background(), "svc")
+	test.Assert(t, err == errEmptyResolveResult, err)
+}
@@ -0,0 +1,166 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+// DefaultFilePollInterval is how often the file resolver checks the file's
+// modification time for hot reload.
+const DefaultFilePollInterval = 10 * time.Second
+
+// FileOptions configures NewFileResolver.
+type FileOptions struct {
+	// PollInterval is how often the file's modification time is checked for
+	// hot reload, DefaultFilePollInterval when 0.
+	PollInterval time.Duration
+}
+
+// fileInstance is one instance entry of the resolver file.
+type fileInstance struct {
+	Network string            `json:"network" yaml:"network"`
+	Address string            `json:"address" yaml:"address"`
+	Weight  int               `json:"weight" yaml:"weight"`
+	Tags    map[string]string `json:"tags" yaml:"tags"`
+}
+
+// fileContent is the schema of the resolver file: a flat instance list.
+type fileContent struct {
+	Instances []fileInstance `json:"instances" yaml:"instances"`
+}
+
+// NewFileResolver creates a resolver that reads the instance list from a
+// JSON (.json) or YAML file, for deployments without a registry and for
+// tests. The file is re-read when its modification time changes, and pushed
+// to the balancer cache through the Watcher interface, so edits take effect
+// within one poll interval.
+func NewFileResolver(path string, opts FileOptions) Resolver {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultFilePollInterval
+	}
+	return &fileResolver{path: path, opts: opts}
+}
+
+type fileResolver struct {
+	path string
+	opts FileOptions
+}
+
+// Target implements the Resolver interface.
+func (r *fileResolver) Target(ctx context.Context, target rpcinfo.EndpointInfo) string {
+	return r.path
+}
+
+// Resolve implements the Resolver interface.
+func (r *fileResolver) Resolve(ctx context.Context, desc string) (Result, error) {
+	instances, err := r.load()
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Cacheable: true, CacheKey: desc, Instances: instances}, nil
+}
+
+// load parses the file into instances; the unmarshaler follows the extension.
+func (r *fileResolver) load() ([]Instance, error) {
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return nil, err
+	}
+	var content fileContent
+	if filepath.Ext(r.path) == ".json" {
+		err = json.Unmarshal(data, &content)
+	} else {
+		err = yaml.Unmarshal(data, &content)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("file resolver: parse %s: %w", r.path, err)
+	}
+	if len(content.Instances) == 0 {
+		return nil, fmt.Errorf("file resolver: no instance in %s", r.path)
+	}
+	instances := make([]Instance, 0, len(content.Instances))
+	for _, fi := range content.Instances {
+		if fi.Network == "" {
+			fi.Network = "tcp"
+		}
+		if fi.Weight <= 0 {
+			fi.Weight = DefaultWeight
+		}
+		instances = append(instances, NewInstance(fi.Network, fi.Address, fi.Weight, fi.Tags))
+	}
+	return instances, nil
+}
+
+// Watch implements the Watcher interface: the file's modification time is
+// polled and every change is re-parsed and pushed, so the balancer cache
+// picks up edits without waiting for its refresh interval.
+func (r *fileResolver) Watch(ctx context.Context, desc string) (<-chan Change, error) {
+	st, err := os.Stat(r.path)
+	if err != nil {
+		return nil, err
+	}
+	ch := make(chan Change, 1)
+	go r.poll(ctx, desc, st.ModTime(), ch)
+	return ch, nil
+}
+
+func (r *fileResolver) poll(ctx context.Context, desc string, lastMod time.Time, ch chan<- Change) {
+	defer close(ch)
+	ticker := time.NewTicker(r.opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			st, err := os.Stat(r.path)
+			if err != nil || !st.ModTime().After(lastMod) {
+				continue
+			}
+			lastMod = st.ModTime()
+			res, err := r.Resolve(ctx, desc)
+			if err != nil {
+				continue // keep serving the previous result on bad edits
+			}
+			select {
+			case ch <- Change{Result: res}:
+			case <-ctx.Done():
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Diff implements the Resolver interface.
+func (r *fileResolver) Diff(cacheKey string, prev, next Result) (Change, bool) {
+	return DefaultDiff(cacheKey, prev, next)
+}
+
+// Name implements the Resolver interface.
+func (r *fileResolver) Name() string {
+	return "file"
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/kitex/internal/test"
+)
+
+func TestFileResolverJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "instances.json")
+	content := `{"instances": [
+		{"address": "10.0.0.1:8888", "weight": 20, "tags": {"zone": "z1"}},
+		{"address": "10.0.0.2:8888"}
+	]}`
+	test.Assert(t, os.WriteFile(path, []byte(content), 0o600) == nil)
+
+	r := NewFileResolver(path, FileOptions{})
+	test.Assert(t, r.Name() == "file")
+	res, err := r.Resolve(context.Background(), path)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, res.Cacheable)
+	test.Assert(t, len(res.Instances) == 2)
+	test.Assert(t, res.Instances[0].Address().String() == "10.0.0.1:8888")
+	test.Assert(t, res.Instances[0].Weight() == 20)
+	zone, ok := res.Instances[0].Tag(TagZone)
+	test.Assert(t, ok && zone == "z1")
+	// omitted fields fall back to defaults
+	test.Assert(t, res.Instances[1].Weight() == DefaultWeight)
+	test.Assert(t, res.Instances[1].Address().Network() == "tcp")
+}
+
+func TestFileResolverYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "instances.yaml")
+	content := `
+instances:
+  - address: 10.0.0.1:8888
+    network: unix
+    weight: 30
+`
+	test.Assert(t, os.WriteFile(path, []byte(content), 0o600) == nil)
+
+	r := NewFileResolver(path, FileOptions{})
+	res, err := r.Resolve(context.Background(), path)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, len(res.Instances) == 1)
+	test.Assert(t, res.Instances[0].Address().Network() == "unix")
+	test.Assert(t, res.Instances[0].Weight() == 30)
+
+	// an empty instance list is an error, not an empty result
+	test.Assert(t, os.WriteFile(path, []byte("instances: []"), 0o600) == nil)
+	_, err = r.Resolve(context.Background(), path)
+	test.Assert(t, err != nil)
+}
+
+func TestFileResolverWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "instances.json")
+	write := func(content string) {
+		test.Assert(t, os.WriteFile(path, []byte(content), 0o600) == nil)
+	}
+	write(`{"instances": [{"address": "10.0.0.1:8888"}]}`)
+
+	r := NewFileResolver(path, FileOptions{PollInterval: 5 * time.Millisecond}).(*fileResolver)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, err := r.Watch(ctx, path)
+	test.Assert(t, err == nil, err)
+
+	// the watch ignores the poll while the file is untouched
+	select {
+	case <-ch:
+		t.Fatal("unexpected change")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	// an edit is pushed within a poll interval; modtime granularity on some
+	// filesystems is a full second, so force it forward
+	write(`{"instances": [{"address": "10.0.0.1:8888"}, {"address": "10.0.0.2:8888"}]}`)
+	future := time.Now().Add(2 * time.Second)
+	test.Assert(t, os.Chtimes(path, future, future) == nil)
+	select {
+	case change := <-ch:
+		test.Assert(t, len(change.Result.Instances) == 2)
+	case <-time.After(time.Second):
+		t.Fatal("watch missed the edit")
+	}
+
+	// cancel stops the watch and closes the channel
+	cancel()
+	select {
+	case _, ok := <-ch:
+		test.Assert(t, !ok)
+	case <-time.After(time.Second):
+		t.Fatal("watch channel not closed")
+	}
+}
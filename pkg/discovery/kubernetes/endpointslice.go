@@ -0,0 +1,341 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package kubernetes provides a resolver backed by Kubernetes EndpointSlices.
+// It talks to the API server's list/watch REST endpoints directly with a
+// minimal typed subset, so core kitex stays free of the client-go dependency
+// tree while behaving like an informer: a full list on resolve, a watch
+// stream that triggers re-lists on change.
+package kubernetes
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/kitex/pkg/discovery"
+	"github.com/cloudwego/kitex/pkg/klog"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+const (
+	defaultMaster        = "https://kubernetes.default.svc"
+	serviceAccountDir    = "/var/run/secrets/kubernetes.io/serviceaccount"
+	defaultTokenFile     = serviceAccountDir + "/token"
+	defaultCAFile        = serviceAccountDir + "/ca.crt"
+	defaultNamespaceFile = serviceAccountDir + "/namespace"
+
+	// serviceNameLabel ties an EndpointSlice to its Service.
+	serviceNameLabel = "kubernetes.io/service-name"
+)
+
+// Options configures NewEndpointSliceResolver. The zero value works inside a
+// cluster: the API server address, token, CA and namespace come from the
+// standard in-cluster environment.
+type Options struct {
+	// Master is the API server base URL, the in-cluster service when empty.
+	Master string
+	// Namespace scopes the lookups, the pod's own namespace (or "default")
+	// when empty.
+	Namespace string
+	// PortName selects which port of a slice to dial when a slice exposes
+	// several; the first port is used when empty.
+	PortName string
+	// TokenFile and CAFile override the in-cluster service account paths.
+	TokenFile string
+	CAFile    string
+	// Client overrides the HTTP client, mainly for tests; when set, TokenFile
+	// and CAFile are not consulted.
+	Client *http.Client
+}
+
+// NewEndpointSliceResolver creates a resolver that lists the EndpointSlices
+// of a service and watches them for changes. Only endpoints whose Ready
+// condition is not false become instances, and the endpoint zone is exposed
+// under the canonical discovery.TagZone tag for locality-aware balancing.
+func NewEndpointSliceResolver(opts Options) (discovery.Resolver, error) {
+	if opts.Master == "" {
+		opts.Master = defaultMaster
+	}
+	if opts.TokenFile == "" {
+		opts.TokenFile = defaultTokenFile
+	}
+	if opts.CAFile == "" {
+		opts.CAFile = defaultCAFile
+	}
+	if opts.Namespace == "" {
+		opts.Namespace = currentNamespace()
+	}
+	r := &endpointSliceResolver{opts: opts, client: opts.Client}
+	if r.client == nil {
+		client, err := inClusterClient(opts.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		r.client = client
+		if data, err := os.ReadFile(opts.TokenFile); err == nil {
+			r.token = strings.TrimSpace(string(data))
+		}
+	}
+	return r, nil
+}
+
+type endpointSliceResolver struct {
+	opts   Options
+	client *http.Client
+	token  string
+}
+
+// currentNamespace reads the pod's namespace, "default" outside a cluster.
+func currentNamespace() string {
+	if data, err := os.ReadFile(defaultNamespaceFile); err == nil {
+		if ns := strings.TrimSpace(string(data)); ns != "" {
+			return ns
+		}
+	}
+	return "default"
+}
+
+func inClusterClient(caFile string) (*http.Client, error) {
+	data, err := os.ReadFile(caFile)
+	if err != nil {
+		// outside a cluster (or CA not mounted): fall back to system roots
+		return http.DefaultClient, nil
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("kubernetes resolver: invalid CA certificate in %s", caFile)
+	}
+	return &http.Client{
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}},
+	}, nil
+}
+
+// The typed subset of discovery.k8s.io/v1 this resolver needs.
+type endpointSliceList struct {
+	Metadata struct {
+		ResourceVersion string `json:"resourceVersion"`
+	} `json:"metadata"`
+	Items []endpointSlice `json:"items"`
+}
+
+type endpointSlice struct {
+	Endpoints []sliceEndpoint `json:"endpoints"`
+	Ports     []slicePort     `json:"ports"`
+}
+
+type sliceEndpoint struct {
+	Addresses  []string `json:"addresses"`
+	Conditions struct {
+		Ready *bool `json:"ready"`
+	} `json:"conditions"`
+	Zone     *string `json:"zone"`
+	NodeName *string `json:"nodeName"`
+}
+
+type slicePort struct {
+	Name *string `json:"name"`
+	Port *int32  `json:"port"`
+}
+
+type watchEvent struct {
+	Type string `json:"type"`
+}
+
+// Target implements the Resolver interface. The description is the service
+// name, optionally prefixed "namespace/" to cross namespaces.
+func (r *endpointSliceResolver) Target(ctx context.Context, target rpcinfo.EndpointInfo) string {
+	return target.ServiceName()
+}
+
+// Resolve implements the Resolver interface.
+func (r *endpointSliceResolver) Resolve(ctx context.Context, desc string) (discovery.Result, error) {
+	list, err := r.list(ctx, desc)
+	if err != nil {
+		return discovery.Result{}, err
+	}
+	instances := r.instances(list)
+	if len(instances) == 0 {
+		return discovery.Result{}, fmt.Errorf("kubernetes resolver: no ready endpoint for %s", desc)
+	}
+	return discovery.Result{Cacheable: true, CacheKey: desc, Instances: instances}, nil
+}
+
+func (r *endpointSliceResolver) list(ctx context.Context, desc string) (*endpointSliceList, error) {
+	resp, err := r.get(ctx, r.listURL(desc, "", false))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("kubernetes resolver: list %s: %s", desc, resp.Status)
+	}
+	var list endpointSliceList
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("kubernetes resolver: decode list %s: %w", desc, err)
+	}
+	return &list, nil
+}
+
+func (r *endpointSliceResolver) listURL(desc, resourceVersion string, watch bool) string {
+	namespace, service := r.opts.Namespace, desc
+	if idx := strings.IndexByte(desc, '/'); idx >= 0 {
+		namespace, service = desc[:idx], desc[idx+1:]
+	}
+	q := url.Values{}
+	q.Set("labelSelector", serviceNameLabel+"="+service)
+	if watch {
+		q.Set("watch", "true")
+		q.Set("allowWatchBookmarks", "false")
+	}
+	if resourceVersion != "" {
+		q.Set("resourceVersion", resourceVersion)
+	}
+	return fmt.Sprintf("%s/apis/discovery.k8s.io/v1/namespaces/%s/endpointslices?%s",
+		r.opts.Master, namespace, q.Encode())
+}
+
+func (r *endpointSliceResolver) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
+	return r.client.Do(req)
+}
+
+// instances flattens ready endpoints into instances, tagging zone topology.
+func (r *endpointSliceResolver) instances(list *endpointSliceList) []discovery.Instance {
+	var instances []discovery.Instance
+	for i := range list.Items {
+		slice := &list.Items[i]
+		port := r.slicePort(slice)
+		if port == "" {
+			continue
+		}
+		for j := range slice.Endpoints {
+			ep := &slice.Endpoints[j]
+			// a nil Ready condition means readiness is unknown and the
+			// endpoint should be considered usable, matching kube-proxy
+			if ep.Conditions.Ready != nil && !*ep.Conditions.Ready {
+				continue
+			}
+			var tags map[string]string
+			if ep.Zone != nil && *ep.Zone != "" {
+				tags = map[string]string{discovery.TagZone: *ep.Zone}
+			}
+			for _, addr := range ep.Addresses {
+				instances = append(instances, discovery.NewInstance(
+					"tcp", addr+":"+port, discovery.DefaultWeight, tags))
+			}
+		}
+	}
+	return instances
+}
+
+func (r *endpointSliceResolver) slicePort(slice *endpointSlice) string {
+	for _, p := range slice.Ports {
+		if p.Port == nil {
+			continue
+		}
+		if r.opts.PortName == "" || (p.Name != nil && *p.Name == r.opts.PortName) {
+			return strconv.Itoa(int(*p.Port))
+		}
+	}
+	return ""
+}
+
+// Watch implements the discovery.Watcher interface. Every watch event
+// triggers a re-list, like a resync-on-change informer; the stream is
+// re-established on error until ctx is done.
+func (r *endpointSliceResolver) Watch(ctx context.Context, desc string) (<-chan discovery.Change, error) {
+	list, err := r.list(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	ch := make(chan discovery.Change, 1)
+	go r.watchLoop(ctx, desc, list.Metadata.ResourceVersion, ch)
+	return ch, nil
+}
+
+func (r *endpointSliceResolver) watchLoop(ctx context.Context, desc, resourceVersion string, ch chan<- discovery.Change) {
+	defer close(ch)
+	for ctx.Err() == nil {
+		resp, err := r.get(ctx, r.listURL(desc, resourceVersion, true))
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			klog.Warnf("KITEX: kubernetes watch failed, key=%s error=%s", desc, err.Error())
+			select {
+			case <-time.After(time.Second):
+			case <-ctx.Done():
+				return
+			}
+			continue
+		}
+		r.consumeEvents(ctx, desc, resp, ch)
+		// the stream ended (timeout or stale resourceVersion): restart from
+		// a fresh list so no change is missed
+		resourceVersion = ""
+	}
+}
+
+func (r *endpointSliceResolver) consumeEvents(ctx context.Context, desc string, resp *http.Response, ch chan<- discovery.Change) {
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		klog.Warnf("KITEX: kubernetes watch failed, key=%s status=%s", desc, resp.Status)
+		return
+	}
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var ev watchEvent
+		if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil || ev.Type == "BOOKMARK" {
+			continue
+		}
+		res, err := r.Resolve(ctx, desc)
+		if err != nil {
+			continue // transient empty lists keep the previous result
+		}
+		select {
+		case ch <- discovery.Change{Result: res}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Diff implements the Resolver interface.
+func (r *endpointSliceResolver) Diff(cacheKey string, prev, next discovery.Result) (discovery.Change, bool) {
+	return discovery.DefaultDiff(cacheKey, prev, next)
+}
+
+// Name implements the Resolver interface.
+func (r *endpointSliceResolver) Name() string {
+	return "kubernetes"
+}
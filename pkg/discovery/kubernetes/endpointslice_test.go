@@ -0,0 +1,112 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubernetes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/discovery"
+)
+
+const sliceFixture = `{
+	"metadata": {"resourceVersion": "42"},
+	"items": [{
+		"endpoints": [
+			{"addresses": ["10.0.0.1"], "conditions": {"ready": true}, "zone": "z1"},
+			{"addresses": ["10.0.0.2"], "conditions": {"ready": false}, "zone": "z1"},
+			{"addresses": ["10.0.0.3"], "conditions": {}}
+		],
+		"ports": [{"name": "grpc", "port": 8888}, {"name": "metrics", "port": 9999}]
+	}]
+}`
+
+func testResolver(t *testing.T, handler http.HandlerFunc, opts Options) discovery.Resolver {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	opts.Master = server.URL
+	opts.Client = server.Client()
+	if opts.Namespace == "" {
+		opts.Namespace = "default"
+	}
+	r, err := NewEndpointSliceResolver(opts)
+	test.Assert(t, err == nil, err)
+	return r
+}
+
+func TestEndpointSliceResolve(t *testing.T) {
+	r := testResolver(t, func(w http.ResponseWriter, req *http.Request) {
+		test.Assert(t, req.URL.Path == "/apis/discovery.k8s.io/v1/namespaces/default/endpointslices")
+		test.Assert(t, req.URL.Query().Get("labelSelector") == "kubernetes.io/service-name=svc")
+		w.Write([]byte(sliceFixture))
+	}, Options{})
+	test.Assert(t, r.Name() == "kubernetes")
+
+	res, err := r.Resolve(context.Background(), "svc")
+	test.Assert(t, err == nil, err)
+	test.Assert(t, res.Cacheable)
+	// the not-ready endpoint is dropped, the unknown-readiness one kept
+	test.Assert(t, len(res.Instances) == 2, len(res.Instances))
+	test.Assert(t, res.Instances[0].Address().String() == "10.0.0.1:8888")
+	zone, ok := res.Instances[0].Tag(discovery.TagZone)
+	test.Assert(t, ok && zone == "z1")
+	test.Assert(t, res.Instances[1].Address().String() == "10.0.0.3:8888")
+	_, ok = res.Instances[1].Tag(discovery.TagZone)
+	test.Assert(t, !ok)
+}
+
+func TestEndpointSlicePortNameAndNamespace(t *testing.T) {
+	r := testResolver(t, func(w http.ResponseWriter, req *http.Request) {
+		test.Assert(t, req.URL.Path == "/apis/discovery.k8s.io/v1/namespaces/other/endpointslices")
+		w.Write([]byte(sliceFixture))
+	}, Options{PortName: "metrics"})
+
+	// "namespace/service" descriptions cross namespaces
+	res, err := r.Resolve(context.Background(), "other/svc")
+	test.Assert(t, err == nil, err)
+	test.Assert(t, res.Instances[0].Address().String() == "10.0.0.1:9999")
+}
+
+func TestEndpointSliceWatch(t *testing.T) {
+	r := testResolver(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("watch") != "true" {
+			w.Write([]byte(sliceFixture))
+			return
+		}
+		// the initial list's resourceVersion seeds the watch
+		test.Assert(t, req.URL.Query().Get("resourceVersion") == "42")
+		w.Write([]byte(`{"type": "MODIFIED", "object": {}}` + "\n"))
+		w.(http.Flusher).Flush()
+		<-req.Context().Done()
+	}, Options{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, err := r.(discovery.Watcher).Watch(ctx, "svc")
+	test.Assert(t, err == nil, err)
+
+	// the event triggers a re-list pushed through the channel
+	change := <-ch
+	test.Assert(t, len(change.Result.Instances) == 2)
+
+	cancel()
+	for range ch { // closes once the watch loop exits
+	}
+}
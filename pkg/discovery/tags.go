@@ -0,0 +1,64 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+// Canonical instance tag keys. Registry adapters should publish instance
+// metadata under these keys so balancer filters and routers written against
+// one registry work against all of them.
+const (
+	// TagZone is the availability zone the instance runs in.
+	TagZone = "zone"
+	// TagEnv is the deployment environment, e.g. "prod", "staging", "canary".
+	TagEnv = "env"
+	// TagCluster is the logical cluster the instance belongs to.
+	TagCluster = "cluster"
+	// TagVersion is the service version the instance is running.
+	TagVersion = "version"
+	// TagWarmupTS is the unix timestamp (seconds) the instance started serving,
+	// for warmup-aware weighting.
+	TagWarmupTS = "warmup_ts"
+)
+
+// FilterInstances returns the instances for which keep reports true.
+// The input slice is not modified.
+func FilterInstances(instances []Instance, keep func(Instance) bool) []Instance {
+	filtered := make([]Instance, 0, len(instances))
+	for _, ins := range instances {
+		if keep(ins) {
+			filtered = append(filtered, ins)
+		}
+	}
+	return filtered
+}
+
+// TagEquals returns a predicate for FilterInstances that keeps instances
+// whose tag key equals value.
+func TagEquals(key, value string) func(Instance) bool {
+	return func(ins Instance) bool {
+		v, ok := ins.Tag(key)
+		return ok && v == value
+	}
+}
+
+// TagExists returns a predicate for FilterInstances that keeps instances
+// carrying the tag key, whatever its value.
+func TagExists(key string) func(Instance) bool {
+	return func(ins Instance) bool {
+		_, ok := ins.Tag(key)
+		return ok
+	}
+}
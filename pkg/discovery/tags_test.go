@@ -0,0 +1,46 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+)
+
+func TestFilterInstances(t *testing.T) {
+	instances := []Instance{
+		NewInstance("tcp", "addr1", 10, map[string]string{TagZone: "z1", TagEnv: "prod"}),
+		NewInstance("tcp", "addr2", 10, map[string]string{TagZone: "z2", TagEnv: "prod"}),
+		NewInstance("tcp", "addr3", 10, map[string]string{TagZone: "z1", TagVersion: "v2"}),
+		NewInstance("tcp", "addr4", 10, nil),
+	}
+
+	z1 := FilterInstances(instances, TagEquals(TagZone, "z1"))
+	test.Assert(t, len(z1) == 2)
+	test.Assert(t, z1[0].Address().String() == "addr1")
+	test.Assert(t, z1[1].Address().String() == "addr3")
+
+	versioned := FilterInstances(instances, TagExists(TagVersion))
+	test.Assert(t, len(versioned) == 1)
+	test.Assert(t, versioned[0].Address().String() == "addr3")
+
+	none := FilterInstances(instances, TagEquals(TagCluster, "c1"))
+	test.Assert(t, len(none) == 0)
+	// the input is left untouched
+	test.Assert(t, len(instances) == 4)
+}
@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	dproto "github.com/cloudwego/dynamicgo/proto"
+	"github.com/jhump/protoreflect/dynamic"
 
 	"github.com/cloudwego/kitex/internal/test"
 )
@@ -50,6 +51,57 @@ func TestPbContentProvider(t *testing.T) {
 	test.Assert(t, tree != nil)
 }
 
+func TestPbContentProviderProto3Optional(t *testing.T) {
+	path := "a/b/opt.proto"
+	content := `
+	syntax = "proto3";
+	package kitex.test.server;
+	option go_package = "test";
+
+	message OptionalMsg {
+		string name = 1;
+		optional string nick = 2;
+	}
+
+	service OptionalService {
+		rpc Echo(OptionalMsg) returns (OptionalMsg);
+	}
+	`
+	p, err := NewPbContentProvider(path, map[string]string{path: content})
+	test.Assert(t, err == nil, err)
+	sd := <-p.Provide()
+	test.Assert(t, sd != nil)
+
+	msg := sd.GetFile().FindMessage("kitex.test.server.OptionalMsg")
+	test.Assert(t, msg != nil)
+	nick := msg.FindFieldByName("nick")
+	test.Assert(t, nick != nil)
+	// proto3 optional fields carry explicit presence via a synthetic oneof
+	test.Assert(t, nick.AsFieldDescriptorProto().GetProto3Optional())
+	test.Assert(t, nick.GetOneOf() != nil)
+	test.Assert(t, msg.FindFieldByName("name").GetOneOf() == nil)
+
+	// an unset optional field stays absent after a round trip
+	unset := dynamic.NewMessage(msg)
+	unset.SetFieldByName("name", "foo")
+	buf, err := unset.Marshal()
+	test.Assert(t, err == nil, err)
+	decoded := dynamic.NewMessage(msg)
+	err = decoded.Unmarshal(buf)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, !decoded.HasFieldName("nick"))
+
+	// an optional field set to the zero value keeps its presence on the wire
+	set := dynamic.NewMessage(msg)
+	set.SetFieldByName("nick", "")
+	buf, err = set.Marshal()
+	test.Assert(t, err == nil, err)
+	decoded = dynamic.NewMessage(msg)
+	err = decoded.Unmarshal(buf)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, decoded.HasFieldName("nick"))
+}
+
 func TestPbContentProviderDynamicGo(t *testing.T) {
 	path := "./jsonpb_test/idl/echo.proto"
 
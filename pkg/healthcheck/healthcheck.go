@@ -0,0 +1,124 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package healthcheck provides a built-in health service, enabled with
+// server.WithHealthCheck. Its protocol mirrors grpc.health.v1.Health: the
+// Check method and the wire format of its messages are compatible, so
+// standard health checkers can probe a Kitex server.
+// Deployments flip readiness with SetServingStatus during warm-up or drain.
+package healthcheck
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cloudwego/kitex/pkg/serviceinfo"
+)
+
+// ServingStatus mirrors grpc.health.v1.HealthCheckResponse.ServingStatus.
+type ServingStatus int32
+
+const (
+	StatusUnknown    ServingStatus = 0
+	StatusServing    ServingStatus = 1
+	StatusNotServing ServingStatus = 2
+)
+
+// ServiceName is the registered name of the health service.
+const ServiceName = "grpc.health.v1.Health"
+
+var defaultRegistry = &registry{}
+
+// registry holds per-service serving statuses.
+// The empty service name addresses the overall server status.
+type registry struct {
+	mu       sync.RWMutex
+	statuses map[string]ServingStatus
+}
+
+func (r *registry) set(service string, status ServingStatus) {
+	r.mu.Lock()
+	if r.statuses == nil {
+		r.statuses = make(map[string]ServingStatus)
+	}
+	r.statuses[service] = status
+	r.mu.Unlock()
+}
+
+func (r *registry) get(service string) ServingStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if status, ok := r.statuses[service]; ok {
+		return status
+	}
+	if service == "" {
+		// the overall status defaults to serving once the process is up
+		return StatusServing
+	}
+	return StatusUnknown
+}
+
+// SetServingStatus sets the serving status reported for the given service.
+// The empty service name addresses the overall server status.
+func SetServingStatus(service string, status ServingStatus) {
+	defaultRegistry.set(service, status)
+}
+
+// GetServingStatus returns the serving status of the given service.
+func GetServingStatus(service string) ServingStatus {
+	return defaultRegistry.get(service)
+}
+
+// NewServiceInfo builds the ServiceInfo of the health service.
+func NewServiceInfo() *serviceinfo.ServiceInfo {
+	return &serviceinfo.ServiceInfo{
+		ServiceName:  ServiceName,
+		PayloadCodec: serviceinfo.Protobuf,
+		Methods: map[string]serviceinfo.MethodInfo{
+			"Check": serviceinfo.NewMethodInfo(
+				checkHandler,
+				func() interface{} { return &CheckRequest{} },
+				func() interface{} { return &CheckResponse{} },
+				false,
+			),
+		},
+		Extra: map[string]interface{}{
+			"PackageName": "grpc.health.v1",
+		},
+	}
+}
+
+// Handler serves health check requests against the default registry.
+type Handler struct{}
+
+// NewHandler creates the handler to be registered with the health ServiceInfo.
+func NewHandler() *Handler {
+	return &Handler{}
+}
+
+// Check implements the health Check method.
+func (*Handler) Check(ctx context.Context, req *CheckRequest) (*CheckResponse, error) {
+	return &CheckResponse{Status: GetServingStatus(req.Service)}, nil
+}
+
+func checkHandler(ctx context.Context, handler, arg, result interface{}) error {
+	resp, err := handler.(*Handler).Check(ctx, arg.(*CheckRequest))
+	if err != nil {
+		return err
+	}
+	*result.(*CheckResponse) = *resp
+	return nil
+}
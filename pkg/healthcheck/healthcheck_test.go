@@ -0,0 +1,66 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package healthcheck
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+)
+
+func TestServingStatusRegistry(t *testing.T) {
+	// the overall status defaults to serving, named services to unknown
+	test.Assert(t, GetServingStatus("") == StatusServing)
+	test.Assert(t, GetServingStatus("svc") == StatusUnknown)
+
+	SetServingStatus("svc", StatusServing)
+	test.Assert(t, GetServingStatus("svc") == StatusServing)
+	SetServingStatus("", StatusNotServing)
+	test.Assert(t, GetServingStatus("") == StatusNotServing)
+	SetServingStatus("", StatusServing)
+}
+
+func TestCheckHandler(t *testing.T) {
+	svcInfo := NewServiceInfo()
+	mi := svcInfo.MethodInfo("Check")
+	test.Assert(t, mi != nil)
+
+	SetServingStatus("svc", StatusNotServing)
+	args := mi.NewArgs().(*CheckRequest)
+	args.Service = "svc"
+	result := mi.NewResult().(*CheckResponse)
+	err := mi.Handler()(context.Background(), NewHandler(), args, result)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, result.Status == StatusNotServing)
+}
+
+func TestMessagesRoundTrip(t *testing.T) {
+	req := &CheckRequest{Service: "svc"}
+	buf, err := req.Marshal(nil)
+	test.Assert(t, err == nil, err)
+	var req2 CheckRequest
+	test.Assert(t, req2.Unmarshal(buf) == nil)
+	test.Assert(t, req2.Service == "svc")
+
+	resp := &CheckResponse{Status: StatusServing}
+	buf, err = resp.Marshal(nil)
+	test.Assert(t, err == nil, err)
+	var resp2 CheckResponse
+	test.Assert(t, resp2.Unmarshal(buf) == nil)
+	test.Assert(t, resp2.Status == StatusServing)
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package healthcheck
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// CheckRequest is wire compatible with grpc.health.v1.HealthCheckRequest.
+type CheckRequest struct {
+	Service string // field 1
+}
+
+// Marshal implements the protobuf codec interface.
+func (r *CheckRequest) Marshal(out []byte) ([]byte, error) {
+	if r.Service != "" {
+		out = protowire.AppendTag(out, 1, protowire.BytesType)
+		out = protowire.AppendString(out, r.Service)
+	}
+	return out, nil
+}
+
+// Unmarshal implements the protobuf codec interface.
+func (r *CheckRequest) Unmarshal(in []byte) error {
+	for len(in) > 0 {
+		num, typ, n := protowire.ConsumeTag(in)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		in = in[n:]
+		if num == 1 && typ == protowire.BytesType {
+			s, n := protowire.ConsumeString(in)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			r.Service = s
+			in = in[n:]
+			continue
+		}
+		n = protowire.ConsumeFieldValue(num, typ, in)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		in = in[n:]
+	}
+	return nil
+}
+
+func (r *CheckRequest) String() string {
+	return fmt.Sprintf("CheckRequest{Service: %q}", r.Service)
+}
+
+// CheckResponse is wire compatible with grpc.health.v1.HealthCheckResponse.
+type CheckResponse struct {
+	Status ServingStatus // field 1
+}
+
+// Marshal implements the protobuf codec interface.
+func (r *CheckResponse) Marshal(out []byte) ([]byte, error) {
+	if r.Status != 0 {
+		out = protowire.AppendTag(out, 1, protowire.VarintType)
+		out = protowire.AppendVarint(out, uint64(r.Status))
+	}
+	return out, nil
+}
+
+// Unmarshal implements the protobuf codec interface.
+func (r *CheckResponse) Unmarshal(in []byte) error {
+	for len(in) > 0 {
+		num, typ, n := protowire.ConsumeTag(in)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		in = in[n:]
+		if num == 1 && typ == protowire.VarintType {
+			v, n := protowire.ConsumeVarint(in)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			r.Status = ServingStatus(v)
+			in = in[n:]
+			continue
+		}
+		n = protowire.ConsumeFieldValue(num, typ, in)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		in = in[n:]
+	}
+	return nil
+}
+
+func (r *CheckResponse) String() string {
+	return fmt.Sprintf("CheckResponse{Status: %d}", r.Status)
+}
@@ -59,10 +59,14 @@ var (
 	ErrNoConnection         = ErrInternalException.WithCause(errors.New("no connection available"))
 	ErrConnOverLimit        = ErrOverlimit.WithCause(errors.New("to many connections"))
 	ErrQPSOverLimit         = ErrOverlimit.WithCause(errors.New("request too frequent"))
+	ErrStreamOverLimit      = ErrOverlimit.WithCause(errors.New("concurrent streams over limit"))
 	ErrNoIvkRequest         = ErrInternalException.WithCause(errors.New("invoker request not set"))
 	ErrServiceCircuitBreak  = ErrCircuitBreak.WithCause(errors.New("service circuitbreak"))
 	ErrInstanceCircuitBreak = ErrCircuitBreak.WithCause(errors.New("instance circuitbreak"))
+	ErrServerCircuitBreak   = ErrCircuitBreak.WithCause(errors.New("server circuitbreak"))
 	ErrNoInstance           = ErrServiceDiscovery.WithCause(errors.New("no instance available"))
+	// ErrClientClosing happens when a call is made while the client is being closed gracefully
+	ErrClientClosing = ErrInternalException.WithCause(errors.New("client is closing"))
 )
 
 type basicError struct {
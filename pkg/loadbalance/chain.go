@@ -0,0 +1,164 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"strings"
+
+	"github.com/cloudwego/kitex/pkg/discovery"
+)
+
+// Filter prunes the candidate instances of a discovery result before the
+// base balancer picks from them, so one-off concerns (zone, tag, canary,
+// health) compose with any balancer instead of requiring a full balancer
+// implementation each.
+type Filter struct {
+	// Name identifies the filter's effect on an instance set; it is appended
+	// to the cache key so the base balancer caches filtered sets separately.
+	Name string
+	// Apply prunes the instance list. Returning the input slice unchanged
+	// means the filter didn't apply.
+	Apply func(instances []discovery.Instance) []discovery.Instance
+	// Volatile marks filters whose decisions change over time (e.g. health or
+	// load based); results they touched are never cached by the base balancer.
+	Volatile bool
+}
+
+// TagMatchFilter keeps only instances whose tag key equals value,
+// a building block for canary and environment routing.
+func TagMatchFilter(key, value string) Filter {
+	return Filter{
+		Name: "tag:" + key + "=" + value,
+		Apply: func(instances []discovery.Instance) []discovery.Instance {
+			filtered := make([]discovery.Instance, 0, len(instances))
+			for _, ins := range instances {
+				if v, ok := ins.Tag(key); ok && v == value {
+					filtered = append(filtered, ins)
+				}
+			}
+			return filtered
+		},
+	}
+}
+
+// ZoneFilter keeps only instances in the given availability zone,
+// matching the canonical discovery.TagZone tag.
+func ZoneFilter(zone string) Filter {
+	return TagMatchFilter(discovery.TagZone, zone)
+}
+
+// EnvFilter keeps only instances in the given deployment environment,
+// matching the canonical discovery.TagEnv tag.
+func EnvFilter(env string) Filter {
+	return TagMatchFilter(discovery.TagEnv, env)
+}
+
+// ClusterFilter keeps only instances of the given logical cluster,
+// matching the canonical discovery.TagCluster tag.
+func ClusterFilter(cluster string) Filter {
+	return TagMatchFilter(discovery.TagCluster, cluster)
+}
+
+// VersionFilter keeps only instances running the given service version,
+// matching the canonical discovery.TagVersion tag.
+func VersionFilter(version string) Filter {
+	return TagMatchFilter(discovery.TagVersion, version)
+}
+
+// Chain composes filters with a base balancer: every filter prunes the
+// discovery result in order before the base picker runs. Filters that leave
+// no instance are skipped (fail open) so a too-strict rule doesn't black-hole
+// all traffic.
+func Chain(base Loadbalancer, filters ...Filter) Loadbalancer {
+	names := make([]string, len(filters))
+	for i := range filters {
+		names[i] = filters[i].Name
+	}
+	return &chainBalancer{
+		base:      base,
+		filters:   filters,
+		keySuffix: "#" + strings.Join(names, ","),
+	}
+}
+
+type chainBalancer struct {
+	base      Loadbalancer
+	filters   []Filter
+	keySuffix string
+}
+
+// GetPicker implements the Loadbalancer interface.
+func (cb *chainBalancer) GetPicker(e discovery.Result) Picker {
+	return cb.base.GetPicker(cb.filterResult(e))
+}
+
+// Rebalance implements the Rebalancer interface.
+func (cb *chainBalancer) Rebalance(change discovery.Change) {
+	rb, ok := cb.base.(Rebalancer)
+	if !ok {
+		return
+	}
+	rb.Rebalance(change)
+	if filtered := cb.filterResult(change.Result); filtered.CacheKey != change.Result.CacheKey {
+		rb.Rebalance(discovery.Change{Result: filtered})
+	}
+}
+
+// Delete implements the Rebalancer interface.
+func (cb *chainBalancer) Delete(change discovery.Change) {
+	rb, ok := cb.base.(Rebalancer)
+	if !ok {
+		return
+	}
+	rb.Delete(change)
+	if filtered := cb.filterResult(change.Result); filtered.CacheKey != change.Result.CacheKey {
+		rb.Delete(discovery.Change{Result: filtered})
+	}
+}
+
+func (cb *chainBalancer) Name() string {
+	return cb.base.Name() + "_chain"
+}
+
+func (cb *chainBalancer) filterResult(e discovery.Result) discovery.Result {
+	instances := e.Instances
+	changed := false
+	volatile := false
+	for i := range cb.filters {
+		filtered := cb.filters[i].Apply(instances)
+		if len(filtered) == 0 {
+			// fail open: skip filters that would drop every instance
+			continue
+		}
+		if len(filtered) != len(instances) {
+			changed = true
+			volatile = volatile || cb.filters[i].Volatile
+		}
+		instances = filtered
+	}
+	if !changed {
+		return e
+	}
+	if volatile {
+		return discovery.Result{Instances: instances}
+	}
+	return discovery.Result{
+		Cacheable: e.Cacheable,
+		CacheKey:  e.CacheKey + cb.keySuffix,
+		Instances: instances,
+	}
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/discovery"
+)
+
+func TestChain(t *testing.T) {
+	insList := []discovery.Instance{
+		discovery.NewInstance("tcp", "prod1", 10, map[string]string{"env": "prod", "zone": "z1"}),
+		discovery.NewInstance("tcp", "prod2", 10, map[string]string{"env": "prod", "zone": "z2"}),
+		discovery.NewInstance("tcp", "canary1", 10, map[string]string{"env": "canary", "zone": "z1"}),
+	}
+	e := discovery.Result{Cacheable: true, CacheKey: "test", Instances: insList}
+
+	lb := Chain(NewWeightedRoundRobinBalancer(), TagMatchFilter("env", "prod"), TagMatchFilter("zone", "z1"))
+	test.Assert(t, lb.Name() == "weight_round_robin_chain")
+	picker := lb.GetPicker(e)
+	for i := 0; i < 10; i++ {
+		ins := picker.Next(context.TODO(), nil)
+		test.Assert(t, ins.Address().String() == "prod1", ins.Address())
+	}
+}
+
+func TestChainFailOpen(t *testing.T) {
+	insList := []discovery.Instance{
+		discovery.NewInstance("tcp", "prod1", 10, map[string]string{"env": "prod"}),
+	}
+	e := discovery.Result{Cacheable: false, Instances: insList}
+	// a filter that matches nothing must not black-hole all traffic
+	lb := Chain(NewWeightedRoundRobinBalancer(), TagMatchFilter("env", "canary"))
+	ins := lb.GetPicker(e).Next(context.TODO(), nil)
+	test.Assert(t, ins != nil)
+	test.Assert(t, ins.Address().String() == "prod1")
+}
+
+func TestChainCacheKey(t *testing.T) {
+	insList := []discovery.Instance{
+		discovery.NewInstance("tcp", "prod1", 10, map[string]string{"env": "prod"}),
+		discovery.NewInstance("tcp", "canary1", 10, map[string]string{"env": "canary"}),
+	}
+	e := discovery.Result{Cacheable: true, CacheKey: "test", Instances: insList}
+
+	cb := Chain(NewWeightedRoundRobinBalancer(), TagMatchFilter("env", "prod")).(*chainBalancer)
+	filtered := cb.filterResult(e)
+	test.Assert(t, filtered.Cacheable)
+	test.Assert(t, filtered.CacheKey == "test#tag:env=prod", filtered.CacheKey)
+	test.Assert(t, len(filtered.Instances) == 1)
+
+	// volatile filters keep the result out of picker caches
+	volatile := Filter{
+		Name:     "healthy",
+		Volatile: true,
+		Apply: func(instances []discovery.Instance) []discovery.Instance {
+			return instances[:1]
+		},
+	}
+	cb = Chain(NewWeightedRoundRobinBalancer(), volatile).(*chainBalancer)
+	filtered = cb.filterResult(e)
+	test.Assert(t, !filtered.Cacheable)
+	test.Assert(t, len(filtered.Instances) == 1)
+}
@@ -25,7 +25,9 @@ import (
 	"github.com/bytedance/gopkg/util/xxhash3"
 	"golang.org/x/sync/singleflight"
 
+	"github.com/cloudwego/kitex/pkg/diagnosis"
 	"github.com/cloudwego/kitex/pkg/discovery"
+	"github.com/cloudwego/kitex/pkg/loadbalance/lbcontext"
 	"github.com/cloudwego/kitex/pkg/utils"
 )
 
@@ -157,6 +159,13 @@ func (cp *consistPicker) Next(ctx context.Context, request interface{}) discover
 		return nil
 	}
 	res := buildConsistResult(cp.info, xxhash3.HashString(key))
+	if lbcontext.HasExcluded(ctx) && lbcontext.IsExcluded(ctx, res.Primary) {
+		// walk the ring clockwise from the primary node looking for the
+		// nearest real node not excluded; keep affinity when nothing is left
+		if ins := nextOnRing(ctx, cp.info, xxhash3.HashString(key)); ins != nil {
+			return ins
+		}
+	}
 	return res.Primary
 	// Todo(DMwangnima): Optimise Replica-related logic
 	// This comment part is previous implementation considering connecting to Replica
@@ -178,6 +187,32 @@ func (cp *consistPicker) Next(ctx context.Context, request interface{}) discover
 	//return nil
 }
 
+// nextOnRing returns the first real node clockwise from key that is not
+// excluded in ctx, or nil if every real node is excluded.
+func nextOnRing(ctx context.Context, info *consistInfo, key uint64) discovery.Instance {
+	vNodes := info.virtualNodes
+	index := sort.Search(len(vNodes), func(i int) bool {
+		return vNodes[i].hash > key
+	})
+	if index == len(vNodes) {
+		index = 0
+	}
+	size := len(info.realNodes)
+	tried := make(map[string]struct{}, size)
+	for i := 0; len(tried) < size && i < len(vNodes); i++ {
+		ins := vNodes[(index+i)%len(vNodes)].RealNode.Ins
+		addr := ins.Address().String()
+		if _, ok := tried[addr]; ok {
+			continue
+		}
+		tried[addr] = struct{}{}
+		if !lbcontext.IsExcluded(ctx, ins) {
+			return ins
+		}
+	}
+	return nil
+}
+
 func buildConsistResult(info *consistInfo, key uint64) *consistResult {
 	cr := &consistResult{}
 	index := sort.Search(len(info.virtualNodes), func(i int) bool {
@@ -225,10 +260,56 @@ func buildConsistResult(info *consistInfo, key uint64) *consistResult {
 
 type consistBalancer struct {
 	cachedConsistInfo sync.Map
+	ringStats         sync.Map // CacheKey -> ringStats
 	opt               ConsistentHashOption
 	sfg               singleflight.Group
 }
 
+// ringStats describes one hash ring for diagnosis.
+type ringStats struct {
+	RealNodes       int           `json:"real_nodes"`
+	VirtualNodes    int           `json:"virtual_nodes"`
+	LastRebuildMode string        `json:"last_rebuild_mode"` // "full" or "incremental"
+	LastRebuildCost time.Duration `json:"last_rebuild_cost"`
+	LastRebuildAt   time.Time     `json:"last_rebuild_at"`
+}
+
+const (
+	ringRebuildFull        = "full"
+	ringRebuildIncremental = "incremental"
+)
+
+// HashRingInfoKey is the probe name the consistent-hash ring stats are registered under.
+const HashRingInfoKey diagnosis.ProbeName = "hash_ring_info"
+
+// RegisterHashRingDiagnose registers the ring stats of a consistent-hash balancer
+// on the debug service, eg: RegisterHashRingDiagnose(lb, opt.DebugService).
+// It is a no-op for other balancer implementations.
+func RegisterHashRingDiagnose(lb Loadbalancer, svc diagnosis.Service) {
+	if cb, ok := lb.(*consistBalancer); ok {
+		diagnosis.RegisterProbeFunc(svc, HashRingInfoKey, cb.ringDump)
+	}
+}
+
+func (cb *consistBalancer) ringDump() interface{} {
+	rings := make(map[string]ringStats)
+	cb.ringStats.Range(func(key, value interface{}) bool {
+		rings[key.(string)] = value.(ringStats)
+		return true
+	})
+	return rings
+}
+
+func (cb *consistBalancer) recordRingStats(key string, info *consistInfo, mode string, cost time.Duration) {
+	cb.ringStats.Store(key, ringStats{
+		RealNodes:       len(info.realNodes),
+		VirtualNodes:    len(info.virtualNodes),
+		LastRebuildMode: mode,
+		LastRebuildCost: cost,
+		LastRebuildAt:   time.Now(),
+	})
+}
+
 // NewConsistBalancer creates a new consist balancer with the given option.
 func NewConsistBalancer(opt ConsistentHashOption) Loadbalancer {
 	if opt.GetKey == nil {
@@ -250,7 +331,10 @@ func (cb *consistBalancer) GetPicker(e discovery.Result) Picker {
 		cii, ok := cb.cachedConsistInfo.Load(e.CacheKey)
 		if !ok {
 			cii, _, _ = cb.sfg.Do(e.CacheKey, func() (interface{}, error) {
-				return cb.newConsistInfo(e), nil
+				begin := time.Now()
+				info := cb.newConsistInfo(e)
+				cb.recordRingStats(e.CacheKey, info, ringRebuildFull, time.Since(begin))
+				return info, nil
 			})
 			cb.cachedConsistInfo.Store(e.CacheKey, cii)
 		}
@@ -345,17 +429,109 @@ func (cb *consistBalancer) getVirtualNodeLen(rNode realNode) int {
 }
 
 func (cb *consistBalancer) updateConsistInfo(e discovery.Result) {
+	begin := time.Now()
 	newInfo := cb.newConsistInfo(e)
+	cb.recordRingStats(e.CacheKey, newInfo, ringRebuildFull, time.Since(begin))
 	cb.cachedConsistInfo.Store(e.CacheKey, newInfo)
 }
 
+// incrConsistInfo applies the change to the old ring without rebuilding it:
+// virtual nodes of removed and updated instances are dropped, virtual nodes
+// for added and updated instances are built and merged into the survivors.
+// Change.Result stays authoritative, deltas not reflected there are ignored.
+// The old info is left untouched so pickers holding it stay consistent.
+func (cb *consistBalancer) incrConsistInfo(old *consistInfo, change discovery.Change) *consistInfo {
+	final := make(map[string]struct{}, len(change.Result.Instances))
+	for _, ins := range change.Result.Instances {
+		final[ins.Address().String()] = struct{}{}
+	}
+	stale := make(map[string]struct{}, len(change.Removed)+len(change.Updated))
+	for _, ins := range change.Removed {
+		stale[ins.Address().String()] = struct{}{}
+	}
+	for _, ins := range change.Updated {
+		stale[ins.Address().String()] = struct{}{}
+	}
+	dropped := func(addr string) bool {
+		if _, ok := stale[addr]; ok {
+			return true
+		}
+		_, ok := final[addr]
+		return !ok
+	}
+
+	kept := make([]virtualNode, 0, len(old.virtualNodes))
+	for i := range old.virtualNodes {
+		if !dropped(old.virtualNodes[i].RealNode.Ins.Address().String()) {
+			kept = append(kept, old.virtualNodes[i])
+		}
+	}
+	keptReal := make([]realNode, 0, len(old.realNodes)+len(change.Added))
+	keptAddr := make(map[string]struct{}, len(old.realNodes))
+	for i := range old.realNodes {
+		addr := old.realNodes[i].Ins.Address().String()
+		if !dropped(addr) {
+			keptReal = append(keptReal, old.realNodes[i])
+			keptAddr[addr] = struct{}{}
+		}
+	}
+
+	freshIns := make([]discovery.Instance, 0, len(change.Added)+len(change.Updated))
+	appendFresh := func(inss []discovery.Instance) {
+		for _, ins := range inss {
+			addr := ins.Address().String()
+			if _, ok := final[addr]; !ok {
+				continue
+			}
+			if _, ok := keptAddr[addr]; ok {
+				continue
+			}
+			freshIns = append(freshIns, ins)
+		}
+	}
+	appendFresh(change.Added)
+	appendFresh(change.Updated)
+	freshReal, freshVirtual := cb.buildNodes(freshIns)
+
+	return &consistInfo{
+		realNodes:    append(keptReal, freshReal...),
+		virtualNodes: mergeVirtualNodes(kept, freshVirtual),
+	}
+}
+
+// mergeVirtualNodes merges two hash-sorted vnode slices into one sorted slice.
+func mergeVirtualNodes(a, b []virtualNode) []virtualNode {
+	merged := make([]virtualNode, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if a[i].hash <= b[j].hash {
+			merged = append(merged, a[i])
+			i++
+		} else {
+			merged = append(merged, b[j])
+			j++
+		}
+	}
+	merged = append(merged, a[i:]...)
+	merged = append(merged, b[j:]...)
+	return merged
+}
+
 // Rebalance implements the Rebalancer interface.
 func (cb *consistBalancer) Rebalance(change discovery.Change) {
 	if !change.Result.Cacheable {
 		return
 	}
-	// TODO: Use TreeMap to optimize performance when updating.
-	// Now, due to the lack of a good red-black tree implementation, we can only build the full amount once per update.
+	delta := len(change.Added) + len(change.Updated) + len(change.Removed)
+	if oldInfo, ok := cb.cachedConsistInfo.Load(change.Result.CacheKey); ok && delta > 0 {
+		// apply the delta instead of rebuilding the full ring, rebuilding from
+		// scratch is costly when the ring holds millions of virtual nodes
+		begin := time.Now()
+		newInfo := cb.incrConsistInfo(oldInfo.(*consistInfo), change)
+		cb.recordRingStats(change.Result.CacheKey, newInfo, ringRebuildIncremental, time.Since(begin))
+		cb.cachedConsistInfo.Store(change.Result.CacheKey, newInfo)
+		return
+	}
 	cb.updateConsistInfo(change.Result)
 }
 
@@ -367,6 +543,7 @@ func (cb *consistBalancer) Delete(change discovery.Change) {
 	// FIXME: If Delete and Rebalance occur together (Discovery OnDelete and OnChange are triggered at the same time),
 	// it may cause the delete to fail and eventually lead to a resource leak.
 	cb.cachedConsistInfo.Delete(change.Result.CacheKey)
+	cb.ringStats.Delete(change.Result.CacheKey)
 }
 
 func (cb *consistBalancer) Name() string {
@@ -0,0 +1,152 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/bytedance/gopkg/util/xxhash3"
+
+	"github.com/cloudwego/kitex/pkg/discovery"
+	"github.com/cloudwego/kitex/pkg/endpoint"
+	"github.com/cloudwego/kitex/pkg/loadbalance/inflight"
+	"github.com/cloudwego/kitex/pkg/loadbalance/lbcontext"
+)
+
+// defaultBoundFactor allows an instance to carry 25% more than the average
+// in-flight load before requests spill to the next node on the ring.
+const defaultBoundFactor = 1.25
+
+// NewConsistBalancerWithBoundedLoad creates a consistent hash balancer with
+// bounded load (CH-BL): a request normally goes to the instance its key hashes
+// to, but when that instance already carries more than boundFactor times the
+// average in-flight load, the request spills over to the next instance on the
+// ring, avoiding hotspots on a single node. boundFactor not greater than 1
+// falls back to defaultBoundFactor.
+//
+// In-flight counters are increased on pick and released by the middleware
+// returned from FeedbackMW, which must be installed on the client via
+// client.WithMiddleware for the load bound to work.
+func NewConsistBalancerWithBoundedLoad(opt ConsistentHashOption, boundFactor float64) Loadbalancer {
+	if boundFactor <= 1 {
+		boundFactor = defaultBoundFactor
+	}
+	cb := NewConsistBalancer(opt).(*consistBalancer)
+	return &boundedConsistBalancer{
+		consistBalancer: cb,
+		boundFactor:     boundFactor,
+		tracker:         inflight.NewTracker(),
+	}
+}
+
+type boundedConsistBalancer struct {
+	*consistBalancer
+	boundFactor float64
+	tracker     *inflight.Tracker
+}
+
+// GetPicker implements the Loadbalancer interface.
+func (b *boundedConsistBalancer) GetPicker(e discovery.Result) Picker {
+	var ci *consistInfo
+	if e.Cacheable {
+		cii, ok := b.cachedConsistInfo.Load(e.CacheKey)
+		if !ok {
+			cii, _, _ = b.sfg.Do(e.CacheKey, func() (interface{}, error) {
+				return b.newConsistInfo(e), nil
+			})
+			b.cachedConsistInfo.Store(e.CacheKey, cii)
+		}
+		ci = cii.(*consistInfo)
+	} else {
+		ci = b.newConsistInfo(e)
+	}
+	return &boundedConsistPicker{b: b, info: ci}
+}
+
+// FeedbackMW returns the middleware that releases the in-flight slot taken by
+// the pick once the RPC finishes.
+func (b *boundedConsistBalancer) FeedbackMW() endpoint.Middleware {
+	return b.tracker.FeedbackMW()
+}
+
+func (b *boundedConsistBalancer) Name() string {
+	return "consist_bounded_load"
+}
+
+func (b *boundedConsistBalancer) acquire(addr string) {
+	b.tracker.Acquire(addr)
+}
+
+func (b *boundedConsistBalancer) release(addr string) {
+	b.tracker.Release(addr)
+}
+
+// withinBound reports whether one more request on addr keeps its load under
+// boundFactor times the average load across size instances.
+func (b *boundedConsistBalancer) withinBound(addr string, size int) bool {
+	load := b.tracker.Inflight(addr)
+	avg := float64(b.tracker.TotalInflight()+1) / float64(size)
+	return float64(load+1) <= math.Ceil(b.boundFactor*avg)
+}
+
+type boundedConsistPicker struct {
+	b    *boundedConsistBalancer
+	info *consistInfo
+}
+
+// Next is not concurrency safe.
+func (bp *boundedConsistPicker) Next(ctx context.Context, request interface{}) discovery.Instance {
+	size := len(bp.info.realNodes)
+	if size == 0 {
+		return nil
+	}
+	key := bp.b.opt.GetKey(ctx, request)
+	if key == "" {
+		return nil
+	}
+	vNodes := bp.info.virtualNodes
+	index := sort.Search(len(vNodes), func(i int) bool {
+		return vNodes[i].hash > xxhash3.HashString(key)
+	})
+	if index == len(vNodes) {
+		index = 0
+	}
+	primary := vNodes[index].RealNode.Ins
+	// walk the ring clockwise until an instance has spare capacity; visiting
+	// each real node once is enough, the ring repeats afterwards
+	tried := make(map[string]struct{}, size)
+	for i := 0; len(tried) < size && i < len(vNodes); i++ {
+		ins := vNodes[(index+i)%len(vNodes)].RealNode.Ins
+		addr := ins.Address().String()
+		if _, ok := tried[addr]; ok {
+			continue
+		}
+		tried[addr] = struct{}{}
+		if lbcontext.IsExcluded(ctx, ins) {
+			continue
+		}
+		if bp.b.withinBound(addr, size) {
+			bp.b.acquire(addr)
+			return ins
+		}
+	}
+	// every instance is above the bound, fall back to the primary node
+	bp.b.acquire(primary.Address().String())
+	return primary
+}
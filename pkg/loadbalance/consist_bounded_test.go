@@ -0,0 +1,104 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/discovery"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+func TestBoundedConsistPicker(t *testing.T) {
+	insList := []discovery.Instance{
+		discovery.NewInstance("tcp", "addr1", 10, nil),
+		discovery.NewInstance("tcp", "addr2", 10, nil),
+		discovery.NewInstance("tcp", "addr3", 10, nil),
+	}
+	e := discovery.Result{
+		Cacheable: true,
+		CacheKey:  "test",
+		Instances: insList,
+	}
+
+	balancer := NewConsistBalancerWithBoundedLoad(newTestConsistentHashOption(), 1.25)
+	test.Assert(t, balancer.Name() == "consist_bounded_load")
+	b := balancer.(*boundedConsistBalancer)
+
+	// without load every pick of one key lands on the same instance
+	picker := balancer.GetPicker(e)
+	first := picker.Next(context.TODO(), nil)
+	test.Assert(t, first != nil)
+	b.release(first.Address().String())
+	for i := 0; i < 10; i++ {
+		ins := balancer.GetPicker(e).Next(context.TODO(), nil)
+		test.Assert(t, ins.Address().String() == first.Address().String())
+		b.release(ins.Address().String())
+	}
+
+	// pile in-flight load onto the primary without releasing: the picks must
+	// spill over to other instances instead of hotspotting one node
+	seen := make(map[string]int)
+	for i := 0; i < 9; i++ {
+		ins := balancer.GetPicker(e).Next(context.TODO(), nil)
+		seen[ins.Address().String()]++
+	}
+	test.Assert(t, len(seen) == 3, seen)
+	// the bound caps every instance well below the 9 picks a plain
+	// consistent hash would send to the primary
+	for _, n := range seen {
+		test.Assert(t, n <= 4, seen)
+	}
+}
+
+func TestBoundedConsistPickerFallback(t *testing.T) {
+	// a single overloaded instance still serves as fallback
+	insList := []discovery.Instance{
+		discovery.NewInstance("tcp", "addr1", 10, nil),
+	}
+	e := discovery.Result{
+		Cacheable: false,
+		Instances: insList,
+	}
+	balancer := NewConsistBalancerWithBoundedLoad(newTestConsistentHashOption(), 0)
+	for i := 0; i < 10; i++ {
+		ins := balancer.GetPicker(e).Next(context.TODO(), nil)
+		test.Assert(t, ins.Address().String() == "addr1")
+	}
+}
+
+func TestBoundedConsistBalancerFeedbackMW(t *testing.T) {
+	insList := []discovery.Instance{
+		discovery.NewInstance("tcp", "addr1", 10, nil),
+	}
+	e := discovery.Result{Cacheable: false, Instances: insList}
+	balancer := NewConsistBalancerWithBoundedLoad(newTestConsistentHashOption(), 1.25)
+	b := balancer.(*boundedConsistBalancer)
+
+	ins := balancer.GetPicker(e).Next(context.TODO(), nil)
+	test.Assert(t, ins != nil)
+	ep := b.FeedbackMW()(func(ctx context.Context, req, resp interface{}) error {
+		return nil
+	})
+	to := rpcinfo.NewEndpointInfo("callee", "method", ins.Address(), nil)
+	ri := rpcinfo.NewRPCInfo(nil, to, nil, nil, nil)
+	ctx := rpcinfo.NewCtxWithRPCInfo(context.Background(), ri)
+	test.Assert(t, ep(ctx, nil, nil) == nil)
+	test.Assert(t, b.tracker.TotalInflight() == 0)
+}
@@ -422,3 +422,74 @@ func BenchmarkConsistPicker_RandomDistributionKey(bb *testing.B) {
 		n *= 10
 	}
 }
+
+func TestConsistBalancerIncrementalRebalance(t *testing.T) {
+	opt := newTestConsistentHashOption()
+	insList := []discovery.Instance{
+		discovery.NewInstance("tcp", "addr1", 10, nil),
+		discovery.NewInstance("tcp", "addr2", 10, nil),
+	}
+	e := discovery.Result{Cacheable: true, CacheKey: "test", Instances: insList}
+
+	cb := NewConsistBalancer(opt).(*consistBalancer)
+	cb.GetPicker(e) // build and cache the ring
+
+	added := discovery.NewInstance("tcp", "addr3", 10, nil)
+	removed := insList[1]
+	e = discovery.Result{
+		Cacheable: true,
+		CacheKey:  "test",
+		Instances: []discovery.Instance{insList[0], added},
+	}
+	cb.Rebalance(discovery.Change{
+		Result:  e,
+		Added:   []discovery.Instance{added},
+		Removed: []discovery.Instance{removed},
+	})
+
+	info, ok := cb.cachedConsistInfo.Load("test")
+	test.Assert(t, ok)
+	ci := info.(*consistInfo)
+	test.Assert(t, len(ci.realNodes) == 2)
+	test.Assert(t, len(ci.virtualNodes) == 2*10*int(opt.VirtualFactor))
+	// the merged ring must stay sorted and hold no vnode of the removed instance
+	for i := range ci.virtualNodes {
+		if i > 0 {
+			test.Assert(t, ci.virtualNodes[i-1].hash <= ci.virtualNodes[i].hash)
+		}
+		test.Assert(t, ci.virtualNodes[i].RealNode.Ins.Address().String() != "addr2")
+	}
+
+	stats := cb.ringDump().(map[string]ringStats)
+	test.Assert(t, stats["test"].LastRebuildMode == ringRebuildIncremental)
+	test.Assert(t, stats["test"].RealNodes == 2)
+	test.Assert(t, stats["test"].VirtualNodes == len(ci.virtualNodes))
+
+	// picks after the change only land on surviving instances
+	picker := cb.GetPicker(e)
+	for i := 0; i < 10; i++ {
+		ctx := context.WithValue(context.Background(), keyCtxKey, fmt.Sprintf("key%d", i))
+		ins := picker.Next(ctx, nil)
+		test.Assert(t, ins.Address().String() != "addr2", ins.Address())
+	}
+}
+
+func TestConsistBalancerRingStats(t *testing.T) {
+	opt := newTestConsistentHashOption()
+	insList := []discovery.Instance{
+		discovery.NewInstance("tcp", "addr1", 10, nil),
+	}
+	e := discovery.Result{Cacheable: true, CacheKey: "test", Instances: insList}
+
+	cb := NewConsistBalancer(opt).(*consistBalancer)
+	cb.GetPicker(e)
+	stats := cb.ringDump().(map[string]ringStats)
+	test.Assert(t, stats["test"].LastRebuildMode == ringRebuildFull)
+	test.Assert(t, stats["test"].RealNodes == 1)
+	test.Assert(t, stats["test"].VirtualNodes == 10*int(opt.VirtualFactor))
+
+	cb.Delete(discovery.Change{Result: e})
+	stats = cb.ringDump().(map[string]ringStats)
+	_, ok := stats["test"]
+	test.Assert(t, !ok)
+}
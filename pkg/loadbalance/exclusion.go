@@ -0,0 +1,54 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"context"
+
+	"github.com/cloudwego/kitex/pkg/discovery"
+	"github.com/cloudwego/kitex/pkg/loadbalance/lbcontext"
+)
+
+// cap the retries a picker spends on skipping excluded instances
+const maxExclusionAttempts = 128
+
+// nextExcluding repeats next until it yields an instance not excluded via
+// lbcontext.WithExcluded, trying at most attempts times. When every candidate
+// is excluded it fails open and returns the first pick, an instance already
+// tried beats no instance at all.
+func nextExcluding(ctx context.Context, attempts int, next func() discovery.Instance) discovery.Instance {
+	if !lbcontext.HasExcluded(ctx) {
+		return next()
+	}
+	if attempts > maxExclusionAttempts {
+		attempts = maxExclusionAttempts
+	}
+	var first discovery.Instance
+	for i := 0; i < attempts; i++ {
+		ins := next()
+		if ins == nil {
+			break
+		}
+		if !lbcontext.IsExcluded(ctx, ins) {
+			return ins
+		}
+		if first == nil {
+			first = ins
+		}
+	}
+	return first
+}
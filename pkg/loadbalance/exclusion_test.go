@@ -0,0 +1,118 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/discovery"
+	"github.com/cloudwego/kitex/pkg/loadbalance/lbcontext"
+)
+
+func excludingTestResult() discovery.Result {
+	return discovery.Result{
+		Cacheable: false,
+		Instances: []discovery.Instance{
+			discovery.NewInstance("tcp", "addr1", 10, nil),
+			discovery.NewInstance("tcp", "addr2", 20, nil),
+			discovery.NewInstance("tcp", "addr3", 30, nil),
+		},
+	}
+}
+
+func testPickerExcludes(t *testing.T, balancer Loadbalancer) {
+	t.Helper()
+	e := excludingTestResult()
+	excluded := e.Instances[:2]
+	ctx := lbcontext.WithExcluded(context.Background(), excluded)
+	picker := balancer.GetPicker(e)
+	for i := 0; i < 30; i++ {
+		ins := picker.Next(ctx, nil)
+		test.Assert(t, ins != nil, balancer.Name())
+		test.Assert(t, ins.Address().String() == "addr3", balancer.Name(), ins.Address())
+	}
+}
+
+func TestPickersHonorExclusion(t *testing.T) {
+	balancers := []Loadbalancer{
+		NewWeightedBalancer(),
+		NewWeightedRoundRobinBalancer(),
+		NewInterleavedWeightedRoundRobinBalancer(),
+		NewWeightedRandomWithAliasMethodBalancer(),
+		NewP2CBalancer(),
+	}
+	for _, balancer := range balancers {
+		testPickerExcludes(t, balancer)
+	}
+}
+
+func TestRoundRobinPickerExclusion(t *testing.T) {
+	e := excludingTestResult()
+	// unweighted instances take the round-robin picker path
+	for i := range e.Instances {
+		e.Instances[i] = discovery.NewInstance("tcp", e.Instances[i].Address().String(), 10, nil)
+	}
+	testPickerExcludes(t, NewWeightedRoundRobinBalancer())
+}
+
+func TestExclusionFailOpen(t *testing.T) {
+	e := excludingTestResult()
+	// excluding every instance must not black-hole the request
+	ctx := lbcontext.WithExcluded(context.Background(), e.Instances)
+	picker := NewWeightedBalancer().GetPicker(e)
+	test.Assert(t, picker.Next(ctx, nil) != nil)
+}
+
+func TestConsistPickerExclusion(t *testing.T) {
+	e := excludingTestResult()
+	opt := NewConsistentHashOption(func(ctx context.Context, request interface{}) string {
+		return "key"
+	})
+	picker := NewConsistBalancer(opt).GetPicker(e)
+	primary := picker.Next(context.Background(), nil)
+	test.Assert(t, primary != nil)
+
+	// excluding the primary moves the pick to another node on the ring
+	ctx := lbcontext.WithExcluded(context.Background(), []discovery.Instance{primary})
+	ins := picker.Next(ctx, nil)
+	test.Assert(t, ins != nil)
+	test.Assert(t, ins.Address().String() != primary.Address().String())
+
+	// all excluded: fail open back to the primary
+	ctx = lbcontext.WithExcluded(context.Background(), e.Instances)
+	ins = picker.Next(ctx, nil)
+	test.Assert(t, ins != nil)
+	test.Assert(t, ins.Address().String() == primary.Address().String())
+}
+
+func TestBoundedConsistPickerExclusion(t *testing.T) {
+	e := excludingTestResult()
+	opt := NewConsistentHashOption(func(ctx context.Context, request interface{}) string {
+		return "key"
+	})
+	balancer := NewConsistBalancerWithBoundedLoad(opt, defaultBoundFactor)
+	picker := balancer.GetPicker(e)
+	primary := picker.Next(context.Background(), nil)
+	test.Assert(t, primary != nil)
+
+	ctx := lbcontext.WithExcluded(context.Background(), []discovery.Instance{primary})
+	ins := picker.Next(ctx, nil)
+	test.Assert(t, ins != nil)
+	test.Assert(t, ins.Address().String() != primary.Address().String())
+}
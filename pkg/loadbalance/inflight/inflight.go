@@ -0,0 +1,144 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package inflight maintains per-address in-flight request counts and latency
+// stats, shared by the load-aware balancers (P2C, least-connection, bounded
+// load) instead of each keeping private state.
+package inflight
+
+import (
+	"context"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudwego/kitex/pkg/endpoint"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+// DefaultDecayTime controls how fast old latency observations fade out of the
+// peak-EWMA: observations older than several decay windows barely count.
+const DefaultDecayTime = 10 * time.Second
+
+// Tracker maintains per-address in-flight counts and a peak-EWMA of the
+// request latency. Balancers call Acquire on pick; the middleware returned
+// from FeedbackMW releases the slot and feeds the latency back when the RPC
+// finishes.
+type Tracker struct {
+	stats sync.Map // map[address]*stat
+	total int64
+	decay int64
+}
+
+type stat struct {
+	inflight int64
+	latency  uint64 // peak-EWMA of the request cost in nanoseconds
+	lastTick int64  // unix nano of the last latency update
+}
+
+// NewTracker creates a Tracker with DefaultDecayTime.
+func NewTracker() *Tracker {
+	return &Tracker{decay: int64(DefaultDecayTime)}
+}
+
+// Acquire takes one in-flight slot on addr; called by balancers on pick.
+func (t *Tracker) Acquire(addr string) {
+	atomic.AddInt64(&t.stat(addr).inflight, 1)
+	atomic.AddInt64(&t.total, 1)
+}
+
+// Release returns the in-flight slot of addr without a latency observation.
+func (t *Tracker) Release(addr string) {
+	t.release(t.stat(addr))
+}
+
+// Finish returns the in-flight slot of addr and feeds one observed request
+// cost into the peak-EWMA; non-positive costs only release the slot.
+func (t *Tracker) Finish(addr string, cost time.Duration) {
+	s := t.stat(addr)
+	t.release(s)
+	if cost <= 0 {
+		return
+	}
+	now := time.Now().UnixNano()
+	last := atomic.SwapInt64(&s.lastTick, now)
+	w := math.Exp(-float64(now-last) / float64(t.decay))
+	old := atomic.LoadUint64(&s.latency)
+	observed := uint64(cost)
+	if old == 0 || observed > old {
+		// peak-EWMA: latency spikes take effect immediately,
+		// only recoveries are smoothed
+		atomic.StoreUint64(&s.latency, observed)
+		return
+	}
+	atomic.StoreUint64(&s.latency, uint64(float64(old)*w+float64(observed)*(1-w)))
+}
+
+// Inflight returns the current in-flight count of addr.
+func (t *Tracker) Inflight(addr string) int64 {
+	return atomic.LoadInt64(&t.stat(addr).inflight)
+}
+
+// TotalInflight returns the in-flight count summed over all addresses.
+func (t *Tracker) TotalInflight() int64 {
+	return atomic.LoadInt64(&t.total)
+}
+
+// Latency returns the peak-EWMA latency of addr, 0 when never observed.
+func (t *Tracker) Latency(addr string) time.Duration {
+	return time.Duration(atomic.LoadUint64(&t.stat(addr).latency))
+}
+
+// Remove drops the stats of addr, eg. when discovery removes the instance.
+func (t *Tracker) Remove(addr string) {
+	t.stats.Delete(addr)
+}
+
+// FeedbackMW returns the RPC-finish hook releasing the slot taken by the pick
+// and feeding the request cost back; install it on the client via
+// client.WithMiddleware when using a balancer built on this tracker.
+func (t *Tracker) FeedbackMW() endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request, response interface{}) (err error) {
+			start := time.Now()
+			err = next(ctx, request, response)
+			if ri := rpcinfo.GetRPCInfo(ctx); ri != nil {
+				if addr := ri.To().Address(); addr != nil {
+					t.Finish(addr.String(), time.Since(start))
+				}
+			}
+			return err
+		}
+	}
+}
+
+func (t *Tracker) stat(addr string) *stat {
+	if s, ok := t.stats.Load(addr); ok {
+		return s.(*stat)
+	}
+	s, _ := t.stats.LoadOrStore(addr, &stat{})
+	return s.(*stat)
+}
+
+func (t *Tracker) release(s *stat) {
+	if atomic.AddInt64(&s.inflight, -1) < 0 {
+		// a release without a matching pick, e.g. the MW without the balancer
+		atomic.AddInt64(&s.inflight, 1)
+		return
+	}
+	atomic.AddInt64(&t.total, -1)
+}
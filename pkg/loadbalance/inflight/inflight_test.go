@@ -0,0 +1,92 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package inflight
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+	"github.com/cloudwego/kitex/pkg/utils"
+)
+
+func TestTrackerCounts(t *testing.T) {
+	tr := NewTracker()
+	tr.Acquire("addr1")
+	tr.Acquire("addr1")
+	tr.Acquire("addr2")
+	test.Assert(t, tr.Inflight("addr1") == 2)
+	test.Assert(t, tr.Inflight("addr2") == 1)
+	test.Assert(t, tr.TotalInflight() == 3)
+
+	tr.Release("addr1")
+	test.Assert(t, tr.Inflight("addr1") == 1)
+	test.Assert(t, tr.TotalInflight() == 2)
+
+	// a release without a matching pick must not go negative
+	tr.Release("addr3")
+	test.Assert(t, tr.Inflight("addr3") == 0)
+	test.Assert(t, tr.TotalInflight() == 2)
+
+	tr.Remove("addr1")
+	test.Assert(t, tr.Inflight("addr1") == 0)
+}
+
+func TestTrackerLatency(t *testing.T) {
+	tr := NewTracker()
+	test.Assert(t, tr.Latency("addr1") == 0)
+
+	tr.Acquire("addr1")
+	tr.Finish("addr1", time.Millisecond)
+	test.Assert(t, tr.Latency("addr1") == time.Millisecond)
+
+	// spikes take effect immediately
+	tr.Acquire("addr1")
+	tr.Finish("addr1", 100*time.Millisecond)
+	test.Assert(t, tr.Latency("addr1") == 100*time.Millisecond)
+
+	// recoveries are smoothed, the EWMA stays above the new observation
+	tr.Acquire("addr1")
+	tr.Finish("addr1", time.Millisecond)
+	lat := tr.Latency("addr1")
+	test.Assert(t, lat > time.Millisecond && lat < 100*time.Millisecond, lat)
+
+	// non-positive costs only release the slot
+	tr.Acquire("addr2")
+	tr.Finish("addr2", 0)
+	test.Assert(t, tr.Inflight("addr2") == 0)
+	test.Assert(t, tr.Latency("addr2") == 0)
+}
+
+func TestTrackerFeedbackMW(t *testing.T) {
+	tr := NewTracker()
+	tr.Acquire("127.0.0.1:8888")
+
+	ep := tr.FeedbackMW()(func(ctx context.Context, req, resp interface{}) error {
+		time.Sleep(time.Millisecond)
+		return nil
+	})
+	to := rpcinfo.NewEndpointInfo("callee", "method", utils.NewNetAddr("tcp", "127.0.0.1:8888"), nil)
+	ri := rpcinfo.NewRPCInfo(nil, to, nil, nil, nil)
+	ctx := rpcinfo.NewCtxWithRPCInfo(context.Background(), ri)
+	test.Assert(t, ep(ctx, nil, nil) == nil)
+
+	test.Assert(t, tr.Inflight("127.0.0.1:8888") == 0)
+	test.Assert(t, tr.Latency("127.0.0.1:8888") > 0)
+}
@@ -41,6 +41,7 @@ type InterleavedWeightedRoundRobinPicker struct {
 	current *iwrrQueue
 	next    *iwrrQueue
 	gcd     int
+	vsize   int // picks of one full round, totalWeight/gcd
 
 	lock sync.Mutex
 }
@@ -53,9 +54,11 @@ func newInterleavedWeightedRoundRobinPicker(instances []discovery.Instance) Pick
 	size := uint64(len(instances))
 	offset := fastrand.Uint64n(size)
 	gcd := 0
+	totalWeight := 0
 	for idx := uint64(0); idx < size; idx++ {
 		ins := instances[(idx+offset)%size]
 		gcd = gcdInt(gcd, ins.Weight())
+		totalWeight += ins.Weight()
 
 		iwrrp.current.enqueue(&iwrrNode{
 			Instance:  ins,
@@ -64,11 +67,16 @@ func newInterleavedWeightedRoundRobinPicker(instances []discovery.Instance) Pick
 	}
 
 	iwrrp.gcd = gcd
+	iwrrp.vsize = totalWeight / gcd
 
 	return iwrrp
 }
 
 func (ip *InterleavedWeightedRoundRobinPicker) Next(ctx context.Context, request interface{}) discovery.Instance {
+	return nextExcluding(ctx, ip.vsize, ip.pick)
+}
+
+func (ip *InterleavedWeightedRoundRobinPicker) pick() discovery.Instance {
 	ip.lock.Lock()
 	defer ip.lock.Unlock()
 
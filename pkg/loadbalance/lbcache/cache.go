@@ -109,6 +109,7 @@ func newBalancerFactory(resolver discovery.Resolver, balancer loadbalance.Loadba
 		b.Hookable = noopHookRebalancer{}
 	}
 	go b.watcher()
+	diagnosis.RegisterProbeFunc(opts.DiagnosisService, ResolveStatsKey, b.statsDump)
 	return b
 }
 
@@ -163,6 +164,7 @@ func (b *BalancerFactory) Get(ctx context.Context, target rpcinfo.EndpointInfo)
 		return val.(*Balancer), nil
 	}
 	val, err, _ := b.sfg.Do(desc, func() (interface{}, error) {
+		begin := time.Now()
 		res, err := b.resolver.Resolve(ctx, desc)
 		if err != nil {
 			return nil, err
@@ -172,8 +174,22 @@ func (b *BalancerFactory) Get(ctx context.Context, target rpcinfo.EndpointInfo)
 			b:      b,
 			target: desc,
 		}
+		bl.stats.recordResolve(time.Since(begin), nil)
+		bl.stats.recordUpdate(len(res.Instances), false)
 		bl.res.Store(res)
 		bl.sharedTicker = getSharedTicker(bl, b.opts.RefreshInterval)
+		// push-capable resolvers deliver changes as they happen; the
+		// ticker stays on as a periodic resync safety net
+		if w, ok := b.resolver.(discovery.Watcher); ok {
+			watchCtx, cancel := context.WithCancel(context.Background())
+			if ch, err := w.Watch(watchCtx, desc); err != nil {
+				cancel()
+				klog.Warnf("KITEX: resolver watch failed, fallback to polling, key=%s error=%s", desc, err.Error())
+			} else {
+				bl.watchCancel = cancel
+				go bl.watch(watchCtx, ch)
+			}
+		}
 		b.cache.Store(desc, bl)
 		return bl, nil
 	})
@@ -191,21 +207,56 @@ type Balancer struct {
 	res          atomic.Value // newest and previous discovery result
 	expire       int32        // 0 = normal, 1 = expire and collect next ticker
 	sharedTicker *utils.SharedTicker
+	watchCancel  context.CancelFunc // stops the resolver watch, nil when polling only
+	stats        resolveStats       // resolve behavior for the diagnosis probe
 }
 
 func (bl *Balancer) Refresh() {
+	begin := time.Now()
 	res, err := bl.b.resolver.Resolve(context.Background(), bl.target)
+	bl.stats.recordResolve(time.Since(begin), err)
 	if err != nil {
 		klog.Warnf("KITEX: resolver refresh failed, key=%s error=%s", bl.target, err.Error())
 		return
 	}
+	bl.update(res)
+}
+
+// watch applies pushed changes until the channel closes or ctx is done.
+func (bl *Balancer) watch(ctx context.Context, ch <-chan discovery.Change) {
+	for {
+		select {
+		case change, ok := <-ch:
+			if !ok {
+				return
+			}
+			bl.update(change.Result)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// update diffs res against the held result, notifies the rebalancer and
+// stores it; shared by the periodic refresh and the resolver watch.
+func (bl *Balancer) update(res discovery.Result) {
 	renameResultCacheKey(&res, bl.b.resolver.Name())
 	prev := bl.res.Load().(discovery.Result)
+	diffed := false
 	if bl.b.rebalancer != nil {
-		if ch, ok := bl.b.resolver.Diff(res.CacheKey, prev, res); ok {
-			bl.b.rebalancer.Rebalance(ch)
+		var ch discovery.Change
+		if ch, diffed = bl.b.resolver.Diff(res.CacheKey, prev, res); diffed {
+			// weight-only adjustments reported as remove+add must not reset
+			// address-keyed balancer state
+			bl.b.rebalancer.Rebalance(discovery.NormalizeChange(ch))
 		}
 	}
+	bl.stats.recordUpdate(len(res.Instances), diffed)
+	if bl.b.rebalancer != nil && !diffed && len(prev.Instances) == len(res.Instances) {
+		// nothing changed: keep the previous instance slice so back-to-back
+		// snapshots share it and the fresh copy can be collected right away
+		res.Instances = prev.Instances
+	}
 	// replace previous result
 	bl.res.Store(res)
 }
@@ -241,6 +292,10 @@ func (bl *Balancer) close() {
 			},
 		})
 	}
+	// stop the resolver watch if any
+	if bl.watchCancel != nil {
+		bl.watchCancel()
+	}
 	// delete from sharedTicker
 	bl.sharedTicker.Delete(bl)
 }
@@ -108,6 +108,88 @@ func TestBalancerCache(t *testing.T) {
 	}
 }
 
+type watchableResolver struct {
+	discovery.SynthesizedResolver
+	ch chan discovery.Change
+}
+
+// Watch implements the discovery.Watcher interface.
+func (r *watchableResolver) Watch(ctx context.Context, desc string) (<-chan discovery.Change, error) {
+	return r.ch, nil
+}
+
+func TestBalancerWatch(t *testing.T) {
+	ins := discovery.NewInstance("tcp", "127.0.0.1:8888", 10, nil)
+	r := &watchableResolver{
+		SynthesizedResolver: discovery.SynthesizedResolver{
+			TargetFunc: func(ctx context.Context, target rpcinfo.EndpointInfo) string {
+				return "mockRoute"
+			},
+			ResolveFunc: func(ctx context.Context, key string) (discovery.Result, error) {
+				return discovery.Result{Cacheable: true, CacheKey: "svc", Instances: []discovery.Instance{ins}}, nil
+			},
+			NameFunc: func() string { return t.Name() },
+		},
+		ch: make(chan discovery.Change, 1),
+	}
+	rebalanced := make(chan discovery.Change, 1)
+	lb := &mockLoadbalancer{
+		rebalanceFunc: func(ch discovery.Change) {
+			rebalanced <- ch
+		},
+	}
+	// a long refresh interval so only the watch can deliver the change
+	blf := NewBalancerFactory(r, lb, Options{RefreshInterval: time.Hour})
+	b, err := blf.Get(context.Background(), nil)
+	test.Assert(t, err == nil)
+	res, ok := b.GetResult()
+	test.Assert(t, ok)
+	test.Assert(t, len(res.Instances) == 1)
+
+	// push a change through the watch channel and wait for the rebalance
+	ins2 := discovery.NewInstance("tcp", "127.0.0.1:8889", 10, nil)
+	r.ch <- discovery.Change{
+		Result: discovery.Result{Cacheable: true, CacheKey: "svc", Instances: []discovery.Instance{ins, ins2}},
+	}
+	select {
+	case ch := <-rebalanced:
+		test.Assert(t, ch.Result.CacheKey == t.Name()+":svc", ch.Result.CacheKey)
+		test.Assert(t, len(ch.Added) == 1)
+		test.Assert(t, ch.Added[0].Address().String() == "127.0.0.1:8889")
+	case <-time.After(time.Second):
+		t.Fatal("watch change not applied")
+	}
+	res, ok = b.GetResult()
+	test.Assert(t, ok)
+	test.Assert(t, len(res.Instances) == 2)
+
+	// close stops the watch goroutine
+	b.close()
+	test.Assert(t, b.watchCancel != nil)
+}
+
+type mockLoadbalancer struct {
+	rebalanceFunc func(ch discovery.Change)
+}
+
+// GetPicker implements the loadbalance.Loadbalancer interface.
+func (m *mockLoadbalancer) GetPicker(res discovery.Result) loadbalance.Picker {
+	return nil
+}
+
+// Name implements the loadbalance.Loadbalancer interface.
+func (m *mockLoadbalancer) Name() string { return "mock_watch" }
+
+// Rebalance implements the loadbalance.Rebalancer interface.
+func (m *mockLoadbalancer) Rebalance(ch discovery.Change) {
+	if m.rebalanceFunc != nil {
+		m.rebalanceFunc(ch)
+	}
+}
+
+// Delete implements the loadbalance.Rebalancer interface.
+func (m *mockLoadbalancer) Delete(ch discovery.Change) {}
+
 type mockRebalancer struct {
 	rebalanceFunc func(ch discovery.Change)
 	deleteFunc    func(ch discovery.Change)
@@ -0,0 +1,99 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lbcache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cloudwego/kitex/pkg/diagnosis"
+)
+
+// ResolveStatsKey is the probe name the per-target resolve stats are
+// registered under when Options.DiagnosisService is set.
+const ResolveStatsKey diagnosis.ProbeName = "resolve_stats"
+
+// ResolveStats is a snapshot of the resolve behavior of one target.
+type ResolveStats struct {
+	// Resolves counts resolve attempts, Errors the failed ones.
+	Resolves int64 `json:"resolves"`
+	Errors   int64 `json:"errors"`
+	// ErrorStreak counts consecutive failures, 0 after any success.
+	ErrorStreak int64 `json:"error_streak"`
+	// Diffs counts refreshes whose result actually changed.
+	Diffs int64 `json:"diffs"`
+	// Instances is the size of the last stored result.
+	Instances int `json:"instances"`
+	// LastCost is the duration of the last resolve attempt.
+	LastCost time.Duration `json:"last_cost"`
+	// LastSuccess is when the last resolve succeeded, zero when none has.
+	LastSuccess time.Time `json:"last_success"`
+	// LastError is the message of the most recent failure, cleared on success.
+	LastError string `json:"last_error,omitempty"`
+}
+
+// resolveStats is the mutable form held per Balancer.
+type resolveStats struct {
+	mu sync.Mutex
+	ResolveStats
+}
+
+func (s *resolveStats) recordResolve(cost time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Resolves++
+	s.LastCost = cost
+	if err != nil {
+		s.Errors++
+		s.ErrorStreak++
+		s.LastError = err.Error()
+		return
+	}
+	s.ErrorStreak = 0
+	s.LastError = ""
+	s.LastSuccess = time.Now()
+}
+
+func (s *resolveStats) recordUpdate(instances int, diffed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Instances = instances
+	if diffed {
+		s.Diffs++
+	}
+}
+
+func (s *resolveStats) snapshot() ResolveStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ResolveStats
+}
+
+// ResolveStats returns a snapshot of the target's resolve behavior.
+func (bl *Balancer) ResolveStats() ResolveStats {
+	return bl.stats.snapshot()
+}
+
+// statsDump builds the probe payload: target description -> stats.
+func (b *BalancerFactory) statsDump() interface{} {
+	dump := make(map[string]ResolveStats)
+	b.cache.Range(func(key, value interface{}) bool {
+		dump[key.(string)] = value.(*Balancer).ResolveStats()
+		return true
+	})
+	return dump
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lbcache
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/discovery"
+	"github.com/cloudwego/kitex/pkg/loadbalance"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+func TestResolveStats(t *testing.T) {
+	ins := discovery.NewInstance("tcp", "127.0.0.1:8888", 10, nil)
+	healthy := true
+	instances := []discovery.Instance{ins}
+	r := &discovery.SynthesizedResolver{
+		TargetFunc: func(ctx context.Context, target rpcinfo.EndpointInfo) string {
+			return "mockRoute"
+		},
+		ResolveFunc: func(ctx context.Context, key string) (discovery.Result, error) {
+			if !healthy {
+				return discovery.Result{}, errors.New("registry down")
+			}
+			return discovery.Result{Cacheable: true, CacheKey: "svc", Instances: instances}, nil
+		},
+		NameFunc: func() string { return t.Name() },
+	}
+	blf := NewBalancerFactory(r, loadbalance.NewWeightedBalancer(), Options{})
+	b, err := blf.Get(context.Background(), nil)
+	test.Assert(t, err == nil)
+
+	stats := b.ResolveStats()
+	test.Assert(t, stats.Resolves == 1, stats.Resolves)
+	test.Assert(t, stats.Instances == 1)
+	test.Assert(t, !stats.LastSuccess.IsZero())
+
+	// failed refreshes grow the error streak and keep the last error
+	healthy = false
+	b.Refresh()
+	b.Refresh()
+	stats = b.ResolveStats()
+	test.Assert(t, stats.Resolves == 3)
+	test.Assert(t, stats.Errors == 2)
+	test.Assert(t, stats.ErrorStreak == 2)
+	test.Assert(t, stats.LastError == "registry down")
+
+	// recovery with a changed result resets the streak and counts the diff
+	healthy = true
+	instances = []discovery.Instance{ins, discovery.NewInstance("tcp", "127.0.0.1:8889", 10, nil)}
+	b.Refresh()
+	stats = b.ResolveStats()
+	test.Assert(t, stats.ErrorStreak == 0)
+	test.Assert(t, stats.LastError == "")
+	test.Assert(t, stats.Diffs == 1, stats.Diffs)
+	test.Assert(t, stats.Instances == 2)
+
+	// the probe dump is keyed by target description
+	dump := blf.statsDump().(map[string]ResolveStats)
+	test.Assert(t, dump["mockRoute"].Resolves == 4)
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package lbcontext carries per-request hints for load balancing, like the
+// instances a pick should avoid because earlier attempts already tried them.
+package lbcontext
+
+import (
+	"context"
+
+	"github.com/cloudwego/kitex/pkg/discovery"
+)
+
+type excludedCtxKey struct{}
+
+// WithExcluded marks instances as excluded for picks made with the returned
+// context. Builtin pickers skip excluded instances when another candidate is
+// available and fail open otherwise. Calling WithExcluded again adds to the
+// exclusion of the parent context, which suits retry and hedging loops that
+// exclude one more instance per attempt.
+func WithExcluded(ctx context.Context, instances []discovery.Instance) context.Context {
+	if len(instances) == 0 {
+		return ctx
+	}
+	old, _ := ctx.Value(excludedCtxKey{}).(map[string]struct{})
+	excluded := make(map[string]struct{}, len(old)+len(instances))
+	for addr := range old {
+		excluded[addr] = struct{}{}
+	}
+	for _, ins := range instances {
+		excluded[ins.Address().String()] = struct{}{}
+	}
+	return context.WithValue(ctx, excludedCtxKey{}, excluded)
+}
+
+// HasExcluded reports whether ctx carries a non-empty exclusion list,
+// letting pickers keep their fast path when it doesn't.
+func HasExcluded(ctx context.Context) bool {
+	excluded, _ := ctx.Value(excludedCtxKey{}).(map[string]struct{})
+	return len(excluded) > 0
+}
+
+// IsExcluded reports whether the instance is excluded in ctx.
+func IsExcluded(ctx context.Context, ins discovery.Instance) bool {
+	excluded, _ := ctx.Value(excludedCtxKey{}).(map[string]struct{})
+	if len(excluded) == 0 {
+		return false
+	}
+	_, ok := excluded[ins.Address().String()]
+	return ok
+}
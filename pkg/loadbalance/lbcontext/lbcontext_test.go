@@ -0,0 +1,46 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lbcontext
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/discovery"
+)
+
+func TestWithExcluded(t *testing.T) {
+	ins1 := discovery.NewInstance("tcp", "addr1", 10, nil)
+	ins2 := discovery.NewInstance("tcp", "addr2", 10, nil)
+
+	ctx := context.Background()
+	test.Assert(t, !HasExcluded(ctx))
+	test.Assert(t, !IsExcluded(ctx, ins1))
+	test.Assert(t, WithExcluded(ctx, nil) == ctx)
+
+	ctx = WithExcluded(ctx, []discovery.Instance{ins1})
+	test.Assert(t, HasExcluded(ctx))
+	test.Assert(t, IsExcluded(ctx, ins1))
+	test.Assert(t, !IsExcluded(ctx, ins2))
+
+	// exclusions accumulate across calls without mutating the parent
+	ctx2 := WithExcluded(ctx, []discovery.Instance{ins2})
+	test.Assert(t, IsExcluded(ctx2, ins1))
+	test.Assert(t, IsExcluded(ctx2, ins2))
+	test.Assert(t, !IsExcluded(ctx, ins2))
+}
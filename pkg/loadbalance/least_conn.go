@@ -0,0 +1,154 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bytedance/gopkg/lang/fastrand"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/cloudwego/kitex/pkg/discovery"
+	"github.com/cloudwego/kitex/pkg/endpoint"
+	"github.com/cloudwego/kitex/pkg/loadbalance/inflight"
+	"github.com/cloudwego/kitex/pkg/loadbalance/lbcontext"
+)
+
+// NewLeastConnBalancer creates a balancer picking the instance with the
+// fewest in-flight requests, suited for long-lived or streaming workloads
+// where round robin piles new requests onto instances still busy with old
+// ones. In-flight counts are released by the middleware returned from
+// FeedbackMW, which must be installed on the client via client.WithMiddleware.
+func NewLeastConnBalancer() Loadbalancer {
+	return &leastConnBalancer{tracker: inflight.NewTracker()}
+}
+
+// NewWeightedLeastConnBalancer is like NewLeastConnBalancer but scales each
+// instance's in-flight count by its weight, so an instance with twice the
+// weight is expected to carry twice the connections.
+func NewWeightedLeastConnBalancer() Loadbalancer {
+	return &leastConnBalancer{tracker: inflight.NewTracker(), weighted: true}
+}
+
+type leastConnBalancer struct {
+	pickerCache sync.Map
+	sfg         singleflight.Group
+	tracker     *inflight.Tracker
+	weighted    bool
+}
+
+// GetPicker implements the Loadbalancer interface.
+func (b *leastConnBalancer) GetPicker(e discovery.Result) Picker {
+	if !e.Cacheable {
+		return &leastConnPicker{b: b, instances: e.Instances}
+	}
+	picker, ok := b.pickerCache.Load(e.CacheKey)
+	if !ok {
+		picker, _, _ = b.sfg.Do(e.CacheKey, func() (interface{}, error) {
+			p := &leastConnPicker{b: b, instances: e.Instances}
+			b.pickerCache.Store(e.CacheKey, p)
+			return p, nil
+		})
+	}
+	return picker.(Picker)
+}
+
+// Rebalance implements the Rebalancer interface.
+func (b *leastConnBalancer) Rebalance(change discovery.Change) {
+	if !change.Result.Cacheable {
+		return
+	}
+	b.pickerCache.Store(change.Result.CacheKey, &leastConnPicker{b: b, instances: change.Result.Instances})
+	for i := range change.Removed {
+		b.tracker.Remove(change.Removed[i].Address().String())
+	}
+}
+
+// Delete implements the Rebalancer interface.
+func (b *leastConnBalancer) Delete(change discovery.Change) {
+	if !change.Result.Cacheable {
+		return
+	}
+	b.pickerCache.Delete(change.Result.CacheKey)
+}
+
+// FeedbackMW returns the middleware that releases the in-flight slot taken by
+// the pick once the RPC finishes.
+func (b *leastConnBalancer) FeedbackMW() endpoint.Middleware {
+	return b.tracker.FeedbackMW()
+}
+
+func (b *leastConnBalancer) Name() string {
+	if b.weighted {
+		return "weighted_least_conn"
+	}
+	return "least_conn"
+}
+
+// score is the load one more request would see on ins; weighted mode divides
+// by the instance weight so heavier instances take proportionally more.
+func (b *leastConnBalancer) score(ins discovery.Instance) float64 {
+	load := float64(b.tracker.Inflight(ins.Address().String()) + 1)
+	if !b.weighted {
+		return load
+	}
+	weight := ins.Weight()
+	if weight <= 0 {
+		weight = 1
+	}
+	return load / float64(weight)
+}
+
+type leastConnPicker struct {
+	b         *leastConnBalancer
+	instances []discovery.Instance
+}
+
+// Next implements the Picker interface.
+func (p *leastConnPicker) Next(ctx context.Context, request interface{}) discovery.Instance {
+	size := len(p.instances)
+	if size == 0 {
+		return nil
+	}
+	// scan from a random offset so equally loaded instances don't all
+	// receive the burst in instance order
+	offset := fastrand.Intn(size)
+	excluding := lbcontext.HasExcluded(ctx)
+	var chosen discovery.Instance
+	var best float64
+	for i := 0; i < size; i++ {
+		ins := p.instances[(offset+i)%size]
+		if excluding && lbcontext.IsExcluded(ctx, ins) {
+			continue
+		}
+		if score := p.b.score(ins); chosen == nil || score < best {
+			chosen, best = ins, score
+		}
+	}
+	if chosen == nil {
+		// every instance is excluded, fail open to the least loaded one
+		for i := 0; i < size; i++ {
+			ins := p.instances[(offset+i)%size]
+			if score := p.b.score(ins); chosen == nil || score < best {
+				chosen, best = ins, score
+			}
+		}
+	}
+	p.b.tracker.Acquire(chosen.Address().String())
+	return chosen
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/discovery"
+	"github.com/cloudwego/kitex/pkg/loadbalance/lbcontext"
+)
+
+func TestLeastConnPicker(t *testing.T) {
+	balancer := NewLeastConnBalancer()
+	test.Assert(t, balancer.Name() == "least_conn")
+	b := balancer.(*leastConnBalancer)
+
+	insList := []discovery.Instance{
+		discovery.NewInstance("tcp", "addr1", 10, nil),
+		discovery.NewInstance("tcp", "addr2", 10, nil),
+	}
+	e := discovery.Result{Cacheable: true, CacheKey: "test", Instances: insList}
+
+	// addr1 holds requests in flight, picks must go to addr2
+	b.tracker.Acquire("addr1")
+	b.tracker.Acquire("addr1")
+	for i := 0; i < 10; i++ {
+		ins := balancer.GetPicker(e).Next(context.TODO(), nil)
+		test.Assert(t, ins.Address().String() == "addr2", ins.Address())
+		b.tracker.Release("addr2")
+	}
+
+	// without load the picks spread over both instances
+	b.tracker.Release("addr1")
+	b.tracker.Release("addr1")
+	seen := map[string]bool{}
+	for i := 0; i < 50; i++ {
+		ins := balancer.GetPicker(e).Next(context.TODO(), nil)
+		seen[ins.Address().String()] = true
+		b.tracker.Release(ins.Address().String())
+	}
+	test.Assert(t, len(seen) == 2, seen)
+
+	picker := balancer.GetPicker(discovery.Result{Instances: nil})
+	test.Assert(t, picker.Next(context.TODO(), nil) == nil)
+}
+
+func TestWeightedLeastConnPicker(t *testing.T) {
+	balancer := NewWeightedLeastConnBalancer()
+	test.Assert(t, balancer.Name() == "weighted_least_conn")
+	b := balancer.(*leastConnBalancer)
+
+	insList := []discovery.Instance{
+		discovery.NewInstance("tcp", "addr1", 30, nil),
+		discovery.NewInstance("tcp", "addr2", 10, nil),
+	}
+	e := discovery.Result{Cacheable: false, Instances: insList}
+
+	// run a steady in-flight workload; the heavier instance should hold
+	// roughly three times the connections of the lighter one
+	for i := 0; i < 100; i++ {
+		balancer.GetPicker(e).Next(context.TODO(), nil)
+	}
+	load1 := b.tracker.Inflight("addr1")
+	load2 := b.tracker.Inflight("addr2")
+	test.Assert(t, load1+load2 == 100)
+	test.Assert(t, load1 > 2*load2 && load1 < 4*load2, load1, load2)
+}
+
+func TestLeastConnPickerExclusion(t *testing.T) {
+	balancer := NewLeastConnBalancer()
+	insList := []discovery.Instance{
+		discovery.NewInstance("tcp", "addr1", 10, nil),
+		discovery.NewInstance("tcp", "addr2", 10, nil),
+	}
+	e := discovery.Result{Cacheable: false, Instances: insList}
+
+	ctx := lbcontext.WithExcluded(context.Background(), insList[:1])
+	for i := 0; i < 10; i++ {
+		ins := balancer.GetPicker(e).Next(ctx, nil)
+		test.Assert(t, ins.Address().String() == "addr2", ins.Address())
+	}
+
+	// all excluded: fail open instead of returning nil
+	ctx = lbcontext.WithExcluded(context.Background(), insList)
+	test.Assert(t, balancer.GetPicker(e).Next(ctx, nil) != nil)
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"github.com/cloudwego/kitex/pkg/discovery"
+)
+
+const (
+	// DefaultZoneKey is the instance tag carrying the zone/idc of an instance.
+	DefaultZoneKey = "zone"
+	// DefaultSpillRatio is the default fraction of the full instance set that
+	// must be in the local zone for traffic to stay local.
+	DefaultSpillRatio = 0.25
+)
+
+// LocalityOption configures NewLocalityBalancer.
+type LocalityOption struct {
+	// LocalZone is the zone of this client. Empty disables zone filtering.
+	LocalZone string
+	// ZoneKey is the instance tag to read the zone from, DefaultZoneKey when empty.
+	ZoneKey string
+	// SpillRatio is the minimum fraction of instances that must be in the
+	// local zone for picks to stay local; when local capacity drops below it
+	// (instances removed or unhealthy), picks spill over to the full set.
+	// Non-positive means DefaultSpillRatio.
+	SpillRatio float64
+}
+
+// NewLocalityBalancer wraps a balancer with a zone-affinity tier: picks
+// prefer instances in the same zone/idc as the client (matched by instance
+// tag), and only fall back to the full instance set when the local zone no
+// longer has enough capacity, see LocalityOption.SpillRatio.
+func NewLocalityBalancer(base Loadbalancer, opt LocalityOption) Loadbalancer {
+	if opt.ZoneKey == "" {
+		opt.ZoneKey = DefaultZoneKey
+	}
+	if opt.SpillRatio <= 0 {
+		opt.SpillRatio = DefaultSpillRatio
+	}
+	return &localityBalancer{base: base, opt: opt}
+}
+
+type localityBalancer struct {
+	base Loadbalancer
+	opt  LocalityOption
+}
+
+// GetPicker implements the Loadbalancer interface.
+func (lb *localityBalancer) GetPicker(e discovery.Result) Picker {
+	if local, ok := lb.localResult(e); ok {
+		return lb.base.GetPicker(local)
+	}
+	return lb.base.GetPicker(e)
+}
+
+// Rebalance implements the Rebalancer interface.
+func (lb *localityBalancer) Rebalance(change discovery.Change) {
+	rb, ok := lb.base.(Rebalancer)
+	if !ok {
+		return
+	}
+	rb.Rebalance(change)
+	if local, ok := lb.localResult(change.Result); ok {
+		rb.Rebalance(discovery.Change{Result: local})
+	}
+}
+
+// Delete implements the Rebalancer interface.
+func (lb *localityBalancer) Delete(change discovery.Change) {
+	rb, ok := lb.base.(Rebalancer)
+	if !ok {
+		return
+	}
+	rb.Delete(change)
+	if local, ok := lb.localResult(change.Result); ok {
+		rb.Delete(discovery.Change{Result: local})
+	}
+}
+
+func (lb *localityBalancer) Name() string {
+	return lb.base.Name() + "_locality"
+}
+
+// localResult returns the local-zone subset of e, or ok=false when zone
+// filtering is off or local capacity dropped below the spill threshold.
+func (lb *localityBalancer) localResult(e discovery.Result) (discovery.Result, bool) {
+	if lb.opt.LocalZone == "" || len(e.Instances) == 0 {
+		return e, false
+	}
+	local := make([]discovery.Instance, 0, len(e.Instances))
+	for _, ins := range e.Instances {
+		if zone, ok := ins.Tag(lb.opt.ZoneKey); ok && zone == lb.opt.LocalZone {
+			local = append(local, ins)
+		}
+	}
+	if float64(len(local))/float64(len(e.Instances)) < lb.opt.SpillRatio {
+		return e, false
+	}
+	return discovery.Result{
+		Cacheable: e.Cacheable,
+		// derive a new cache key, the base balancer must not mix the local
+		// subset up with the full set
+		CacheKey:  e.CacheKey + "#zone=" + lb.opt.LocalZone,
+		Instances: local,
+	}, true
+}
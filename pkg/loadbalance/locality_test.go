@@ -0,0 +1,94 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/discovery"
+)
+
+func TestLocalityBalancer(t *testing.T) {
+	insList := []discovery.Instance{
+		discovery.NewInstance("tcp", "local1", 10, map[string]string{"zone": "z1"}),
+		discovery.NewInstance("tcp", "local2", 10, map[string]string{"zone": "z1"}),
+		discovery.NewInstance("tcp", "remote1", 10, map[string]string{"zone": "z2"}),
+		discovery.NewInstance("tcp", "remote2", 10, map[string]string{"zone": "z2"}),
+	}
+	e := discovery.Result{Cacheable: true, CacheKey: "test", Instances: insList}
+
+	lb := NewLocalityBalancer(NewWeightedRoundRobinBalancer(), LocalityOption{LocalZone: "z1"})
+	test.Assert(t, lb.Name() == "weight_round_robin_locality")
+
+	// enough local capacity: all picks stay in z1
+	picker := lb.GetPicker(e)
+	for i := 0; i < 20; i++ {
+		ins := picker.Next(context.TODO(), nil)
+		zone, _ := ins.Tag("zone")
+		test.Assert(t, zone == "z1", ins.Address())
+	}
+
+	// local capacity below the spill ratio: picks use the full set
+	spilled := discovery.Result{
+		Cacheable: true,
+		CacheKey:  "test2",
+		Instances: []discovery.Instance{
+			insList[0], insList[2], insList[3],
+			discovery.NewInstance("tcp", "remote3", 10, map[string]string{"zone": "z2"}),
+			discovery.NewInstance("tcp", "remote4", 10, map[string]string{"zone": "z2"}),
+		},
+	}
+	lb = NewLocalityBalancer(NewWeightedRoundRobinBalancer(), LocalityOption{LocalZone: "z1", SpillRatio: 0.5})
+	picker = lb.GetPicker(spilled)
+	zones := make(map[string]int)
+	for i := 0; i < 20; i++ {
+		zone, _ := picker.Next(context.TODO(), nil).Tag("zone")
+		zones[zone]++
+	}
+	test.Assert(t, zones["z2"] > 0, zones)
+
+	// no local zone configured: pass-through
+	lb = NewLocalityBalancer(NewWeightedRoundRobinBalancer(), LocalityOption{})
+	picker = lb.GetPicker(e)
+	test.Assert(t, picker.Next(context.TODO(), nil) != nil)
+}
+
+func TestLocalityBalancerRebalance(t *testing.T) {
+	insList := []discovery.Instance{
+		discovery.NewInstance("tcp", "local1", 10, map[string]string{"zone": "z1"}),
+		discovery.NewInstance("tcp", "remote1", 10, map[string]string{"zone": "z2"}),
+	}
+	e := discovery.Result{Cacheable: true, CacheKey: "test", Instances: insList}
+	lb := NewLocalityBalancer(NewWeightedRoundRobinBalancer(), LocalityOption{LocalZone: "z1"})
+	picker := lb.GetPicker(e)
+	test.Assert(t, picker.Next(context.TODO(), nil).Address().String() == "local1")
+
+	// the local instance went away, rebalance must refresh the local subset
+	changed := discovery.Result{
+		Cacheable: true,
+		CacheKey:  "test",
+		Instances: []discovery.Instance{
+			discovery.NewInstance("tcp", "local2", 10, map[string]string{"zone": "z1"}),
+			insList[1],
+		},
+	}
+	lb.(Rebalancer).Rebalance(discovery.Change{Result: changed})
+	picker = lb.GetPicker(changed)
+	test.Assert(t, picker.Next(context.TODO(), nil).Address().String() == "local2")
+}
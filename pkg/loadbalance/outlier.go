@@ -0,0 +1,197 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudwego/kitex/pkg/discovery"
+	"github.com/cloudwego/kitex/pkg/endpoint"
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+const (
+	// DefaultConsecutiveFailures is how many connect failures in a row eject an instance.
+	DefaultConsecutiveFailures = 5
+	// DefaultBaseEjectionTime is the first ejection duration; it doubles per
+	// repeated ejection up to DefaultMaxEjectionTime.
+	DefaultBaseEjectionTime = 30 * time.Second
+	// DefaultMaxEjectionTime caps the ejection backoff.
+	DefaultMaxEjectionTime = 5 * time.Minute
+	// DefaultMaxEjectionRatio caps how much of the instance set may be ejected at once.
+	DefaultMaxEjectionRatio = 0.5
+)
+
+// OutlierOption configures passive outlier detection.
+type OutlierOption struct {
+	// ConsecutiveFailures ejects an instance after that many connect failures
+	// in a row, DefaultConsecutiveFailures when 0.
+	ConsecutiveFailures int
+	// BaseEjectionTime is the first ejection duration, doubled on every
+	// repeated ejection, DefaultBaseEjectionTime when 0.
+	BaseEjectionTime time.Duration
+	// MaxEjectionTime caps the backoff, DefaultMaxEjectionTime when 0.
+	MaxEjectionTime time.Duration
+	// MaxEjectionRatio caps the fraction of instances ejected at once so the
+	// detector cannot black-hole the service, DefaultMaxEjectionRatio when 0.
+	MaxEjectionRatio float64
+}
+
+// NewOutlierBalancer wraps a balancer with passive outlier detection:
+// instances whose requests keep failing to connect are ejected from the
+// candidate set for a backoff period, independent of the registry's slower
+// health propagation. Failures are observed by the middleware returned from
+// FeedbackMW, which must be installed on the client for the detection to work.
+func NewOutlierBalancer(base Loadbalancer, opt OutlierOption) Loadbalancer {
+	if opt.ConsecutiveFailures <= 0 {
+		opt.ConsecutiveFailures = DefaultConsecutiveFailures
+	}
+	if opt.BaseEjectionTime <= 0 {
+		opt.BaseEjectionTime = DefaultBaseEjectionTime
+	}
+	if opt.MaxEjectionTime <= 0 {
+		opt.MaxEjectionTime = DefaultMaxEjectionTime
+	}
+	if opt.MaxEjectionRatio <= 0 {
+		opt.MaxEjectionRatio = DefaultMaxEjectionRatio
+	}
+	return &outlierBalancer{base: base, opt: opt}
+}
+
+type outlierBalancer struct {
+	base  Loadbalancer
+	opt   OutlierOption
+	stats sync.Map // addr -> *outlierStat
+}
+
+type outlierStat struct {
+	failures     int64 // consecutive connect failures
+	ejectedUntil int64 // unixnano, 0 when not ejected
+	ejections    int64 // repeated ejection count driving the backoff
+}
+
+// GetPicker implements the Loadbalancer interface.
+func (b *outlierBalancer) GetPicker(e discovery.Result) Picker {
+	healthy := b.healthyInstances(e.Instances)
+	if len(healthy) == len(e.Instances) {
+		return b.base.GetPicker(e)
+	}
+	// ejections change over time, keep the pruned set out of picker caches
+	return b.base.GetPicker(discovery.Result{Instances: healthy})
+}
+
+// healthyInstances drops ejected instances, capped by MaxEjectionRatio and
+// failing open to the full set when nothing would be left.
+func (b *outlierBalancer) healthyInstances(instances []discovery.Instance) []discovery.Instance {
+	maxEjected := int(b.opt.MaxEjectionRatio * float64(len(instances)))
+	now := time.Now().UnixNano()
+	healthy := make([]discovery.Instance, 0, len(instances))
+	ejected := 0
+	for _, ins := range instances {
+		if ejected < maxEjected && b.isEjected(ins.Address().String(), now) {
+			ejected++
+			continue
+		}
+		healthy = append(healthy, ins)
+	}
+	if len(healthy) == 0 {
+		return instances
+	}
+	return healthy
+}
+
+func (b *outlierBalancer) isEjected(addr string, now int64) bool {
+	s, ok := b.stats.Load(addr)
+	if !ok {
+		return false
+	}
+	until := atomic.LoadInt64(&s.(*outlierStat).ejectedUntil)
+	return until != 0 && until > now
+}
+
+// Rebalance implements the Rebalancer interface.
+func (b *outlierBalancer) Rebalance(change discovery.Change) {
+	for _, ins := range change.Removed {
+		b.stats.Delete(ins.Address().String())
+	}
+	if rb, ok := b.base.(Rebalancer); ok {
+		rb.Rebalance(change)
+	}
+}
+
+// Delete implements the Rebalancer interface.
+func (b *outlierBalancer) Delete(change discovery.Change) {
+	if rb, ok := b.base.(Rebalancer); ok {
+		rb.Delete(change)
+	}
+}
+
+func (b *outlierBalancer) Name() string {
+	return b.base.Name() + "_outlier"
+}
+
+// FeedbackMW returns the middleware that reports request outcomes back into
+// the detector; connect failures count towards ejection, any success resets
+// the instance.
+func (b *outlierBalancer) FeedbackMW() endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request, response interface{}) (err error) {
+			err = next(ctx, request, response)
+			if ri := rpcinfo.GetRPCInfo(ctx); ri != nil {
+				if addr := ri.To().Address(); addr != nil {
+					b.report(addr.String(), err)
+				}
+			}
+			return err
+		}
+	}
+}
+
+// report records one request outcome of addr.
+func (b *outlierBalancer) report(addr string, err error) {
+	s := b.stat(addr)
+	if err == nil || !errors.Is(err, kerrors.ErrGetConnection) {
+		// only connect failures are the instance's fault for sure; any
+		// response, even an error one, proves the instance reachable
+		atomic.StoreInt64(&s.failures, 0)
+		atomic.StoreInt64(&s.ejections, 0)
+		return
+	}
+	if atomic.AddInt64(&s.failures, 1) < int64(b.opt.ConsecutiveFailures) {
+		return
+	}
+	atomic.StoreInt64(&s.failures, 0)
+	ejections := atomic.AddInt64(&s.ejections, 1)
+	backoff := b.opt.BaseEjectionTime << (ejections - 1)
+	if backoff <= 0 || backoff > b.opt.MaxEjectionTime {
+		backoff = b.opt.MaxEjectionTime
+	}
+	atomic.StoreInt64(&s.ejectedUntil, time.Now().Add(backoff).UnixNano())
+}
+
+func (b *outlierBalancer) stat(addr string) *outlierStat {
+	if s, ok := b.stats.Load(addr); ok {
+		return s.(*outlierStat)
+	}
+	s, _ := b.stats.LoadOrStore(addr, &outlierStat{})
+	return s.(*outlierStat)
+}
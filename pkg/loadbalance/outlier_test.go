@@ -0,0 +1,144 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/discovery"
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+	"github.com/cloudwego/kitex/pkg/utils"
+)
+
+func outlierTestResult() discovery.Result {
+	return discovery.Result{
+		Cacheable: false,
+		Instances: []discovery.Instance{
+			discovery.NewInstance("tcp", "addr1", 10, nil),
+			discovery.NewInstance("tcp", "addr2", 10, nil),
+			discovery.NewInstance("tcp", "addr3", 10, nil),
+		},
+	}
+}
+
+func outlierCtx(addr string) context.Context {
+	to := rpcinfo.NewEndpointInfo("service", "method", utils.NewNetAddr("tcp", addr), nil)
+	ri := rpcinfo.NewRPCInfo(nil, to, nil, nil, nil)
+	return rpcinfo.NewCtxWithRPCInfo(context.Background(), ri)
+}
+
+func TestOutlierBalancerEjection(t *testing.T) {
+	lb := NewOutlierBalancer(NewWeightedRoundRobinBalancer(), OutlierOption{
+		ConsecutiveFailures: 3,
+		BaseEjectionTime:    50 * time.Millisecond,
+	}).(*outlierBalancer)
+	test.Assert(t, lb.Name() == "weight_round_robin_outlier")
+
+	mw := lb.FeedbackMW()
+	failing := mw(func(ctx context.Context, req, resp interface{}) error {
+		return kerrors.ErrGetConnection.WithCause(errors.New("connection refused"))
+	})
+	for i := 0; i < 3; i++ {
+		failing(outlierCtx("addr1"), nil, nil)
+	}
+
+	e := outlierTestResult()
+	picker := lb.GetPicker(e)
+	for i := 0; i < 20; i++ {
+		ins := picker.Next(context.Background(), nil)
+		test.Assert(t, ins.Address().String() != "addr1", ins.Address())
+	}
+
+	// the ejection expires and the instance comes back
+	time.Sleep(60 * time.Millisecond)
+	seen := map[string]bool{}
+	picker = lb.GetPicker(e)
+	for i := 0; i < 20; i++ {
+		seen[picker.Next(context.Background(), nil).Address().String()] = true
+	}
+	test.Assert(t, seen["addr1"], seen)
+}
+
+func TestOutlierBalancerSuccessResets(t *testing.T) {
+	lb := NewOutlierBalancer(NewWeightedRoundRobinBalancer(), OutlierOption{
+		ConsecutiveFailures: 3,
+	}).(*outlierBalancer)
+	mw := lb.FeedbackMW()
+	failing := mw(func(ctx context.Context, req, resp interface{}) error {
+		return kerrors.ErrGetConnection.WithCause(errors.New("connection refused"))
+	})
+	ok := mw(func(ctx context.Context, req, resp interface{}) error {
+		return nil
+	})
+
+	// a success in between keeps the instance in rotation
+	failing(outlierCtx("addr1"), nil, nil)
+	failing(outlierCtx("addr1"), nil, nil)
+	ok(outlierCtx("addr1"), nil, nil)
+	failing(outlierCtx("addr1"), nil, nil)
+	test.Assert(t, !lb.isEjected("addr1", time.Now().UnixNano()))
+
+	// response errors are not the instance's fault and don't count either
+	rpcErr := mw(func(ctx context.Context, req, resp interface{}) error {
+		return kerrors.ErrRPCTimeout
+	})
+	for i := 0; i < 5; i++ {
+		rpcErr(outlierCtx("addr1"), nil, nil)
+	}
+	test.Assert(t, !lb.isEjected("addr1", time.Now().UnixNano()))
+}
+
+func TestOutlierBalancerMaxEjectionRatio(t *testing.T) {
+	lb := NewOutlierBalancer(NewWeightedRoundRobinBalancer(), OutlierOption{
+		ConsecutiveFailures: 1,
+		MaxEjectionRatio:    0.4, // at most 1 of 3 instances
+	}).(*outlierBalancer)
+	mw := lb.FeedbackMW()
+	failing := mw(func(ctx context.Context, req, resp interface{}) error {
+		return kerrors.ErrGetConnection.WithCause(errors.New("connection refused"))
+	})
+	failing(outlierCtx("addr1"), nil, nil)
+	failing(outlierCtx("addr2"), nil, nil)
+
+	e := outlierTestResult()
+	healthy := lb.healthyInstances(e.Instances)
+	test.Assert(t, len(healthy) == 2, healthy)
+}
+
+func TestOutlierBalancerBackoffDoubles(t *testing.T) {
+	lb := NewOutlierBalancer(NewWeightedRoundRobinBalancer(), OutlierOption{
+		ConsecutiveFailures: 1,
+		BaseEjectionTime:    time.Minute,
+		MaxEjectionTime:     10 * time.Minute,
+	}).(*outlierBalancer)
+	mw := lb.FeedbackMW()
+	failing := mw(func(ctx context.Context, req, resp interface{}) error {
+		return kerrors.ErrGetConnection.WithCause(errors.New("connection refused"))
+	})
+
+	failing(outlierCtx("addr1"), nil, nil)
+	first := lb.stat("addr1").ejectedUntil
+	failing(outlierCtx("addr1"), nil, nil)
+	second := lb.stat("addr1").ejectedUntil
+	// second ejection backs off twice as long as the first
+	test.Assert(t, second-first > int64(50*time.Second), second-first)
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bytedance/gopkg/lang/fastrand"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/cloudwego/kitex/pkg/discovery"
+	"github.com/cloudwego/kitex/pkg/endpoint"
+	"github.com/cloudwego/kitex/pkg/loadbalance/inflight"
+)
+
+// NewP2CBalancer creates a power-of-two-choices balancer with peak-EWMA
+// latency feedback: each pick compares two random instances and chooses the
+// one with the lower latency×in-flight score, which adapts quickly to
+// heterogeneous or degraded backends. Latency is fed back by the middleware
+// returned from FeedbackMW, which must be installed on the client via
+// client.WithMiddleware; without it the balancer degrades to random picks
+// scored by in-flight counts only.
+func NewP2CBalancer() Loadbalancer {
+	return &p2cBalancer{tracker: inflight.NewTracker()}
+}
+
+type p2cBalancer struct {
+	pickerCache sync.Map
+	sfg         singleflight.Group
+	tracker     *inflight.Tracker
+}
+
+// GetPicker implements the Loadbalancer interface.
+func (b *p2cBalancer) GetPicker(e discovery.Result) Picker {
+	if !e.Cacheable {
+		return &p2cPicker{b: b, instances: e.Instances}
+	}
+	picker, ok := b.pickerCache.Load(e.CacheKey)
+	if !ok {
+		picker, _, _ = b.sfg.Do(e.CacheKey, func() (interface{}, error) {
+			p := &p2cPicker{b: b, instances: e.Instances}
+			b.pickerCache.Store(e.CacheKey, p)
+			return p, nil
+		})
+	}
+	return picker.(Picker)
+}
+
+// Rebalance implements the Rebalancer interface.
+func (b *p2cBalancer) Rebalance(change discovery.Change) {
+	if !change.Result.Cacheable {
+		return
+	}
+	b.pickerCache.Store(change.Result.CacheKey, &p2cPicker{b: b, instances: change.Result.Instances})
+	for i := range change.Removed {
+		b.tracker.Remove(change.Removed[i].Address().String())
+	}
+}
+
+// Delete implements the Rebalancer interface.
+func (b *p2cBalancer) Delete(change discovery.Change) {
+	if !change.Result.Cacheable {
+		return
+	}
+	b.pickerCache.Delete(change.Result.CacheKey)
+}
+
+// FeedbackMW returns the middleware that feeds the request cost back into
+// the peak-EWMA and releases the in-flight slot taken by the pick.
+func (b *p2cBalancer) FeedbackMW() endpoint.Middleware {
+	return b.tracker.FeedbackMW()
+}
+
+func (b *p2cBalancer) Name() string {
+	return "p2c_peak_ewma"
+}
+
+// score is the expected waiting cost of one more request on addr:
+// the smoothed latency scaled by the queue it would join.
+func (b *p2cBalancer) score(addr string) uint64 {
+	latency := uint64(b.tracker.Latency(addr))
+	if latency == 0 {
+		// never observed, treat as most attractive
+		return 0
+	}
+	return latency * uint64(b.tracker.Inflight(addr)+1)
+}
+
+type p2cPicker struct {
+	b         *p2cBalancer
+	instances []discovery.Instance
+}
+
+// Next implements the Picker interface.
+func (p *p2cPicker) Next(ctx context.Context, request interface{}) discovery.Instance {
+	size := len(p.instances)
+	if size == 0 {
+		return nil
+	}
+	if size == 1 {
+		return p.pick(p.instances[0])
+	}
+	ins := nextExcluding(ctx, maxExclusionAttempts, p.choose)
+	if ins == nil {
+		return nil
+	}
+	return p.pick(ins)
+}
+
+func (p *p2cPicker) choose() discovery.Instance {
+	size := len(p.instances)
+	i := fastrand.Intn(size)
+	j := fastrand.Intn(size - 1)
+	if j >= i {
+		j++
+	}
+	a, c := p.instances[i], p.instances[j]
+	if p.b.score(a.Address().String()) > p.b.score(c.Address().String()) {
+		a = c
+	}
+	return a
+}
+
+func (p *p2cPicker) pick(ins discovery.Instance) discovery.Instance {
+	p.b.tracker.Acquire(ins.Address().String())
+	return ins
+}
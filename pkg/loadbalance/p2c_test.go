@@ -0,0 +1,103 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/discovery"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+func TestP2CPicker(t *testing.T) {
+	balancer := NewP2CBalancer()
+	test.Assert(t, balancer.Name() == "p2c_peak_ewma")
+	b := balancer.(*p2cBalancer)
+
+	insList := []discovery.Instance{
+		discovery.NewInstance("tcp", "addr1", 10, nil),
+		discovery.NewInstance("tcp", "addr2", 10, nil),
+	}
+	e := discovery.Result{Cacheable: true, CacheKey: "test", Instances: insList}
+
+	// make addr1 slow and addr2 fast, picks should strongly prefer addr2
+	for i := 0; i < 10; i++ {
+		b.tracker.Finish("addr1", 100*time.Millisecond)
+		b.tracker.Finish("addr2", time.Millisecond)
+	}
+	seen := make(map[string]int)
+	for i := 0; i < 100; i++ {
+		ins := balancer.GetPicker(e).Next(context.TODO(), nil)
+		seen[ins.Address().String()]++
+		b.tracker.Release(ins.Address().String()) // release the slot
+	}
+	test.Assert(t, seen["addr2"] > seen["addr1"], seen)
+
+	// empty and single-instance results
+	picker := balancer.GetPicker(discovery.Result{Instances: nil})
+	test.Assert(t, picker.Next(context.TODO(), nil) == nil)
+	picker = balancer.GetPicker(discovery.Result{Instances: insList[:1]})
+	test.Assert(t, picker.Next(context.TODO(), nil).Address().String() == "addr1")
+}
+
+func TestP2CPickerInflight(t *testing.T) {
+	balancer := NewP2CBalancer()
+	b := balancer.(*p2cBalancer)
+	insList := []discovery.Instance{
+		discovery.NewInstance("tcp", "addr1", 10, nil),
+		discovery.NewInstance("tcp", "addr2", 10, nil),
+	}
+	e := discovery.Result{Cacheable: false, Instances: insList}
+
+	// same latency but addr1 keeps its requests in flight:
+	// the queue factor must shift picks to addr2
+	b.tracker.Finish("addr1", time.Millisecond)
+	b.tracker.Finish("addr2", time.Millisecond)
+	seen := make(map[string]int)
+	for i := 0; i < 100; i++ {
+		ins := balancer.GetPicker(e).Next(context.TODO(), nil)
+		seen[ins.Address().String()]++
+		if ins.Address().String() == "addr2" {
+			b.tracker.Finish("addr2", time.Millisecond)
+		}
+	}
+	test.Assert(t, seen["addr2"] > seen["addr1"], seen)
+}
+
+func TestP2CFeedbackMW(t *testing.T) {
+	balancer := NewP2CBalancer()
+	b := balancer.(*p2cBalancer)
+	ins := discovery.NewInstance("tcp", "addr1", 10, nil)
+	e := discovery.Result{Cacheable: false, Instances: []discovery.Instance{ins}}
+
+	got := balancer.GetPicker(e).Next(context.TODO(), nil)
+	test.Assert(t, got != nil)
+	ep := b.FeedbackMW()(func(ctx context.Context, req, resp interface{}) error {
+		time.Sleep(time.Millisecond)
+		return nil
+	})
+	to := rpcinfo.NewEndpointInfo("callee", "method", ins.Address(), nil)
+	ri := rpcinfo.NewRPCInfo(nil, to, nil, nil, nil)
+	ctx := rpcinfo.NewCtxWithRPCInfo(context.Background(), ri)
+	test.Assert(t, ep(ctx, nil, nil) == nil)
+
+	test.Assert(t, b.tracker.Inflight("addr1") == 0)
+	test.Assert(t, b.tracker.Latency("addr1") > 0)
+}
@@ -0,0 +1,222 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/bytedance/gopkg/cloud/metainfo"
+	"github.com/bytedance/gopkg/lang/fastrand"
+
+	"github.com/cloudwego/kitex/pkg/discovery"
+)
+
+// DefaultUIDKey is the metainfo key rules read the uid from when matching ranges.
+const DefaultUIDKey = "uid"
+
+// Rule routes a class of requests to a tagged instance group. A request
+// matches when every set condition holds; zero-value conditions match all.
+// Rules are evaluated in order and the first match wins; requests matching
+// no rule fall back to the full instance set.
+type Rule struct {
+	// Name identifies the rule; it is appended to the cache key so the base
+	// balancer caches each group separately.
+	Name string
+
+	// MetaKey/MetaValue match when the request carries that metainfo pair,
+	// eg: env=canary.
+	MetaKey   string
+	MetaValue string
+
+	// UIDMin/UIDMax match when the uid carried under UIDKey (DefaultUIDKey
+	// when empty) falls in [UIDMin, UIDMax). They are ignored when equal.
+	UIDKey string
+	UIDMin uint64
+	UIDMax uint64
+
+	// Percent routes only that percentage of the matching traffic, 0 or 100
+	// routes all of it.
+	Percent int
+
+	// GroupKey/GroupValue select the destination group: instances tagged
+	// with that pair.
+	GroupKey   string
+	GroupValue string
+}
+
+func (r Rule) match(ctx context.Context) bool {
+	if r.MetaKey != "" {
+		v, ok := metainfo.GetValue(ctx, r.MetaKey)
+		if !ok {
+			v, ok = metainfo.GetPersistentValue(ctx, r.MetaKey)
+		}
+		if !ok || v != r.MetaValue {
+			return false
+		}
+	}
+	if r.UIDMax > r.UIDMin {
+		key := r.UIDKey
+		if key == "" {
+			key = DefaultUIDKey
+		}
+		v, ok := metainfo.GetValue(ctx, key)
+		if !ok {
+			v, ok = metainfo.GetPersistentValue(ctx, key)
+		}
+		if !ok {
+			return false
+		}
+		uid, err := strconv.ParseUint(v, 10, 64)
+		if err != nil || uid < r.UIDMin || uid >= r.UIDMax {
+			return false
+		}
+	}
+	if r.Percent > 0 && r.Percent < 100 {
+		if fastrand.Intn(100) >= r.Percent {
+			return false
+		}
+	}
+	return true
+}
+
+// RuleSource provides the current routing rules; implementations may back it
+// with a config center so rules change at runtime without rebuilding clients.
+type RuleSource interface {
+	Rules() []Rule
+}
+
+// StaticRules is a RuleSource holding a fixed rule list, swappable at runtime
+// through Update.
+type StaticRules struct {
+	rules atomic.Value // []Rule
+}
+
+// NewStaticRules creates a StaticRules with the given rules.
+func NewStaticRules(rules []Rule) *StaticRules {
+	s := &StaticRules{}
+	s.rules.Store(rules)
+	return s
+}
+
+// Rules implements the RuleSource interface.
+func (s *StaticRules) Rules() []Rule {
+	rules, _ := s.rules.Load().([]Rule)
+	return rules
+}
+
+// Update replaces the rule list.
+func (s *StaticRules) Update(rules []Rule) {
+	s.rules.Store(rules)
+}
+
+// NewRuleBasedRouter wraps a balancer with request-class-based traffic
+// splitting: rules from src match on request metainfo (exact pairs, uid
+// ranges, percentages) and route matching requests to tagged instance groups,
+// the base balancer then picks within the selected group. Rules selecting an
+// empty group are skipped (fail open).
+func NewRuleBasedRouter(base Loadbalancer, src RuleSource) Loadbalancer {
+	return &routerBalancer{base: base, src: src}
+}
+
+type routerBalancer struct {
+	base Loadbalancer
+	src  RuleSource
+}
+
+type route struct {
+	rule   Rule
+	picker Picker
+}
+
+type routerPicker struct {
+	routes   []route
+	fallback Picker
+}
+
+// GetPicker implements the Loadbalancer interface.
+func (rb *routerBalancer) GetPicker(e discovery.Result) Picker {
+	rules := rb.src.Rules()
+	if len(rules) == 0 {
+		return rb.base.GetPicker(e)
+	}
+	routes := make([]route, 0, len(rules))
+	for _, rule := range rules {
+		group := rb.groupResult(e, rule)
+		if len(group.Instances) == 0 {
+			continue
+		}
+		routes = append(routes, route{rule: rule, picker: rb.base.GetPicker(group)})
+	}
+	if len(routes) == 0 {
+		return rb.base.GetPicker(e)
+	}
+	return &routerPicker{routes: routes, fallback: rb.base.GetPicker(e)}
+}
+
+func (rb *routerBalancer) groupResult(e discovery.Result, rule Rule) discovery.Result {
+	group := make([]discovery.Instance, 0, len(e.Instances))
+	for _, ins := range e.Instances {
+		if v, ok := ins.Tag(rule.GroupKey); ok && v == rule.GroupValue {
+			group = append(group, ins)
+		}
+	}
+	return discovery.Result{
+		Cacheable: e.Cacheable,
+		CacheKey:  e.CacheKey + "#rule=" + rule.Name,
+		Instances: group,
+	}
+}
+
+// Next implements the Picker interface.
+func (rp *routerPicker) Next(ctx context.Context, request interface{}) discovery.Instance {
+	for i := range rp.routes {
+		if rp.routes[i].rule.match(ctx) {
+			return rp.routes[i].picker.Next(ctx, request)
+		}
+	}
+	return rp.fallback.Next(ctx, request)
+}
+
+// Rebalance implements the Rebalancer interface.
+func (rb *routerBalancer) Rebalance(change discovery.Change) {
+	r, ok := rb.base.(Rebalancer)
+	if !ok {
+		return
+	}
+	r.Rebalance(change)
+	for _, rule := range rb.src.Rules() {
+		r.Rebalance(discovery.Change{Result: rb.groupResult(change.Result, rule)})
+	}
+}
+
+// Delete implements the Rebalancer interface.
+func (rb *routerBalancer) Delete(change discovery.Change) {
+	r, ok := rb.base.(Rebalancer)
+	if !ok {
+		return
+	}
+	r.Delete(change)
+	for _, rule := range rb.src.Rules() {
+		r.Delete(discovery.Change{Result: rb.groupResult(change.Result, rule)})
+	}
+}
+
+func (rb *routerBalancer) Name() string {
+	return rb.base.Name() + "_router"
+}
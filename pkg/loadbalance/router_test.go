@@ -0,0 +1,143 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bytedance/gopkg/cloud/metainfo"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/discovery"
+)
+
+func routerTestResult() discovery.Result {
+	return discovery.Result{
+		Cacheable: true,
+		CacheKey:  "test",
+		Instances: []discovery.Instance{
+			discovery.NewInstance("tcp", "prod1", 10, map[string]string{"env": "prod"}),
+			discovery.NewInstance("tcp", "prod2", 10, map[string]string{"env": "prod"}),
+			discovery.NewInstance("tcp", "canary1", 10, map[string]string{"env": "canary"}),
+		},
+	}
+}
+
+func TestRuleBasedRouterMetaMatch(t *testing.T) {
+	rules := NewStaticRules([]Rule{{
+		Name:       "canary",
+		MetaKey:    "env",
+		MetaValue:  "canary",
+		GroupKey:   "env",
+		GroupValue: "canary",
+	}})
+	lb := NewRuleBasedRouter(NewWeightedRoundRobinBalancer(), rules)
+	test.Assert(t, lb.Name() == "weight_round_robin_router")
+	picker := lb.GetPicker(routerTestResult())
+
+	// matching requests go to the canary group
+	ctx := metainfo.WithValue(context.Background(), "env", "canary")
+	for i := 0; i < 10; i++ {
+		ins := picker.Next(ctx, nil)
+		test.Assert(t, ins.Address().String() == "canary1", ins.Address())
+	}
+	// others fall back to the full set
+	seen := map[string]bool{}
+	for i := 0; i < 10; i++ {
+		seen[picker.Next(context.Background(), nil).Address().String()] = true
+	}
+	test.Assert(t, len(seen) == 3, seen)
+}
+
+func TestRuleBasedRouterUIDRange(t *testing.T) {
+	rules := NewStaticRules([]Rule{{
+		Name:       "uid-canary",
+		UIDMin:     100,
+		UIDMax:     200,
+		GroupKey:   "env",
+		GroupValue: "canary",
+	}})
+	lb := NewRuleBasedRouter(NewWeightedRoundRobinBalancer(), rules)
+	picker := lb.GetPicker(routerTestResult())
+
+	ctx := metainfo.WithValue(context.Background(), DefaultUIDKey, "150")
+	test.Assert(t, picker.Next(ctx, nil).Address().String() == "canary1")
+
+	ctx = metainfo.WithValue(context.Background(), DefaultUIDKey, "99")
+	seen := map[string]bool{}
+	for i := 0; i < 10; i++ {
+		seen[picker.Next(ctx, nil).Address().String()] = true
+	}
+	test.Assert(t, len(seen) == 3, seen)
+}
+
+func TestRuleBasedRouterPercent(t *testing.T) {
+	rules := NewStaticRules([]Rule{{
+		Name:       "ten-percent",
+		Percent:    10,
+		GroupKey:   "env",
+		GroupValue: "canary",
+	}})
+	lb := NewRuleBasedRouter(NewWeightedRoundRobinBalancer(), rules)
+	picker := lb.GetPicker(routerTestResult())
+
+	// ~10% routed to canary plus ~1/3 of the remaining 90% via fallback ≈ 40%
+	canary := 0
+	total := 10000
+	for i := 0; i < total; i++ {
+		if picker.Next(context.Background(), nil).Address().String() == "canary1" {
+			canary++
+		}
+	}
+	test.Assert(t, canary > total*3/10 && canary < total/2, canary)
+}
+
+func TestRuleBasedRouterRuntimeUpdate(t *testing.T) {
+	rules := NewStaticRules(nil)
+	lb := NewRuleBasedRouter(NewWeightedRoundRobinBalancer(), rules)
+	e := routerTestResult()
+	ctx := metainfo.WithValue(context.Background(), "env", "canary")
+
+	// no rules: picks come from the full set
+	seen := map[string]bool{}
+	for i := 0; i < 10; i++ {
+		seen[lb.GetPicker(e).Next(ctx, nil).Address().String()] = true
+	}
+	test.Assert(t, len(seen) == 3, seen)
+
+	rules.Update([]Rule{{
+		Name:       "canary",
+		MetaKey:    "env",
+		MetaValue:  "canary",
+		GroupKey:   "env",
+		GroupValue: "canary",
+	}})
+	test.Assert(t, lb.GetPicker(e).Next(ctx, nil).Address().String() == "canary1")
+}
+
+func TestRuleBasedRouterEmptyGroupFailOpen(t *testing.T) {
+	rules := NewStaticRules([]Rule{{
+		Name:       "missing",
+		GroupKey:   "env",
+		GroupValue: "staging",
+	}})
+	lb := NewRuleBasedRouter(NewWeightedRoundRobinBalancer(), rules)
+	// rule matching everything but selecting an empty group must not black-hole
+	ins := lb.GetPicker(routerTestResult()).Next(context.Background(), nil)
+	test.Assert(t, ins != nil)
+}
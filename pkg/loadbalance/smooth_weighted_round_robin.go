@@ -0,0 +1,70 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bytedance/gopkg/lang/fastrand"
+
+	"github.com/cloudwego/kitex/pkg/discovery"
+)
+
+// SmoothWeightedRoundRobinPicker implements the smooth weighted round-robin
+// algorithm computing each pick on demand. Unlike WeightedRoundRobinPicker it
+// keeps no precalculated vnode sequence, so its memory stays O(instances) even
+// when totalWeight/gcd is huge, at the cost of holding a mutex per pick.
+type SmoothWeightedRoundRobinPicker struct {
+	nodes []*wrrNode
+	vsize int // picks of one full cycle, totalWeight/gcd
+
+	lock sync.Mutex
+}
+
+func newSmoothWeightedRoundRobinPicker(instances []discovery.Instance) Picker {
+	size := uint64(len(instances))
+	offset := fastrand.Uint64n(size)
+	nodes := make([]*wrrNode, size)
+	gcd := 0
+	totalWeight := 0
+	for idx := uint64(0); idx < size; idx++ {
+		ins := instances[(idx+offset)%size]
+		gcd = gcdInt(gcd, ins.Weight())
+		totalWeight += ins.Weight()
+		nodes[idx] = &wrrNode{Instance: ins}
+	}
+	return &SmoothWeightedRoundRobinPicker{
+		nodes: nodes,
+		vsize: totalWeight / gcd,
+	}
+}
+
+// Next implements the Picker interface.
+func (sp *SmoothWeightedRoundRobinPicker) Next(ctx context.Context, request interface{}) discovery.Instance {
+	return nextExcluding(ctx, sp.vsize, sp.pick)
+}
+
+func (sp *SmoothWeightedRoundRobinPicker) pick() discovery.Instance {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	node := nextWrrNode(sp.nodes)
+	if node == nil {
+		return nil
+	}
+	return node.Instance
+}
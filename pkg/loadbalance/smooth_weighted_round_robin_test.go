@@ -0,0 +1,63 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/discovery"
+)
+
+func TestSmoothWeightedRoundRobinPicker(t *testing.T) {
+	insList := []discovery.Instance{
+		discovery.NewInstance("tcp", "addr1", 50, nil),
+		discovery.NewInstance("tcp", "addr2", 30, nil),
+		discovery.NewInstance("tcp", "addr3", 20, nil),
+	}
+	e := discovery.Result{Cacheable: true, CacheKey: "test", Instances: insList}
+
+	lb := NewSmoothWeightedRoundRobinBalancer()
+	test.Assert(t, lb.Name() == "smooth_weight_round_robin")
+	picker := lb.GetPicker(e)
+
+	// one full cycle hits each instance exactly weight/gcd times
+	cycle := (50 + 30 + 20) / 10
+	counts := map[string]int{}
+	maxRun := 0
+	run := 0
+	last := ""
+	for i := 0; i < cycle; i++ {
+		addr := picker.Next(context.TODO(), nil).Address().String()
+		counts[addr]++
+		if addr == last {
+			run++
+		} else {
+			run = 1
+			last = addr
+		}
+		if run > maxRun {
+			maxRun = run
+		}
+	}
+	test.Assert(t, counts["addr1"] == 5, counts)
+	test.Assert(t, counts["addr2"] == 3, counts)
+	test.Assert(t, counts["addr3"] == 2, counts)
+	// smooth scheduling never bursts the full weight of a node consecutively
+	test.Assert(t, maxRun < 5, maxRun)
+}
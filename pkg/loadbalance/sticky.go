@@ -0,0 +1,160 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bytedance/gopkg/cloud/metainfo"
+
+	"github.com/cloudwego/kitex/pkg/discovery"
+	"github.com/cloudwego/kitex/pkg/loadbalance/lbcontext"
+)
+
+const (
+	// DefaultSessionKey is the metainfo key carrying the session id.
+	DefaultSessionKey = "session_id"
+	// DefaultSessionTTL is how long a session pin lives without being used.
+	DefaultSessionTTL = 5 * time.Minute
+
+	// sweep expired sessions every this many new pins
+	sessionSweepInterval = 1024
+)
+
+// StickySessionOption configures NewStickySessionBalancer.
+type StickySessionOption struct {
+	// SessionKey is the metainfo key holding the session id, read from the
+	// transient part first (callopt hint) and the persistent part second.
+	// DefaultSessionKey when empty.
+	SessionKey string
+	// TTL is how long a pin lives without being used, DefaultSessionTTL when
+	// not positive. Every sticky pick refreshes the pin.
+	TTL time.Duration
+}
+
+// NewStickySessionBalancer wraps a balancer with session affinity: requests
+// carrying the same session id in metainfo are pinned to the instance the
+// first request picked, for as long as that instance stays in the discovery
+// result. When the instance disappears or the pin expires, the next request
+// fails over to a fresh pick of the wrapped balancer and re-pins. Requests
+// without a session id are balanced as usual.
+func NewStickySessionBalancer(base Loadbalancer, opt StickySessionOption) Loadbalancer {
+	if opt.SessionKey == "" {
+		opt.SessionKey = DefaultSessionKey
+	}
+	if opt.TTL <= 0 {
+		opt.TTL = DefaultSessionTTL
+	}
+	return &stickyBalancer{base: base, opt: opt}
+}
+
+type stickyBalancer struct {
+	base Loadbalancer
+	opt  StickySessionOption
+
+	sessions sync.Map // map[sessionID]*sessionPin
+	pinCount int64
+}
+
+type sessionPin struct {
+	addr   string
+	expire int64 // unix nano
+}
+
+// GetPicker implements the Loadbalancer interface.
+func (sb *stickyBalancer) GetPicker(e discovery.Result) Picker {
+	return &stickyPicker{sb: sb, result: e, base: sb.base.GetPicker(e)}
+}
+
+// Rebalance implements the Rebalancer interface.
+func (sb *stickyBalancer) Rebalance(change discovery.Change) {
+	if rb, ok := sb.base.(Rebalancer); ok {
+		rb.Rebalance(change)
+	}
+}
+
+// Delete implements the Rebalancer interface.
+func (sb *stickyBalancer) Delete(change discovery.Change) {
+	if rb, ok := sb.base.(Rebalancer); ok {
+		rb.Delete(change)
+	}
+}
+
+func (sb *stickyBalancer) Name() string {
+	return sb.base.Name() + "_sticky"
+}
+
+func (sb *stickyBalancer) pin(sid, addr string) {
+	sb.sessions.Store(sid, &sessionPin{addr: addr, expire: time.Now().Add(sb.opt.TTL).UnixNano()})
+	if atomic.AddInt64(&sb.pinCount, 1)%sessionSweepInterval == 0 {
+		sb.sweep()
+	}
+}
+
+// sweep drops expired pins so idle sessions don't accumulate forever.
+func (sb *stickyBalancer) sweep() {
+	now := time.Now().UnixNano()
+	sb.sessions.Range(func(key, value interface{}) bool {
+		if value.(*sessionPin).expire < now {
+			sb.sessions.Delete(key)
+		}
+		return true
+	})
+}
+
+type stickyPicker struct {
+	sb     *stickyBalancer
+	result discovery.Result
+	base   Picker
+}
+
+// Next implements the Picker interface.
+func (sp *stickyPicker) Next(ctx context.Context, request interface{}) discovery.Instance {
+	sid, ok := metainfo.GetValue(ctx, sp.sb.opt.SessionKey)
+	if !ok {
+		sid, ok = metainfo.GetPersistentValue(ctx, sp.sb.opt.SessionKey)
+	}
+	if !ok || sid == "" {
+		return sp.base.Next(ctx, request)
+	}
+	if p, ok := sp.sb.sessions.Load(sid); ok {
+		pin := p.(*sessionPin)
+		if pin.expire >= time.Now().UnixNano() {
+			// the pinned instance must still be part of the discovery result
+			for _, ins := range sp.result.Instances {
+				if ins.Address().String() == pin.addr {
+					if lbcontext.IsExcluded(ctx, ins) {
+						// a retry already tried the pinned instance,
+						// fall through and re-pin a fresh pick
+						break
+					}
+					sp.sb.pin(sid, pin.addr) // refresh the TTL
+					return ins
+				}
+			}
+		}
+	}
+	// no pin, expired, or the instance is gone: fail over to a fresh pick
+	ins := sp.base.Next(ctx, request)
+	if ins != nil {
+		sp.sb.pin(sid, ins.Address().String())
+	}
+	return ins
+}
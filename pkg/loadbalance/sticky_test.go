@@ -0,0 +1,96 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bytedance/gopkg/cloud/metainfo"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/discovery"
+)
+
+func TestStickySessionBalancer(t *testing.T) {
+	e := discovery.Result{
+		Cacheable: true,
+		CacheKey:  "test",
+		Instances: genSubsetInstances(10),
+	}
+	lb := NewStickySessionBalancer(NewWeightedRoundRobinBalancer(), StickySessionOption{})
+	test.Assert(t, lb.Name() == "weight_round_robin_sticky")
+
+	ctx := metainfo.WithValue(context.Background(), DefaultSessionKey, "sess-1")
+	first := lb.GetPicker(e).Next(ctx, nil)
+	test.Assert(t, first != nil)
+	// the same session sticks to the same instance across picks
+	for i := 0; i < 20; i++ {
+		ins := lb.GetPicker(e).Next(ctx, nil)
+		test.Assert(t, ins.Address().String() == first.Address().String())
+	}
+
+	// another session may land elsewhere, requests without one rotate freely
+	ctx2 := metainfo.WithPersistentValue(context.Background(), DefaultSessionKey, "sess-2")
+	ins2 := lb.GetPicker(e).Next(ctx2, nil)
+	test.Assert(t, ins2 != nil)
+	for i := 0; i < 20; i++ {
+		ins := lb.GetPicker(e).Next(ctx2, nil)
+		test.Assert(t, ins.Address().String() == ins2.Address().String())
+	}
+	test.Assert(t, lb.GetPicker(e).Next(context.Background(), nil) != nil)
+}
+
+func TestStickySessionFailover(t *testing.T) {
+	instances := genSubsetInstances(3)
+	e := discovery.Result{Cacheable: false, Instances: instances}
+	lb := NewStickySessionBalancer(NewWeightedRoundRobinBalancer(), StickySessionOption{})
+
+	ctx := metainfo.WithValue(context.Background(), DefaultSessionKey, "sess-1")
+	first := lb.GetPicker(e).Next(ctx, nil)
+
+	// the pinned instance disappears: the session fails over and re-pins
+	var rest []discovery.Instance
+	for _, ins := range instances {
+		if ins.Address().String() != first.Address().String() {
+			rest = append(rest, ins)
+		}
+	}
+	shrunk := discovery.Result{Cacheable: false, Instances: rest}
+	second := lb.GetPicker(shrunk).Next(ctx, nil)
+	test.Assert(t, second.Address().String() != first.Address().String())
+	for i := 0; i < 10; i++ {
+		ins := lb.GetPicker(shrunk).Next(ctx, nil)
+		test.Assert(t, ins.Address().String() == second.Address().String())
+	}
+}
+
+func TestStickySessionTTL(t *testing.T) {
+	e := discovery.Result{Cacheable: false, Instances: genSubsetInstances(10)}
+	lb := NewStickySessionBalancer(NewWeightedRoundRobinBalancer(), StickySessionOption{TTL: time.Millisecond}).(*stickyBalancer)
+
+	ctx := metainfo.WithValue(context.Background(), DefaultSessionKey, "sess-1")
+	first := lb.GetPicker(e).Next(ctx, nil)
+	test.Assert(t, first != nil)
+	time.Sleep(5 * time.Millisecond)
+	// the pin expired; the next pick is fresh and re-pins
+	lb.GetPicker(e).Next(ctx, nil)
+	p, ok := lb.sessions.Load("sess-1")
+	test.Assert(t, ok)
+	test.Assert(t, p.(*sessionPin).expire >= time.Now().UnixNano())
+}
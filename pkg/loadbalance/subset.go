@@ -0,0 +1,135 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/bytedance/gopkg/util/xxhash3"
+
+	"github.com/cloudwego/kitex/pkg/discovery"
+)
+
+type subsetOptions struct {
+	size int
+	seed uint64
+}
+
+// SubsetOption configures NewSubsetBalancer.
+type SubsetOption func(*subsetOptions)
+
+// WithSubsetSize caps how many instances of the full discovery result this
+// client considers; non-positive disables subsetting.
+func WithSubsetSize(k int) SubsetOption {
+	return func(o *subsetOptions) {
+		o.size = k
+	}
+}
+
+// WithSubsetSeed overrides the per-client seed that decides which instances
+// land in the subset. By default the seed is derived from the hostname and
+// pid, so different clients spread over different subsets while one client
+// keeps a stable subset across discovery changes.
+func WithSubsetSeed(seed uint64) SubsetOption {
+	return func(o *subsetOptions) {
+		o.seed = seed
+	}
+}
+
+// NewSubsetBalancer wraps a balancer with deterministic subsetting: for very
+// large downstream clusters each client only considers a stable subset of K
+// instances (rendezvous hashing on the client seed), drastically reducing the
+// connection fan-out in big meshes. Instances joining or leaving only cause
+// minimal subset churn.
+func NewSubsetBalancer(base Loadbalancer, opts ...SubsetOption) Loadbalancer {
+	o := subsetOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.seed == 0 {
+		host, _ := os.Hostname()
+		o.seed = xxhash3.HashString(host + "/" + strconv.Itoa(os.Getpid()))
+	}
+	return &subsetBalancer{base: base, opt: o}
+}
+
+type subsetBalancer struct {
+	base Loadbalancer
+	opt  subsetOptions
+}
+
+// GetPicker implements the Loadbalancer interface.
+func (sb *subsetBalancer) GetPicker(e discovery.Result) Picker {
+	return sb.base.GetPicker(sb.subsetResult(e))
+}
+
+// Rebalance implements the Rebalancer interface.
+func (sb *subsetBalancer) Rebalance(change discovery.Change) {
+	if rb, ok := sb.base.(Rebalancer); ok {
+		change.Result = sb.subsetResult(change.Result)
+		rb.Rebalance(change)
+	}
+}
+
+// Delete implements the Rebalancer interface.
+func (sb *subsetBalancer) Delete(change discovery.Change) {
+	if rb, ok := sb.base.(Rebalancer); ok {
+		change.Result = sb.subsetResult(change.Result)
+		rb.Delete(change)
+	}
+}
+
+func (sb *subsetBalancer) Name() string {
+	return sb.base.Name() + "_subset"
+}
+
+// subsetResult picks the K instances with the highest rendezvous score for
+// this client; the score only depends on the seed and the instance address,
+// so the subset is stable and shifts minimally on membership changes.
+func (sb *subsetBalancer) subsetResult(e discovery.Result) discovery.Result {
+	k := sb.opt.size
+	if k <= 0 || len(e.Instances) <= k {
+		return e
+	}
+	type scored struct {
+		score uint64
+		ins   discovery.Instance
+	}
+	candidates := make([]scored, len(e.Instances))
+	seed := strconv.FormatUint(sb.opt.seed, 10) + "#"
+	for i, ins := range e.Instances {
+		candidates[i] = scored{
+			score: xxhash3.HashString(seed + ins.Address().String()),
+			ins:   ins,
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+	subset := make([]discovery.Instance, k)
+	for i := 0; i < k; i++ {
+		subset[i] = candidates[i].ins
+	}
+	return discovery.Result{
+		Cacheable: e.Cacheable,
+		// the base balancer must not mix the subset up with the full set
+		CacheKey:  e.CacheKey + "#subset=" + strconv.Itoa(k),
+		Instances: subset,
+	}
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/discovery"
+)
+
+func genSubsetInstances(n int) []discovery.Instance {
+	ins := make([]discovery.Instance, n)
+	for i := range ins {
+		ins[i] = discovery.NewInstance("tcp", "addr"+strconv.Itoa(i), 10, nil)
+	}
+	return ins
+}
+
+func TestSubsetBalancer(t *testing.T) {
+	e := discovery.Result{Cacheable: true, CacheKey: "test", Instances: genSubsetInstances(100)}
+	lb := NewSubsetBalancer(NewWeightedRoundRobinBalancer(), WithSubsetSize(10), WithSubsetSeed(42))
+	test.Assert(t, lb.Name() == "weight_round_robin_subset")
+
+	// only K distinct instances are ever picked, stable across pickers
+	seen := make(map[string]struct{})
+	for i := 0; i < 500; i++ {
+		picker := lb.GetPicker(e)
+		seen[picker.Next(context.TODO(), nil).Address().String()] = struct{}{}
+	}
+	test.Assert(t, len(seen) == 10, len(seen))
+
+	// the subset is deterministic for the same seed
+	sb := lb.(*subsetBalancer)
+	res1 := sb.subsetResult(e)
+	res2 := sb.subsetResult(e)
+	for i := range res1.Instances {
+		test.Assert(t, res1.Instances[i].Address().String() == res2.Instances[i].Address().String())
+	}
+
+	// a different seed picks a different subset
+	other := NewSubsetBalancer(NewWeightedRoundRobinBalancer(), WithSubsetSize(10), WithSubsetSeed(43)).(*subsetBalancer)
+	diff := false
+	otherRes := other.subsetResult(e)
+	for i := range res1.Instances {
+		if res1.Instances[i].Address().String() != otherRes.Instances[i].Address().String() {
+			diff = true
+			break
+		}
+	}
+	test.Assert(t, diff)
+}
+
+func TestSubsetBalancerChurn(t *testing.T) {
+	sb := NewSubsetBalancer(NewWeightedRoundRobinBalancer(), WithSubsetSize(10), WithSubsetSeed(42)).(*subsetBalancer)
+	full := genSubsetInstances(100)
+	before := sb.subsetResult(discovery.Result{Instances: full})
+	// removing one non-subset instance keeps the subset identical
+	after := sb.subsetResult(discovery.Result{Instances: full[:99]})
+	stable := 0
+	beforeSet := make(map[string]struct{})
+	for _, ins := range before.Instances {
+		beforeSet[ins.Address().String()] = struct{}{}
+	}
+	for _, ins := range after.Instances {
+		if _, ok := beforeSet[ins.Address().String()]; ok {
+			stable++
+		}
+	}
+	// rendezvous hashing: at most one member changes per removed instance
+	test.Assert(t, stable >= 9, stable)
+}
+
+func TestSubsetBalancerDisabled(t *testing.T) {
+	e := discovery.Result{Instances: genSubsetInstances(5)}
+	// subset size of zero or larger than the set passes through
+	sb := NewSubsetBalancer(NewWeightedRoundRobinBalancer()).(*subsetBalancer)
+	test.Assert(t, len(sb.subsetResult(e).Instances) == 5)
+	sb = NewSubsetBalancer(NewWeightedRoundRobinBalancer(), WithSubsetSize(10)).(*subsetBalancer)
+	test.Assert(t, len(sb.subsetResult(e).Instances) == 5)
+}
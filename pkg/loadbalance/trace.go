@@ -0,0 +1,149 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/kitex/pkg/diagnosis"
+	"github.com/cloudwego/kitex/pkg/discovery"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+const (
+	// PickTraceTagKey is the rpcinfo endpoint tag the trace of each pick is
+	// recorded under, eg: "candidates=3 chosen=127.0.0.1:8888".
+	PickTraceTagKey = "lb_pick_trace"
+
+	// PickTraceKey is the probe name the recent pick traces are registered under.
+	PickTraceKey diagnosis.ProbeName = "lb_pick_trace"
+
+	// how many recent picks the diagnosis probe keeps
+	pickTraceCapacity = 256
+)
+
+// PickTrace records one pick decision for debugging skewed traffic,
+// eg: "why does all traffic go to one node".
+type PickTrace struct {
+	Balancer   string    `json:"balancer"`
+	Candidates int       `json:"candidates"`
+	Chosen     string    `json:"chosen"`
+	PickedAt   time.Time `json:"picked_at"`
+}
+
+// NewTraceBalancer wraps a balancer so every pick is traced: the candidate set
+// size and the chosen instance are tagged on the request's rpcinfo under
+// PickTraceTagKey, and the most recent picks are kept for the diagnosis probe,
+// registered via RegisterPickTraceDiagnose. Tracing costs a mutex per pick, so
+// keep it opt-in for debugging.
+func NewTraceBalancer(base Loadbalancer) Loadbalancer {
+	return &traceBalancer{base: base}
+}
+
+type traceBalancer struct {
+	base Loadbalancer
+
+	lock   sync.Mutex
+	traces []PickTrace // ring buffer of the last pickTraceCapacity picks
+	next   int
+}
+
+// RegisterPickTraceDiagnose registers the recent pick traces of a trace
+// balancer on the debug service, no-op for other balancer implementations.
+func RegisterPickTraceDiagnose(lb Loadbalancer, svc diagnosis.Service) {
+	if tb, ok := lb.(*traceBalancer); ok {
+		diagnosis.RegisterProbeFunc(svc, PickTraceKey, tb.traceDump)
+	}
+}
+
+func (tb *traceBalancer) traceDump() interface{} {
+	tb.lock.Lock()
+	defer tb.lock.Unlock()
+	// oldest first
+	dump := make([]PickTrace, 0, len(tb.traces))
+	dump = append(dump, tb.traces[tb.next:]...)
+	dump = append(dump, tb.traces[:tb.next]...)
+	return dump
+}
+
+func (tb *traceBalancer) record(trace PickTrace) {
+	tb.lock.Lock()
+	defer tb.lock.Unlock()
+	if len(tb.traces) < pickTraceCapacity {
+		tb.traces = append(tb.traces, trace)
+		tb.next = len(tb.traces) % pickTraceCapacity
+		return
+	}
+	tb.traces[tb.next] = trace
+	tb.next = (tb.next + 1) % pickTraceCapacity
+}
+
+// GetPicker implements the Loadbalancer interface.
+func (tb *traceBalancer) GetPicker(e discovery.Result) Picker {
+	return &tracePicker{
+		tb:         tb,
+		base:       tb.base.GetPicker(e),
+		candidates: len(e.Instances),
+	}
+}
+
+// Rebalance implements the Rebalancer interface.
+func (tb *traceBalancer) Rebalance(change discovery.Change) {
+	if rb, ok := tb.base.(Rebalancer); ok {
+		rb.Rebalance(change)
+	}
+}
+
+// Delete implements the Rebalancer interface.
+func (tb *traceBalancer) Delete(change discovery.Change) {
+	if rb, ok := tb.base.(Rebalancer); ok {
+		rb.Delete(change)
+	}
+}
+
+func (tb *traceBalancer) Name() string {
+	return tb.base.Name() + "_trace"
+}
+
+type tracePicker struct {
+	tb         *traceBalancer
+	base       Picker
+	candidates int
+}
+
+// Next implements the Picker interface.
+func (tp *tracePicker) Next(ctx context.Context, request interface{}) discovery.Instance {
+	ins := tp.base.Next(ctx, request)
+	trace := PickTrace{
+		Balancer:   tp.tb.base.Name(),
+		Candidates: tp.candidates,
+		PickedAt:   time.Now(),
+	}
+	if ins != nil {
+		trace.Chosen = ins.Address().String()
+	}
+	tp.tb.record(trace)
+	if ri := rpcinfo.GetRPCInfo(ctx); ri != nil {
+		if to := rpcinfo.AsMutableEndpointInfo(ri.To()); to != nil {
+			to.SetTag(PickTraceTagKey, "candidates="+strconv.Itoa(trace.Candidates)+" chosen="+trace.Chosen)
+		}
+	}
+	return ins
+}
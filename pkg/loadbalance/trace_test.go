@@ -0,0 +1,75 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/discovery"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+func TestTraceBalancer(t *testing.T) {
+	e := discovery.Result{
+		Cacheable: false,
+		Instances: []discovery.Instance{
+			discovery.NewInstance("tcp", "addr1", 10, nil),
+			discovery.NewInstance("tcp", "addr2", 10, nil),
+		},
+	}
+
+	lb := NewTraceBalancer(NewWeightedRoundRobinBalancer())
+	test.Assert(t, lb.Name() == "weight_round_robin_trace")
+
+	to := rpcinfo.NewMutableEndpointInfo("service", "method", nil, nil)
+	ri := rpcinfo.NewRPCInfo(nil, to.ImmutableView(), nil, nil, nil)
+	ctx := rpcinfo.NewCtxWithRPCInfo(context.Background(), ri)
+
+	picker := lb.GetPicker(e)
+	ins := picker.Next(ctx, nil)
+	test.Assert(t, ins != nil)
+
+	// the pick is tagged on rpcinfo
+	tag, ok := ri.To().Tag(PickTraceTagKey)
+	test.Assert(t, ok)
+	test.Assert(t, tag == "candidates=2 chosen="+ins.Address().String(), tag)
+
+	// and kept for the diagnosis probe
+	traces := lb.(*traceBalancer).traceDump().([]PickTrace)
+	test.Assert(t, len(traces) == 1)
+	test.Assert(t, traces[0].Candidates == 2)
+	test.Assert(t, traces[0].Chosen == ins.Address().String())
+	test.Assert(t, traces[0].Balancer == "weight_round_robin")
+}
+
+func TestTraceBalancerRingBuffer(t *testing.T) {
+	e := discovery.Result{
+		Cacheable: false,
+		Instances: []discovery.Instance{
+			discovery.NewInstance("tcp", "addr1", 10, nil),
+		},
+	}
+	lb := NewTraceBalancer(NewWeightedRoundRobinBalancer()).(*traceBalancer)
+	picker := lb.GetPicker(e)
+	for i := 0; i < pickTraceCapacity+10; i++ {
+		picker.Next(context.Background(), nil)
+	}
+	traces := lb.traceDump().([]PickTrace)
+	test.Assert(t, len(traces) == pickTraceCapacity, len(traces))
+}
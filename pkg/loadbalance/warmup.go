@@ -0,0 +1,139 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/kitex/pkg/discovery"
+)
+
+// DefaultRegisterTimeTag is the instance tag carrying the unix millisecond
+// timestamp of when the instance registered itself.
+const DefaultRegisterTimeTag = "register_time_ms"
+
+// WarmupOption configures NewWarmupBalancer.
+type WarmupOption struct {
+	// Window is how long a new instance takes to ramp up to its full weight.
+	Window time.Duration
+	// RegisterTimeTag is the instance tag with the unix millisecond register
+	// time, DefaultRegisterTimeTag when empty. Instances without the tag are
+	// ramped from the moment this client first sees them.
+	RegisterTimeTag string
+}
+
+// NewWarmupBalancer wraps a weighted balancer with slow-start warmup: the
+// effective weight of a newly registered instance ramps up linearly over the
+// warmup window instead of jumping to full weight, protecting cold instances
+// (empty caches, JIT) from a thundering herd. The instance age is read from
+// the register time tag when present and falls back to the first time this
+// client saw the instance.
+func NewWarmupBalancer(base Loadbalancer, opt WarmupOption) Loadbalancer {
+	if opt.RegisterTimeTag == "" {
+		opt.RegisterTimeTag = DefaultRegisterTimeTag
+	}
+	return &warmupBalancer{base: base, opt: opt}
+}
+
+type warmupBalancer struct {
+	base Loadbalancer
+	opt  WarmupOption
+
+	firstSeen sync.Map // map[address]time.Time, for instances without the tag
+}
+
+// GetPicker implements the Loadbalancer interface.
+func (wb *warmupBalancer) GetPicker(e discovery.Result) Picker {
+	if wb.opt.Window <= 0 {
+		return wb.base.GetPicker(e)
+	}
+	if adjusted, changed := wb.adjustInstances(e.Instances); changed {
+		// warmup weights change with time, the ramped result must not be
+		// cached; once every instance is warmed up the cached path resumes
+		e = discovery.Result{Instances: adjusted}
+	}
+	return wb.base.GetPicker(e)
+}
+
+// Rebalance implements the Rebalancer interface.
+func (wb *warmupBalancer) Rebalance(change discovery.Change) {
+	for i := range change.Removed {
+		wb.firstSeen.Delete(change.Removed[i].Address().String())
+	}
+	if rb, ok := wb.base.(Rebalancer); ok {
+		rb.Rebalance(change)
+	}
+}
+
+// Delete implements the Rebalancer interface.
+func (wb *warmupBalancer) Delete(change discovery.Change) {
+	if rb, ok := wb.base.(Rebalancer); ok {
+		rb.Delete(change)
+	}
+}
+
+func (wb *warmupBalancer) Name() string {
+	return wb.base.Name() + "_warmup"
+}
+
+func (wb *warmupBalancer) adjustInstances(instances []discovery.Instance) (adjusted []discovery.Instance, changed bool) {
+	now := time.Now()
+	adjusted = make([]discovery.Instance, len(instances))
+	for i, ins := range instances {
+		age := wb.instanceAge(ins, now)
+		if age >= wb.opt.Window {
+			adjusted[i] = ins
+			continue
+		}
+		weight := int(int64(ins.Weight()) * int64(age) / int64(wb.opt.Window))
+		if weight < 1 {
+			weight = 1
+		}
+		adjusted[i] = &warmupInstance{Instance: ins, weight: weight}
+		changed = true
+	}
+	if !changed {
+		return instances, false
+	}
+	return adjusted, true
+}
+
+func (wb *warmupBalancer) instanceAge(ins discovery.Instance, now time.Time) time.Duration {
+	if tag, ok := ins.Tag(wb.opt.RegisterTimeTag); ok {
+		if ms, err := strconv.ParseInt(tag, 10, 64); err == nil {
+			return now.Sub(time.UnixMilli(ms))
+		}
+	}
+	addr := ins.Address().String()
+	if seen, ok := wb.firstSeen.Load(addr); ok {
+		return now.Sub(seen.(time.Time))
+	}
+	seen, _ := wb.firstSeen.LoadOrStore(addr, now)
+	return now.Sub(seen.(time.Time))
+}
+
+// warmupInstance overrides the effective weight of an instance while it warms up.
+type warmupInstance struct {
+	discovery.Instance
+	weight int
+}
+
+func (w *warmupInstance) Weight() int {
+	return w.weight
+}
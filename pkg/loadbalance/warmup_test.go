@@ -0,0 +1,88 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/discovery"
+)
+
+func registerTimeTag(t time.Time) map[string]string {
+	return map[string]string{DefaultRegisterTimeTag: strconv.FormatInt(t.UnixMilli(), 10)}
+}
+
+func TestWarmupBalancer(t *testing.T) {
+	now := time.Now()
+	warm := discovery.NewInstance("tcp", "warm", 100, registerTimeTag(now.Add(-time.Hour)))
+	cold := discovery.NewInstance("tcp", "cold", 100, registerTimeTag(now))
+	e := discovery.Result{
+		Cacheable: true,
+		CacheKey:  "test",
+		Instances: []discovery.Instance{warm, cold},
+	}
+
+	lb := NewWarmupBalancer(NewWeightedRoundRobinBalancer(), WarmupOption{Window: time.Minute})
+	test.Assert(t, lb.Name() == "weight_round_robin_warmup")
+
+	// the cold instance just registered, nearly all picks go to the warm one
+	seen := make(map[string]int)
+	picker := lb.GetPicker(e)
+	for i := 0; i < 100; i++ {
+		seen[picker.Next(context.TODO(), nil).Address().String()]++
+	}
+	test.Assert(t, seen["warm"] > 90, seen)
+	test.Assert(t, seen["cold"] >= 1, seen)
+}
+
+func TestWarmupBalancerWarmedUp(t *testing.T) {
+	now := time.Now()
+	e := discovery.Result{
+		Cacheable: true,
+		CacheKey:  "test",
+		Instances: []discovery.Instance{
+			discovery.NewInstance("tcp", "a", 10, registerTimeTag(now.Add(-time.Hour))),
+			discovery.NewInstance("tcp", "b", 10, registerTimeTag(now.Add(-time.Hour))),
+		},
+	}
+	wb := NewWarmupBalancer(NewWeightedRoundRobinBalancer(), WarmupOption{Window: time.Minute}).(*warmupBalancer)
+	// everything is past the window: the instances pass through untouched
+	adjusted, changed := wb.adjustInstances(e.Instances)
+	test.Assert(t, !changed)
+	test.Assert(t, adjusted[0] == e.Instances[0])
+	// zero window disables warmup entirely
+	lb := NewWarmupBalancer(NewWeightedRoundRobinBalancer(), WarmupOption{})
+	test.Assert(t, lb.GetPicker(e).Next(context.TODO(), nil) != nil)
+}
+
+func TestWarmupBalancerFirstSeen(t *testing.T) {
+	// no register time tag: the ramp starts when the client first sees the instance
+	ins := discovery.NewInstance("tcp", "a", 100, nil)
+	wb := NewWarmupBalancer(NewWeightedRoundRobinBalancer(), WarmupOption{Window: time.Minute}).(*warmupBalancer)
+	adjusted, changed := wb.adjustInstances([]discovery.Instance{ins})
+	test.Assert(t, changed)
+	test.Assert(t, adjusted[0].Weight() < 100, adjusted[0].Weight())
+
+	// removal cleans the first-seen record
+	wb.Rebalance(discovery.Change{Removed: []discovery.Instance{ins}})
+	_, ok := wb.firstSeen.Load("a")
+	test.Assert(t, !ok)
+}
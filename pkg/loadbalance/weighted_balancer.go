@@ -30,6 +30,7 @@ const (
 	lbKindInterleaved
 	lbKindRandom
 	lbKindRandomWithAliasMethod
+	lbKindSmoothRoundRobin
 )
 
 type weightedBalancer struct {
@@ -61,6 +62,14 @@ func NewWeightedRandomBalancer() Loadbalancer {
 	return lb
 }
 
+// NewSmoothWeightedRoundRobinBalancer creates a loadbalancer using smooth-weighted-round-robin algorithm
+// computed per pick. It spreads selections of high-weight instances evenly over time without the bursts
+// weighted-random allows, and without precalculating the pick sequence like NewWeightedRoundRobinBalancer does.
+func NewSmoothWeightedRoundRobinBalancer() Loadbalancer {
+	lb := &weightedBalancer{kind: lbKindSmoothRoundRobin}
+	return lb
+}
+
 // NewWeightedRandomWithAliasMethodBalancer creates a loadbalancer using alias-method algorithm.
 func NewWeightedRandomWithAliasMethodBalancer() Loadbalancer {
 	lb := &weightedBalancer{kind: lbKindRandomWithAliasMethod}
@@ -121,6 +130,12 @@ func (wb *weightedBalancer) createPicker(e discovery.Result) (picker Picker) {
 		} else {
 			picker = newInterleavedWeightedRoundRobinPicker(instances)
 		}
+	case lbKindSmoothRoundRobin:
+		if balance {
+			picker = newRoundRobinPicker(instances)
+		} else {
+			picker = newSmoothWeightedRoundRobinPicker(instances)
+		}
 	case lbKindRandomWithAliasMethod:
 		if balance {
 			picker = newRandomPicker(instances)
@@ -159,6 +174,8 @@ func (wb *weightedBalancer) Name() string {
 		return "weight_round_robin"
 	case lbKindInterleaved:
 		return "interleaved_weighted_round_robin"
+	case lbKindSmoothRoundRobin:
+		return "smooth_weight_round_robin"
 	case lbKindRandomWithAliasMethod:
 		return "weight_random_with_alias_method"
 	default:
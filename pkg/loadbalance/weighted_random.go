@@ -38,14 +38,17 @@ func newWeightedRandomPickerWithSum(instances []discovery.Instance, weightSum in
 
 // Next implements the Picker interface.
 func (wp *weightedRandomPicker) Next(ctx context.Context, request interface{}) (ins discovery.Instance) {
-	weight := fastrand.Intn(wp.weightSum)
-	for i := 0; i < len(wp.instances); i++ {
-		weight -= wp.instances[i].Weight()
-		if weight < 0 {
-			return wp.instances[i]
+	// random draws may repeat, so give them the full attempt budget
+	return nextExcluding(ctx, maxExclusionAttempts, func() discovery.Instance {
+		weight := fastrand.Intn(wp.weightSum)
+		for i := 0; i < len(wp.instances); i++ {
+			weight -= wp.instances[i].Weight()
+			if weight < 0 {
+				return wp.instances[i]
+			}
 		}
-	}
-	return nil
+		return nil
+	})
 }
 
 type randomPicker struct {
@@ -60,6 +63,8 @@ func newRandomPicker(instances []discovery.Instance) Picker {
 
 // Next implements the Picker interface.
 func (rp *randomPicker) Next(ctx context.Context, request interface{}) (ins discovery.Instance) {
-	idx := fastrand.Intn(len(rp.instances))
-	return rp.instances[idx]
+	return nextExcluding(ctx, maxExclusionAttempts, func() discovery.Instance {
+		idx := fastrand.Intn(len(rp.instances))
+		return rp.instances[idx]
+	})
 }
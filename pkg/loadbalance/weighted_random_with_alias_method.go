@@ -93,9 +93,12 @@ func (a *AliasMethodPicker) init() {
 
 // Next implements the Picker interface.
 func (a *AliasMethodPicker) Next(ctx context.Context, request interface{}) discovery.Instance {
-	i := fastrand.Intn(len(a.instances))
-	if fastrand.Float64() < a.prob[i] {
-		return a.instances[i]
-	}
-	return a.instances[a.alias[i]]
+	// random draws may repeat, so give them the full attempt budget
+	return nextExcluding(ctx, maxExclusionAttempts, func() discovery.Instance {
+		i := fastrand.Intn(len(a.instances))
+		if fastrand.Float64() < a.prob[i] {
+			return a.instances[i]
+		}
+		return a.instances[a.alias[i]]
+	})
 }
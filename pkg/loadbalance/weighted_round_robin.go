@@ -78,6 +78,12 @@ type WeightedRoundRobinPicker struct {
 
 // Next implements the Picker interface.
 func (wp *WeightedRoundRobinPicker) Next(ctx context.Context, request interface{}) (ins discovery.Instance) {
+	// with skewed weights a window of size picks may hold one instance only,
+	// so give the walk up to vcapacity attempts (capped by nextExcluding)
+	return nextExcluding(ctx, int(wp.vcapacity), wp.next)
+}
+
+func (wp *WeightedRoundRobinPicker) next() (ins discovery.Instance) {
 	// iterator must start from 0, because we need warmup the vnode at beginning
 	idx := wp.iterator.Next() % wp.vcapacity
 	// fast path
@@ -152,9 +158,10 @@ func (rp *RoundRobinPicker) Next(ctx context.Context, request interface{}) (ins
 	if rp.size == 0 {
 		return nil
 	}
-	idx := rp.iterator.Next() % rp.size
-	ins = rp.instances[idx]
-	return ins
+	return nextExcluding(ctx, int(rp.size), func() discovery.Instance {
+		idx := rp.iterator.Next() % rp.size
+		return rp.instances[idx]
+	})
 }
 
 func gcdInt(a, b int) int {
@@ -0,0 +1,217 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package reflection
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// ListServicesRequest asks for the registered services.
+type ListServicesRequest struct{}
+
+// Marshal implements the protobuf codec interface.
+func (r *ListServicesRequest) Marshal(out []byte) ([]byte, error) { return out, nil }
+
+// Unmarshal implements the protobuf codec interface.
+func (r *ListServicesRequest) Unmarshal(in []byte) error { return nil }
+
+func (r *ListServicesRequest) String() string { return "ListServicesRequest{}" }
+
+// Service describes one registered service.
+type Service struct {
+	Name    string   // field 1
+	Methods []string // field 2
+}
+
+func (s *Service) marshal(out []byte) []byte {
+	if s.Name != "" {
+		out = protowire.AppendTag(out, 1, protowire.BytesType)
+		out = protowire.AppendString(out, s.Name)
+	}
+	for _, m := range s.Methods {
+		out = protowire.AppendTag(out, 2, protowire.BytesType)
+		out = protowire.AppendString(out, m)
+	}
+	return out
+}
+
+func (s *Service) unmarshal(in []byte) error {
+	for len(in) > 0 {
+		num, typ, n := protowire.ConsumeTag(in)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		in = in[n:]
+		if typ == protowire.BytesType && (num == 1 || num == 2) {
+			v, n := protowire.ConsumeString(in)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			if num == 1 {
+				s.Name = v
+			} else {
+				s.Methods = append(s.Methods, v)
+			}
+			in = in[n:]
+			continue
+		}
+		n = protowire.ConsumeFieldValue(num, typ, in)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		in = in[n:]
+	}
+	return nil
+}
+
+// ListServicesResponse carries the registered services.
+type ListServicesResponse struct {
+	Services []Service // field 1
+}
+
+// Marshal implements the protobuf codec interface.
+func (r *ListServicesResponse) Marshal(out []byte) ([]byte, error) {
+	for i := range r.Services {
+		out = protowire.AppendTag(out, 1, protowire.BytesType)
+		out = protowire.AppendBytes(out, r.Services[i].marshal(nil))
+	}
+	return out, nil
+}
+
+// Unmarshal implements the protobuf codec interface.
+func (r *ListServicesResponse) Unmarshal(in []byte) error {
+	for len(in) > 0 {
+		num, typ, n := protowire.ConsumeTag(in)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		in = in[n:]
+		if num == 1 && typ == protowire.BytesType {
+			b, n := protowire.ConsumeBytes(in)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			var svc Service
+			if err := svc.unmarshal(b); err != nil {
+				return err
+			}
+			r.Services = append(r.Services, svc)
+			in = in[n:]
+			continue
+		}
+		n = protowire.ConsumeFieldValue(num, typ, in)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		in = in[n:]
+	}
+	return nil
+}
+
+func (r *ListServicesResponse) String() string {
+	return fmt.Sprintf("ListServicesResponse{Services: %+v}", r.Services)
+}
+
+// GetIDLRequest asks for the IDL source of a service.
+type GetIDLRequest struct {
+	Service string // field 1
+}
+
+// Marshal implements the protobuf codec interface.
+func (r *GetIDLRequest) Marshal(out []byte) ([]byte, error) {
+	if r.Service != "" {
+		out = protowire.AppendTag(out, 1, protowire.BytesType)
+		out = protowire.AppendString(out, r.Service)
+	}
+	return out, nil
+}
+
+// Unmarshal implements the protobuf codec interface.
+func (r *GetIDLRequest) Unmarshal(in []byte) error {
+	for len(in) > 0 {
+		num, typ, n := protowire.ConsumeTag(in)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		in = in[n:]
+		if num == 1 && typ == protowire.BytesType {
+			s, n := protowire.ConsumeString(in)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			r.Service = s
+			in = in[n:]
+			continue
+		}
+		n = protowire.ConsumeFieldValue(num, typ, in)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		in = in[n:]
+	}
+	return nil
+}
+
+func (r *GetIDLRequest) String() string {
+	return fmt.Sprintf("GetIDLRequest{Service: %q}", r.Service)
+}
+
+// GetIDLResponse carries the IDL source of a service.
+type GetIDLResponse struct {
+	IDL string // field 1
+}
+
+// Marshal implements the protobuf codec interface.
+func (r *GetIDLResponse) Marshal(out []byte) ([]byte, error) {
+	if r.IDL != "" {
+		out = protowire.AppendTag(out, 1, protowire.BytesType)
+		out = protowire.AppendString(out, r.IDL)
+	}
+	return out, nil
+}
+
+// Unmarshal implements the protobuf codec interface.
+func (r *GetIDLResponse) Unmarshal(in []byte) error {
+	for len(in) > 0 {
+		num, typ, n := protowire.ConsumeTag(in)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		in = in[n:]
+		if num == 1 && typ == protowire.BytesType {
+			s, n := protowire.ConsumeString(in)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			r.IDL = s
+			in = in[n:]
+			continue
+		}
+		n = protowire.ConsumeFieldValue(num, typ, in)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		in = in[n:]
+	}
+	return nil
+}
+
+func (r *GetIDLResponse) String() string {
+	return fmt.Sprintf("GetIDLResponse{IDL: %d bytes}", len(r.IDL))
+}
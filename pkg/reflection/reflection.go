@@ -0,0 +1,133 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package reflection provides a built-in reflection service, enabled with
+// server.WithReflectionService. It lets generic clients and debug CLIs list
+// the registered services and methods, and fetch the original IDL of a
+// service without a local copy, similar to gRPC server reflection.
+// IDL sources are published with RegisterIDL, typically from generated code.
+package reflection
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/cloudwego/kitex/pkg/serviceinfo"
+)
+
+// ServiceName is the registered name of the reflection service.
+const ServiceName = "kitex.reflection.v1.Reflection"
+
+var (
+	idlMu  sync.RWMutex
+	idlMap = make(map[string]string)
+)
+
+// RegisterIDL publishes the IDL source of a service, so GetIDL can serve it.
+// It is expected to be called from generated code or server setup.
+func RegisterIDL(service, content string) {
+	idlMu.Lock()
+	idlMap[service] = content
+	idlMu.Unlock()
+}
+
+// ServiceInfoSource provides the registered services; *server.server
+// implements it with GetServiceInfos.
+type ServiceInfoSource interface {
+	GetServiceInfos() map[string]*serviceinfo.ServiceInfo
+}
+
+// NewServiceInfo builds the ServiceInfo of the reflection service.
+func NewServiceInfo() *serviceinfo.ServiceInfo {
+	return &serviceinfo.ServiceInfo{
+		ServiceName:  ServiceName,
+		PayloadCodec: serviceinfo.Protobuf,
+		Methods: map[string]serviceinfo.MethodInfo{
+			"ListServices": serviceinfo.NewMethodInfo(
+				listServicesHandler,
+				func() interface{} { return &ListServicesRequest{} },
+				func() interface{} { return &ListServicesResponse{} },
+				false,
+			),
+			"GetIDL": serviceinfo.NewMethodInfo(
+				getIDLHandler,
+				func() interface{} { return &GetIDLRequest{} },
+				func() interface{} { return &GetIDLResponse{} },
+				false,
+			),
+		},
+		Extra: map[string]interface{}{
+			"PackageName": "kitex.reflection.v1",
+		},
+	}
+}
+
+// Handler serves reflection requests against the given service source.
+type Handler struct {
+	src ServiceInfoSource
+}
+
+// NewHandler creates the handler to be registered with the reflection ServiceInfo.
+func NewHandler(src ServiceInfoSource) *Handler {
+	return &Handler{src: src}
+}
+
+// ListServices returns the registered services with their methods sorted by name.
+func (h *Handler) ListServices(ctx context.Context, req *ListServicesRequest) (*ListServicesResponse, error) {
+	svcInfos := h.src.GetServiceInfos()
+	resp := &ListServicesResponse{Services: make([]Service, 0, len(svcInfos))}
+	for name, svcInfo := range svcInfos {
+		svc := Service{Name: name, Methods: make([]string, 0, len(svcInfo.Methods))}
+		for method := range svcInfo.Methods {
+			svc.Methods = append(svc.Methods, method)
+		}
+		sort.Strings(svc.Methods)
+		resp.Services = append(resp.Services, svc)
+	}
+	sort.Slice(resp.Services, func(i, j int) bool { return resp.Services[i].Name < resp.Services[j].Name })
+	return resp, nil
+}
+
+// GetIDL returns the IDL source registered for the requested service.
+func (h *Handler) GetIDL(ctx context.Context, req *GetIDLRequest) (*GetIDLResponse, error) {
+	idlMu.RLock()
+	content, ok := idlMap[req.Service]
+	idlMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no IDL registered for service %q", req.Service)
+	}
+	return &GetIDLResponse{IDL: content}, nil
+}
+
+func listServicesHandler(ctx context.Context, handler, arg, result interface{}) error {
+	resp, err := handler.(*Handler).ListServices(ctx, arg.(*ListServicesRequest))
+	if err != nil {
+		return err
+	}
+	*result.(*ListServicesResponse) = *resp
+	return nil
+}
+
+func getIDLHandler(ctx context.Context, handler, arg, result interface{}) error {
+	resp, err := handler.(*Handler).GetIDL(ctx, arg.(*GetIDLRequest))
+	if err != nil {
+		return err
+	}
+	*result.(*GetIDLResponse) = *resp
+	return nil
+}
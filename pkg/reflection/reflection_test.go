@@ -0,0 +1,97 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package reflection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/serviceinfo"
+)
+
+type mockSource map[string]*serviceinfo.ServiceInfo
+
+func (m mockSource) GetServiceInfos() map[string]*serviceinfo.ServiceInfo { return m }
+
+func mockSvcInfo(name string, methods ...string) *serviceinfo.ServiceInfo {
+	ms := make(map[string]serviceinfo.MethodInfo, len(methods))
+	for _, m := range methods {
+		ms[m] = serviceinfo.NewMethodInfo(nil, nil, nil, false)
+	}
+	return &serviceinfo.ServiceInfo{ServiceName: name, Methods: ms}
+}
+
+func TestListServices(t *testing.T) {
+	src := mockSource{
+		"b.svc": mockSvcInfo("b.svc", "Mock2", "Mock1"),
+		"a.svc": mockSvcInfo("a.svc", "Echo"),
+	}
+	resp, err := NewHandler(src).ListServices(context.Background(), &ListServicesRequest{})
+	test.Assert(t, err == nil, err)
+	test.Assert(t, len(resp.Services) == 2)
+	test.Assert(t, resp.Services[0].Name == "a.svc")
+	test.DeepEqual(t, resp.Services[0].Methods, []string{"Echo"})
+	test.Assert(t, resp.Services[1].Name == "b.svc")
+	test.DeepEqual(t, resp.Services[1].Methods, []string{"Mock1", "Mock2"})
+}
+
+func TestGetIDL(t *testing.T) {
+	RegisterIDL("a.svc", "service A {}")
+	h := NewHandler(mockSource{})
+
+	resp, err := h.GetIDL(context.Background(), &GetIDLRequest{Service: "a.svc"})
+	test.Assert(t, err == nil, err)
+	test.Assert(t, resp.IDL == "service A {}")
+
+	_, err = h.GetIDL(context.Background(), &GetIDLRequest{Service: "unknown.svc"})
+	test.Assert(t, err != nil)
+}
+
+func TestMessagesRoundTrip(t *testing.T) {
+	resp := &ListServicesResponse{Services: []Service{
+		{Name: "a.svc", Methods: []string{"Echo", "Mock"}},
+		{Name: "b.svc"},
+	}}
+	buf, err := resp.Marshal(nil)
+	test.Assert(t, err == nil, err)
+	var decoded ListServicesResponse
+	test.Assert(t, decoded.Unmarshal(buf) == nil)
+	test.DeepEqual(t, &decoded, resp)
+
+	req := &GetIDLRequest{Service: "a.svc"}
+	buf, err = req.Marshal(nil)
+	test.Assert(t, err == nil, err)
+	var decodedReq GetIDLRequest
+	test.Assert(t, decodedReq.Unmarshal(buf) == nil)
+	test.DeepEqual(t, &decodedReq, req)
+
+	idlResp := &GetIDLResponse{IDL: "service A {}"}
+	buf, err = idlResp.Marshal(nil)
+	test.Assert(t, err == nil, err)
+	var decodedIDL GetIDLResponse
+	test.Assert(t, decodedIDL.Unmarshal(buf) == nil)
+	test.DeepEqual(t, &decodedIDL, idlResp)
+}
+
+func TestNewServiceInfo(t *testing.T) {
+	svcInfo := NewServiceInfo()
+	test.Assert(t, svcInfo.ServiceName == ServiceName)
+	test.Assert(t, svcInfo.PayloadCodec == serviceinfo.Protobuf)
+	test.Assert(t, svcInfo.Methods["ListServices"] != nil)
+	test.Assert(t, svcInfo.Methods["GetIDL"] != nil)
+}
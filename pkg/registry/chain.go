@@ -0,0 +1,93 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registry
+
+import (
+	"time"
+
+	"github.com/cloudwego/kitex/pkg/utils"
+)
+
+// DefaultChainRetryInterval is the pause between retries of a failed
+// registration within a chain.
+const DefaultChainRetryInterval = time.Second
+
+// ChainOptions configures ChainWithOptions.
+type ChainOptions struct {
+	// Retries is how many extra attempts each registry gets before its
+	// error is reported.
+	Retries int
+	// RetryInterval is the pause between attempts,
+	// DefaultChainRetryInterval when 0.
+	RetryInterval time.Duration
+}
+
+// Chain combines several registries into one, so a server can register into
+// all of them at once, e.g. while migrating between registry systems. Each
+// registry is handled independently: a failure in one doesn't stop the
+// others, and all failures are reported together.
+func Chain(registries ...Registry) Registry {
+	return ChainWithOptions(ChainOptions{}, registries...)
+}
+
+// ChainWithOptions is Chain with retry control: each failing registry is
+// retried opts.Retries times before its error counts.
+func ChainWithOptions(opts ChainOptions, registries ...Registry) Registry {
+	if opts.RetryInterval <= 0 {
+		opts.RetryInterval = DefaultChainRetryInterval
+	}
+	return &chainRegistry{registries: registries, opts: opts}
+}
+
+type chainRegistry struct {
+	registries []Registry
+	opts       ChainOptions
+}
+
+// Register implements the Registry interface. Every registry is attempted;
+// the returned error aggregates the ones that still fail after retries.
+func (c *chainRegistry) Register(info *Info) error {
+	return c.each(func(r Registry) error { return r.Register(info) })
+}
+
+// Deregister implements the Registry interface. Every registry is attempted
+// so a failure in one doesn't leave stale registrations in the others.
+func (c *chainRegistry) Deregister(info *Info) error {
+	return c.each(func(r Registry) error { return r.Deregister(info) })
+}
+
+func (c *chainRegistry) each(op func(Registry) error) error {
+	var errs utils.ErrChain
+	for _, r := range c.registries {
+		var err error
+		for attempt := 0; attempt <= c.opts.Retries; attempt++ {
+			if attempt > 0 {
+				time.Sleep(c.opts.RetryInterval)
+			}
+			if err = op(r); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			errs.Append(err)
+		}
+	}
+	if errs.HasError() {
+		return errs
+	}
+	return nil
+}
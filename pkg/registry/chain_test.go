@@ -0,0 +1,80 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registry
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/kitex/internal/test"
+)
+
+type mockRegistry struct {
+	registered   int
+	deregistered int
+	failUntil    int // Register fails while registered < failUntil
+	err          error
+}
+
+func (m *mockRegistry) Register(info *Info) error {
+	m.registered++
+	if m.registered <= m.failUntil {
+		return m.err
+	}
+	return nil
+}
+
+func (m *mockRegistry) Deregister(info *Info) error {
+	m.deregistered++
+	return m.err
+}
+
+func TestChainRegister(t *testing.T) {
+	r1 := &mockRegistry{}
+	r2 := &mockRegistry{}
+	c := Chain(r1, r2)
+	test.Assert(t, c.Register(&Info{ServiceName: "svc"}) == nil)
+	test.Assert(t, r1.registered == 1)
+	test.Assert(t, r2.registered == 1)
+	test.Assert(t, c.Deregister(&Info{ServiceName: "svc"}) == nil)
+	test.Assert(t, r1.deregistered == 1)
+	test.Assert(t, r2.deregistered == 1)
+}
+
+func TestChainRegisterPartialFailure(t *testing.T) {
+	fail := errors.New("registry down")
+	r1 := &mockRegistry{failUntil: 100, err: fail}
+	r2 := &mockRegistry{}
+	c := Chain(r1, r2)
+
+	// the healthy registry is still attempted, the failure is reported
+	err := c.Register(&Info{ServiceName: "svc"})
+	test.Assert(t, err != nil)
+	test.Assert(t, err.Error() == fail.Error(), err)
+	test.Assert(t, r2.registered == 1)
+}
+
+func TestChainRegisterRetry(t *testing.T) {
+	fail := errors.New("registry flaky")
+	r1 := &mockRegistry{failUntil: 2, err: fail}
+	c := ChainWithOptions(ChainOptions{Retries: 2, RetryInterval: time.Millisecond}, r1)
+
+	// two failures then success within the retry budget
+	test.Assert(t, c.Register(&Info{ServiceName: "svc"}) == nil)
+	test.Assert(t, r1.registered == 3)
+}
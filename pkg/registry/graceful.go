@@ -0,0 +1,133 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudwego/kitex/pkg/discovery"
+)
+
+const (
+	// DefaultVerifyInterval is the pause between verification polls.
+	DefaultVerifyInterval = 500 * time.Millisecond
+	// DefaultVerifyTimeout bounds how long Deregister waits for the instance
+	// to disappear from the registry.
+	DefaultVerifyTimeout = 10 * time.Second
+)
+
+// GracefulOptions configures WithGraceful.
+type GracefulOptions struct {
+	// PropagationDelay is slept after a successful deregistration (and
+	// verification, when configured) before Deregister returns, giving
+	// clients time to refresh their instance lists before the listener
+	// stops accepting.
+	PropagationDelay time.Duration
+	// OnDeregister is called with the deregistration result before any
+	// waiting starts.
+	OnDeregister func(info *Info, err error)
+	// Verify reports whether the instance is gone from the registry; when
+	// set, Deregister polls it until it reports true or VerifyTimeout
+	// passes. See VerifyGoneWithResolver for the common case.
+	Verify func(info *Info) (gone bool, err error)
+	// VerifyInterval and VerifyTimeout control the polling,
+	// DefaultVerifyInterval and DefaultVerifyTimeout when 0.
+	VerifyInterval time.Duration
+	VerifyTimeout  time.Duration
+}
+
+// WithGraceful wraps a registry so Deregister only returns once the
+// deregistration has taken effect: the result is reported through a
+// callback, the registry is optionally polled until the instance is gone,
+// and a propagation delay lets clients drop the instance before the server
+// stops accepting. Since kitex deregisters before closing the listener,
+// wrapping the registry is all a zero-error rolling restart needs.
+func WithGraceful(r Registry, opts GracefulOptions) Registry {
+	if opts.VerifyInterval <= 0 {
+		opts.VerifyInterval = DefaultVerifyInterval
+	}
+	if opts.VerifyTimeout <= 0 {
+		opts.VerifyTimeout = DefaultVerifyTimeout
+	}
+	return &gracefulRegistry{registry: r, opts: opts}
+}
+
+type gracefulRegistry struct {
+	registry Registry
+	opts     GracefulOptions
+}
+
+// Register implements the Registry interface.
+func (g *gracefulRegistry) Register(info *Info) error {
+	return g.registry.Register(info)
+}
+
+// Deregister implements the Registry interface.
+func (g *gracefulRegistry) Deregister(info *Info) error {
+	err := g.registry.Deregister(info)
+	if g.opts.OnDeregister != nil {
+		g.opts.OnDeregister(info, err)
+	}
+	if err != nil {
+		return err
+	}
+	if g.opts.Verify != nil {
+		if err := g.waitGone(info); err != nil {
+			return err
+		}
+	}
+	time.Sleep(g.opts.PropagationDelay)
+	return nil
+}
+
+func (g *gracefulRegistry) waitGone(info *Info) error {
+	deadline := time.Now().Add(g.opts.VerifyTimeout)
+	for {
+		gone, err := g.opts.Verify(info)
+		if gone {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			if err != nil {
+				return fmt.Errorf("registry: instance still registered after %s: %w", g.opts.VerifyTimeout, err)
+			}
+			return fmt.Errorf("registry: instance still registered after %s", g.opts.VerifyTimeout)
+		}
+		time.Sleep(g.opts.VerifyInterval)
+	}
+}
+
+// VerifyGoneWithResolver builds a Verify func that resolves desc and reports
+// the instance gone when its address is absent from the result; an empty
+// result also counts as gone since nothing routes to the instance then.
+func VerifyGoneWithResolver(resolver discovery.Resolver, desc string) func(info *Info) (bool, error) {
+	return func(info *Info) (bool, error) {
+		res, err := resolver.Resolve(context.Background(), desc)
+		if err != nil {
+			return false, err
+		}
+		addr := info.Addr.String()
+		for _, ins := range res.Instances {
+			if ins.Address().String() == addr {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+}
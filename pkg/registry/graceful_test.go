@@ -0,0 +1,83 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/discovery"
+	"github.com/cloudwego/kitex/pkg/utils"
+)
+
+func TestGracefulDeregister(t *testing.T) {
+	inner := &mockRegistry{}
+	var callbackErr error
+	called := false
+	polls := 0
+	begin := time.Now()
+	r := WithGraceful(inner, GracefulOptions{
+		PropagationDelay: 20 * time.Millisecond,
+		OnDeregister: func(info *Info, err error) {
+			called = true
+			callbackErr = err
+		},
+		Verify: func(info *Info) (bool, error) {
+			polls++
+			return polls >= 3, nil // gone on the third poll
+		},
+		VerifyInterval: time.Millisecond,
+	})
+
+	test.Assert(t, r.Register(&Info{ServiceName: "svc"}) == nil)
+	test.Assert(t, r.Deregister(&Info{ServiceName: "svc"}) == nil)
+	test.Assert(t, called && callbackErr == nil)
+	test.Assert(t, polls == 3, polls)
+	// the propagation delay is respected after verification
+	test.Assert(t, time.Since(begin) >= 20*time.Millisecond)
+	test.Assert(t, inner.deregistered == 1)
+}
+
+func TestGracefulVerifyTimeout(t *testing.T) {
+	r := WithGraceful(&mockRegistry{}, GracefulOptions{
+		Verify:         func(info *Info) (bool, error) { return false, nil },
+		VerifyInterval: time.Millisecond,
+		VerifyTimeout:  10 * time.Millisecond,
+	})
+	test.Assert(t, r.Deregister(&Info{ServiceName: "svc"}) != nil)
+}
+
+func TestVerifyGoneWithResolver(t *testing.T) {
+	instances := []discovery.Instance{discovery.NewInstance("tcp", "10.0.0.1:8888", 10, nil)}
+	resolver := discovery.SynthesizedResolver{
+		NameFunc: func() string { return "mock" },
+		ResolveFunc: func(ctx context.Context, key string) (discovery.Result, error) {
+			return discovery.Result{Instances: instances}, nil
+		},
+	}
+	verify := VerifyGoneWithResolver(resolver, "svc")
+	info := &Info{Addr: utils.NewNetAddr("tcp", "10.0.0.1:8888")}
+
+	gone, err := verify(info)
+	test.Assert(t, err == nil && !gone)
+
+	instances = nil
+	gone, err = verify(info)
+	test.Assert(t, err == nil && gone)
+}
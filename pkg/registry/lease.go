@@ -0,0 +1,163 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registry
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bytedance/gopkg/lang/fastrand"
+)
+
+const (
+	// DefaultHeartbeatInterval is the pause between periodic re-registrations.
+	DefaultHeartbeatInterval = 10 * time.Second
+	// DefaultLeaseRetryInterval is the first retry backoff after a failed
+	// heartbeat; it doubles per consecutive failure.
+	DefaultLeaseRetryInterval = time.Second
+	// DefaultLeaseMaxRetryInterval caps the retry backoff.
+	DefaultLeaseMaxRetryInterval = 30 * time.Second
+)
+
+// LeaseState describes the registration state of a Lease.
+type LeaseState int
+
+const (
+	// LeaseUp means the last heartbeat succeeded.
+	LeaseUp LeaseState = iota
+	// LeaseRetrying means heartbeats are failing and being retried.
+	LeaseRetrying
+	// LeaseStopped means the lease was stopped and deregistered.
+	LeaseStopped
+)
+
+// LeaseOptions configures NewLease.
+type LeaseOptions struct {
+	// Interval is the pause between heartbeats, DefaultHeartbeatInterval
+	// when 0. Registries with a TTL should set it well below the TTL,
+	// a third is customary.
+	Interval time.Duration
+	// RetryInterval is the first backoff after a failed heartbeat,
+	// DefaultLeaseRetryInterval when 0; it doubles per consecutive failure
+	// up to MaxRetryInterval and is jittered by ±25% so restarting fleets
+	// don't retry in lockstep.
+	RetryInterval time.Duration
+	// MaxRetryInterval caps the backoff, DefaultLeaseMaxRetryInterval when 0.
+	MaxRetryInterval time.Duration
+	// OnStateChange is called on every state transition with the error that
+	// caused it (nil on recovery). Called from the heartbeat goroutine, so
+	// it must not block.
+	OnStateChange func(state LeaseState, err error)
+}
+
+// Lease keeps a registration alive by re-registering periodically, retrying
+// failures with jittered exponential backoff. It frees registry extensions
+// from hand-rolling keepalive loops: an extension whose backend expires
+// registrations wraps its Register in a Lease and only implements the two
+// Registry methods.
+type Lease struct {
+	registry Registry
+	info     *Info
+	opts     LeaseOptions
+	mu       sync.Mutex // guards state
+	state    LeaseState
+	stop     chan struct{}
+	stopped  sync.Once
+}
+
+// NewLease creates a Lease for the given registration. Start must be called
+// to register and begin the heartbeat.
+func NewLease(r Registry, info *Info, opts LeaseOptions) *Lease {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultHeartbeatInterval
+	}
+	if opts.RetryInterval <= 0 {
+		opts.RetryInterval = DefaultLeaseRetryInterval
+	}
+	if opts.MaxRetryInterval <= 0 {
+		opts.MaxRetryInterval = DefaultLeaseMaxRetryInterval
+	}
+	return &Lease{
+		registry: r,
+		info:     info,
+		opts:     opts,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start registers synchronously, so callers fail loudly at startup, then
+// launches the heartbeat goroutine.
+func (l *Lease) Start() error {
+	if err := l.registry.Register(l.info); err != nil {
+		return err
+	}
+	go l.run()
+	return nil
+}
+
+// Stop ends the heartbeat and deregisters. It is safe to call more than once;
+// the deregistration error of the first call is returned.
+func (l *Lease) Stop() (err error) {
+	l.stopped.Do(func() {
+		close(l.stop)
+		err = l.registry.Deregister(l.info)
+		l.transition(LeaseStopped, nil)
+	})
+	return
+}
+
+func (l *Lease) run() {
+	backoff := l.opts.RetryInterval
+	wait := l.opts.Interval
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-time.After(wait):
+		}
+		if err := l.registry.Register(l.info); err != nil {
+			l.transition(LeaseRetrying, err)
+			wait = jitter(backoff)
+			if backoff *= 2; backoff > l.opts.MaxRetryInterval {
+				backoff = l.opts.MaxRetryInterval
+			}
+		} else {
+			l.transition(LeaseUp, nil)
+			backoff = l.opts.RetryInterval
+			wait = l.opts.Interval
+		}
+	}
+}
+
+// transition fires the state callback on changes.
+func (l *Lease) transition(state LeaseState, err error) {
+	l.mu.Lock()
+	changed := l.state != state
+	l.state = state
+	l.mu.Unlock()
+	if !changed {
+		return
+	}
+	if l.opts.OnStateChange != nil {
+		l.opts.OnStateChange(state, err)
+	}
+}
+
+// jitter spreads d over [0.75d, 1.25d).
+func jitter(d time.Duration) time.Duration {
+	return d*3/4 + time.Duration(fastrand.Int63n(int64(d)/2))
+}
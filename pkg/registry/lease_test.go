@@ -0,0 +1,120 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registry
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/kitex/internal/test"
+)
+
+type leaseRegistry struct {
+	mu           sync.Mutex
+	registered   int
+	deregistered int
+	failing      bool
+}
+
+func (m *leaseRegistry) Register(info *Info) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.registered++
+	if m.failing {
+		return errors.New("registry down")
+	}
+	return nil
+}
+
+func (m *leaseRegistry) Deregister(info *Info) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deregistered++
+	return nil
+}
+
+func (m *leaseRegistry) count() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.registered
+}
+
+func (m *leaseRegistry) setFailing(failing bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failing = failing
+}
+
+func TestLeaseHeartbeat(t *testing.T) {
+	r := &leaseRegistry{}
+	l := NewLease(r, &Info{ServiceName: "svc"}, LeaseOptions{Interval: 5 * time.Millisecond})
+	test.Assert(t, l.Start() == nil)
+
+	for i := 0; i < 100 && r.count() < 3; i++ {
+		time.Sleep(5 * time.Millisecond)
+	}
+	test.Assert(t, r.count() >= 3, r.count())
+
+	test.Assert(t, l.Stop() == nil)
+	test.Assert(t, r.deregistered == 1)
+	// Stop is idempotent
+	test.Assert(t, l.Stop() == nil)
+	test.Assert(t, r.deregistered == 1)
+}
+
+func TestLeaseStartFails(t *testing.T) {
+	r := &leaseRegistry{failing: true}
+	l := NewLease(r, &Info{ServiceName: "svc"}, LeaseOptions{Interval: time.Millisecond})
+	test.Assert(t, l.Start() != nil)
+}
+
+func TestLeaseStateTransitions(t *testing.T) {
+	r := &leaseRegistry{}
+	states := make(chan LeaseState, 16)
+	l := NewLease(r, &Info{ServiceName: "svc"}, LeaseOptions{
+		Interval:      time.Millisecond,
+		RetryInterval: time.Millisecond,
+		OnStateChange: func(state LeaseState, err error) {
+			if state == LeaseRetrying {
+				test.Assert(t, err != nil)
+			}
+			states <- state
+		},
+	})
+	test.Assert(t, l.Start() == nil)
+	defer l.Stop()
+
+	next := func() LeaseState {
+		select {
+		case s := <-states:
+			return s
+		case <-time.After(time.Second):
+			t.Fatal("no state transition")
+			return -1
+		}
+	}
+
+	// heartbeats fail: one Retrying transition, not one per failure
+	r.setFailing(true)
+	test.Assert(t, next() == LeaseRetrying)
+
+	// the registry recovers: back to Up
+	r.setFailing(false)
+	test.Assert(t, next() == LeaseUp)
+}
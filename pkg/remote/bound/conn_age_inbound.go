@@ -0,0 +1,73 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bound
+
+import (
+	"context"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/kitex/pkg/remote"
+)
+
+// NewMaxConnectionAgeHandler creates an inbound handler which closes server
+// connections after maxAge plus a grace period, so load rebalances to new
+// instances after scale-out instead of sticking to long-lived connections.
+// A jitter of up to 10% is added to maxAge to avoid closing all connections
+// of a client at once. grace is the drain window between reaching the age
+// and force-closing the connection; in-flight requests should finish within it.
+func NewMaxConnectionAgeHandler(maxAge, grace time.Duration) remote.InboundHandler {
+	return &connAgeHandler{maxAge: maxAge, grace: grace}
+}
+
+type connAgeHandler struct {
+	maxAge time.Duration
+	grace  time.Duration
+
+	timers sync.Map // net.Conn -> *time.Timer
+}
+
+// OnActive implements the remote.InboundHandler interface.
+func (h *connAgeHandler) OnActive(ctx context.Context, conn net.Conn) (context.Context, error) {
+	age := h.maxAge + time.Duration(rand.Int63n(int64(h.maxAge)/10+1))
+	timer := time.AfterFunc(age+h.grace, func() {
+		h.timers.Delete(conn)
+		conn.Close()
+	})
+	h.timers.Store(conn, timer)
+	return ctx, nil
+}
+
+// OnRead implements the remote.InboundHandler interface.
+func (h *connAgeHandler) OnRead(ctx context.Context, conn net.Conn) (context.Context, error) {
+	return ctx, nil
+}
+
+// OnInactive implements the remote.InboundHandler interface.
+func (h *connAgeHandler) OnInactive(ctx context.Context, conn net.Conn) context.Context {
+	if timer, ok := h.timers.LoadAndDelete(conn); ok {
+		timer.(*time.Timer).Stop()
+	}
+	return ctx
+}
+
+// OnMessage implements the remote.InboundHandler interface.
+func (h *connAgeHandler) OnMessage(ctx context.Context, args, result remote.Message) (context.Context, error) {
+	return ctx, nil
+}
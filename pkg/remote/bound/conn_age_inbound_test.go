@@ -0,0 +1,62 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bound
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/kitex/internal/test"
+)
+
+func TestMaxConnectionAgeHandler(t *testing.T) {
+	h := NewMaxConnectionAgeHandler(20*time.Millisecond, 10*time.Millisecond)
+
+	server, client := net.Pipe()
+	defer client.Close()
+	_, err := h.OnActive(context.Background(), server)
+	test.Assert(t, err == nil, err)
+
+	// the connection is closed once maxAge(+jitter)+grace elapsed
+	deadline := time.Now().Add(time.Second)
+	closed := false
+	for time.Now().Before(deadline) {
+		server.SetReadDeadline(time.Now().Add(10 * time.Millisecond))
+		if _, err := server.Read(make([]byte, 1)); err != nil {
+			if ne, ok := err.(net.Error); !ok || !ne.Timeout() {
+				closed = true
+				break
+			}
+		}
+	}
+	test.Assert(t, closed)
+}
+
+func TestMaxConnectionAgeHandlerCancel(t *testing.T) {
+	h := NewMaxConnectionAgeHandler(10*time.Millisecond, 0).(*connAgeHandler)
+
+	server, client := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+	_, err := h.OnActive(context.Background(), server)
+	test.Assert(t, err == nil, err)
+	h.OnInactive(context.Background(), server)
+	_, ok := h.timers.Load(server)
+	test.Assert(t, !ok)
+}
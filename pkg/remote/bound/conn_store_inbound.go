@@ -0,0 +1,54 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bound
+
+import (
+	"context"
+	"net"
+
+	"github.com/cloudwego/kitex/pkg/remote"
+)
+
+// NewConnStoreInboundHandler creates an inbound handler which attaches a
+// remote.ConnStore to every accepted connection, retrievable in handlers and
+// middlewares via remote.GetConnStore(ctx). The store is released together
+// with the connection context when the connection closes.
+func NewConnStoreInboundHandler() remote.InboundHandler {
+	return &connStoreHandler{}
+}
+
+type connStoreHandler struct{}
+
+// OnActive implements the remote.InboundHandler interface.
+func (h *connStoreHandler) OnActive(ctx context.Context, conn net.Conn) (context.Context, error) {
+	return remote.WithConnStore(ctx), nil
+}
+
+// OnRead implements the remote.InboundHandler interface.
+func (h *connStoreHandler) OnRead(ctx context.Context, conn net.Conn) (context.Context, error) {
+	return ctx, nil
+}
+
+// OnInactive implements the remote.InboundHandler interface.
+func (h *connStoreHandler) OnInactive(ctx context.Context, conn net.Conn) context.Context {
+	return ctx
+}
+
+// OnMessage implements the remote.InboundHandler interface.
+func (h *connStoreHandler) OnMessage(ctx context.Context, args, result remote.Message) (context.Context, error) {
+	return ctx, nil
+}
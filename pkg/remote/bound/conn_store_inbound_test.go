@@ -0,0 +1,54 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bound
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/remote"
+)
+
+func TestConnStoreInboundHandler(t *testing.T) {
+	h := NewConnStoreInboundHandler()
+
+	// no store before OnActive
+	test.Assert(t, remote.GetConnStore(context.Background()) == nil)
+
+	ctx, err := h.OnActive(context.Background(), nil)
+	test.Assert(t, err == nil, err)
+	store := remote.GetConnStore(ctx)
+	test.Assert(t, store != nil)
+
+	// values survive across requests on the same connection context
+	store.Set("identity", "svc-a")
+	rctx, err := h.OnRead(ctx, nil)
+	test.Assert(t, err == nil, err)
+	v, ok := remote.GetConnStore(rctx).Get("identity")
+	test.Assert(t, ok)
+	test.Assert(t, v == "svc-a")
+
+	store.Delete("identity")
+	_, ok = store.Get("identity")
+	test.Assert(t, !ok)
+
+	// each connection gets its own store
+	ctx2, err := h.OnActive(context.Background(), nil)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, remote.GetConnStore(ctx2) != store)
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bound
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/shedding"
+)
+
+// NewServerSheddingHandler creates an inbound handler shedding low-priority
+// requests while the server is overloaded, see the shedding package.
+func NewServerSheddingHandler(shedder *shedding.Shedder) remote.InboundHandler {
+	return &serverSheddingHandler{shedder: shedder}
+}
+
+type serverSheddingHandler struct {
+	shedder *shedding.Shedder
+}
+
+type sheddingReadTimeKey struct{}
+
+// OnActive implements the remote.InboundHandler interface.
+func (h *serverSheddingHandler) OnActive(ctx context.Context, conn net.Conn) (context.Context, error) {
+	return ctx, nil
+}
+
+// OnRead implements the remote.InboundHandler interface.
+func (h *serverSheddingHandler) OnRead(ctx context.Context, conn net.Conn) (context.Context, error) {
+	return context.WithValue(ctx, sheddingReadTimeKey{}, time.Now()), nil
+}
+
+// OnInactive implements the remote.InboundHandler interface.
+func (h *serverSheddingHandler) OnInactive(ctx context.Context, conn net.Conn) context.Context {
+	return ctx
+}
+
+// OnMessage implements the remote.InboundHandler interface.
+// It feeds the shedder with the delay between reading a request off the
+// connection and decoding it, and rejects low-priority requests while the
+// server is overloaded.
+func (h *serverSheddingHandler) OnMessage(ctx context.Context, args, result remote.Message) (context.Context, error) {
+	if t0, ok := ctx.Value(sheddingReadTimeKey{}).(time.Time); ok {
+		h.shedder.Observe(time.Since(t0))
+	}
+	priority := shedding.DefaultPriority
+	if ti := args.TransInfo(); ti != nil {
+		priority = shedding.ParsePriority(ti.TransStrInfo()[shedding.PriorityHeaderKey])
+	}
+	if !h.shedder.Allow(priority) {
+		return ctx, shedding.ErrServerOverloaded
+	}
+	return ctx, nil
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package codec
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+
+	"github.com/cloudwego/netpoll"
+
+	"github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/remote/codec/perrors"
+	netpolltrans "github.com/cloudwego/kitex/pkg/remote/trans/netpoll"
+	"github.com/cloudwego/kitex/pkg/remote/transmeta"
+)
+
+// encodeMetaAndPayloadWithChunking splits payloads larger than ChunkSize into
+// [4-byte length][chunk bytes] frames after the TTHeader. The raw payload size
+// travels in a header key so the peer auto-detects chunking and can size its
+// reassembly buffer up front. Chunks are handed to the transport one by one,
+// so neither side needs the whole payload in one contiguous transport buffer.
+func (c *defaultCodec) encodeMetaAndPayloadWithChunking(ctx context.Context, message remote.Message, out remote.ByteBuffer, me remote.MetaEncoder) (err error) {
+	writer := netpoll.NewLinkBuffer()
+	payloadOut := netpolltrans.NewWriterByteBuffer(writer)
+	defer func() {
+		payloadOut.Release(err)
+	}()
+
+	// 1. encode payload into the side buffer
+	if err = me.EncodePayload(ctx, message, payloadOut); err != nil {
+		return err
+	}
+	if err = writer.Flush(); err != nil {
+		return err
+	}
+	rawLen := writer.Len()
+
+	strInfo := message.TransInfo().TransStrInfo()
+	if rawLen <= c.ChunkSize || strInfo == nil {
+		// small enough for a single piece, send as-is
+		payload := writer.Bytes()
+		message.SetPayloadLen(rawLen)
+		return finishTTHeaderPayload(ctx, message, out, payload)
+	}
+
+	// 2. chunked: announce the raw size and account for the chunk length fields
+	strInfo[transmeta.HeaderPayloadChunked] = strconv.Itoa(rawLen)
+	nChunks := (rawLen + c.ChunkSize - 1) / c.ChunkSize
+	message.SetPayloadLen(rawLen + nChunks*Size32)
+
+	// 3. encode header and return totalLenField if needed
+	totalLenField, err := ttHeaderCodec.encode(ctx, message, out)
+	if err != nil {
+		return err
+	}
+
+	// 4. write the payload as length-prefixed chunks, read from the side
+	// buffer piece by piece
+	for remaining := rawLen; remaining > 0; {
+		chunkLen := c.ChunkSize
+		if remaining < chunkLen {
+			chunkLen = remaining
+		}
+		chunk, nErr := writer.Next(chunkLen)
+		if nErr != nil {
+			return perrors.NewProtocolErrorWithErrMsg(nErr, fmt.Sprintf("chunked payload read failed: %s", nErr.Error()))
+		}
+		if err = WriteUint32(uint32(chunkLen), out); err != nil {
+			return err
+		}
+		if ncWriter, ok := out.(remote.NocopyWrite); ok {
+			err = ncWriter.WriteDirect(chunk, 0)
+		} else {
+			_, err = out.WriteBinary(chunk)
+		}
+		if err != nil {
+			return err
+		}
+		remaining -= chunkLen
+	}
+
+	// 5. fill totalLen field for header if needed
+	// FIXME: if the `out` buffer using copy to grow when the capacity is not enough, setting the pre-allocated `totalLenField` may not take effect.
+	if totalLenField == nil {
+		return perrors.NewProtocolErrorWithMsg("no buffer allocated for the header length field")
+	}
+	payloadLen := out.WrittenLen() - Size32
+	binary.BigEndian.PutUint32(totalLenField, uint32(payloadLen))
+	return nil
+}
+
+// finishTTHeaderPayload writes the header followed by an already encoded
+// payload and fills the total length field, the shared tail of the
+// payload-rewriting encode paths.
+func finishTTHeaderPayload(ctx context.Context, message remote.Message, out remote.ByteBuffer, payload []byte) (err error) {
+	totalLenField, err := ttHeaderCodec.encode(ctx, message, out)
+	if err != nil {
+		return err
+	}
+	if ncWriter, ok := out.(remote.NocopyWrite); ok {
+		err = ncWriter.WriteDirect(payload, 0)
+	} else {
+		_, err = out.WriteBinary(payload)
+	}
+	if totalLenField == nil {
+		return perrors.NewProtocolErrorWithMsg("no buffer allocated for the header length field")
+	}
+	payloadLen := out.WrittenLen() - Size32
+	binary.BigEndian.PutUint32(totalLenField, uint32(payloadLen))
+	return err
+}
+
+// reassembleChunkedPayload reads the chunk frames from in and returns a buffer
+// holding the reassembled payload, stashed on the message like the
+// decompressed payload so that DecodePayload reads from it. Each wire read is
+// at most one chunk, so the transport buffer never needs the whole payload in
+// one piece.
+func reassembleChunkedPayload(rawSize string, message remote.Message, in remote.ByteBuffer) (remote.ByteBuffer, error) {
+	rawLen, err := strconv.Atoi(rawSize)
+	if err != nil || rawLen < 0 {
+		return nil, perrors.NewProtocolErrorWithMsg(fmt.Sprintf("invalid chunked payload size %q", rawSize))
+	}
+	payload := make([]byte, 0, rawLen)
+	for remaining := message.PayloadLen(); remaining > 0; {
+		chunkLen, err := ReadUint32(in)
+		if err != nil {
+			return nil, perrors.NewProtocolErrorWithErrMsg(err, fmt.Sprintf("chunk length read failed: %s", err.Error()))
+		}
+		remaining -= Size32
+		if int(chunkLen) > remaining {
+			return nil, perrors.NewProtocolErrorWithMsg(fmt.Sprintf("chunk length %d exceeds the remaining payload %d", chunkLen, remaining))
+		}
+		chunk, err := in.Next(int(chunkLen))
+		if err != nil {
+			return nil, perrors.NewProtocolErrorWithErrMsg(err, fmt.Sprintf("chunk read failed: %s", err.Error()))
+		}
+		payload = append(payload, chunk...)
+		remaining -= int(chunkLen)
+	}
+	if len(payload) != rawLen {
+		return nil, perrors.NewProtocolErrorWithMsg(fmt.Sprintf("chunked payload size mismatch: got %d, announced %d", len(payload), rawLen))
+	}
+	message.SetPayloadLen(rawLen)
+	buf := remote.NewReaderBuffer(payload)
+	message.Tags()[replacedPayloadTagKey] = buf
+	return buf, nil
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package codec
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/cloudwego/netpoll"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/remote"
+	netpolltrans "github.com/cloudwego/kitex/pkg/remote/trans/netpoll"
+	"github.com/cloudwego/kitex/pkg/remote/transmeta"
+	"github.com/cloudwego/kitex/pkg/serviceinfo"
+	"github.com/cloudwego/kitex/transport"
+)
+
+func TestDefaultCodecWithChunking_Encode_Decode(t *testing.T) {
+	remote.PutPayloadCode(serviceinfo.Thrift, mpc)
+
+	chunkSize := 16 * 1024
+	dc := NewDefaultCodecWithConfig(CodecConfig{ChunkSize: chunkSize})
+	ctx := context.Background()
+	intKVInfo := prepareIntKVInfo()
+	payloadLen := 256 * 1024
+	sendMsg := initClientSendMsg(transport.TTHeaderFramed, payloadLen)
+	sendMsg.TransInfo().PutTransIntInfo(intKVInfo)
+	sendMsg.TransInfo().PutTransStrInfo(prepareStrKVInfo())
+
+	// encode with netpollBytebuffer
+	writer := netpoll.NewLinkBuffer()
+	npBuffer := netpolltrans.NewReaderWriterByteBuffer(writer)
+	err := dc.Encode(ctx, sendMsg, npBuffer)
+	test.Assert(t, err == nil, err)
+
+	// the raw payload size is announced in the header
+	rawSize, err := strconv.Atoi(sendMsg.TransInfo().TransStrInfo()[transmeta.HeaderPayloadChunked])
+	test.Assert(t, err == nil, err)
+	test.Assert(t, rawSize > payloadLen, rawSize)
+	// the wire carries one length field per chunk on top of the raw payload
+	nChunks := (rawSize + chunkSize - 1) / chunkSize
+	test.Assert(t, sendMsg.PayloadLen() == rawSize+nChunks*Size32)
+
+	// the receiving side auto-detects chunking with a plain default codec
+	recvMsg := initServerRecvMsg()
+	buf, err := getWrittenBytes(writer)
+	test.Assert(t, err == nil, err)
+	in := remote.NewReaderBuffer(buf)
+	err = NewDefaultCodec().Decode(ctx, recvMsg, in)
+	test.Assert(t, err == nil, err)
+	// checkPayload strips the framed size prefix from the reassembled payload
+	test.Assert(t, recvMsg.PayloadLen() == rawSize-Size32)
+	test.DeepEqual(t, recvMsg.TransInfo().TransIntInfo(), intKVInfo)
+	test.Assert(t, sendMsg.RPCInfo().Invocation().SeqID() == recvMsg.RPCInfo().Invocation().SeqID())
+}
+
+func TestDefaultCodecWithChunking_SmallPayload(t *testing.T) {
+	remote.PutPayloadCode(serviceinfo.Thrift, mpc)
+
+	// the payload fits into one chunk: sent as-is, no header key
+	dc := NewDefaultCodecWithConfig(CodecConfig{ChunkSize: 16 * 1024})
+	ctx := context.Background()
+	sendMsg := initClientSendMsg(transport.TTHeaderFramed, 64)
+
+	writer := netpoll.NewLinkBuffer()
+	npBuffer := netpolltrans.NewReaderWriterByteBuffer(writer)
+	err := dc.Encode(ctx, sendMsg, npBuffer)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, sendMsg.TransInfo().TransStrInfo()[transmeta.HeaderPayloadChunked] == "")
+
+	recvMsg := initServerRecvMsg()
+	buf, err := getWrittenBytes(writer)
+	test.Assert(t, err == nil, err)
+	in := remote.NewReaderBuffer(buf)
+	err = NewDefaultCodec().Decode(ctx, recvMsg, in)
+	test.Assert(t, err == nil, err)
+}
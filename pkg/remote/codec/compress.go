@@ -0,0 +1,127 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package codec
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/remote/codec/perrors"
+)
+
+// Payload compressor names. GZip is provided out of the box; Snappy and Zstd
+// are reserved names, register an implementation backed by the compression
+// library of your choice with RegisterPayloadCompressor before using them.
+const (
+	CompressorGZip   = "gzip"
+	CompressorSnappy = "snappy"
+	CompressorZstd   = "zstd"
+)
+
+// DefaultCompressMinSize is the minimum payload size worth compressing when
+// CodecConfig.CompressMinSize is not set. Compressing tiny payloads burns CPU
+// without saving bandwidth.
+const DefaultCompressMinSize = 1024
+
+// replacedPayloadTagKey stashes a replacement payload buffer, e.g. the
+// decompressed or reassembled payload, on the message between DecodeMeta and
+// DecodePayload.
+const replacedPayloadTagKey = "replaced_payload"
+
+// PayloadCompressor compresses and decompresses TTHeader payloads.
+// Unlike the gRPC encoding.Compressor it works on whole payloads rather than
+// streams, since a TTHeader payload is framed and read in one piece.
+type PayloadCompressor interface {
+	// Name is the compressor name carried in the negotiation header;
+	// the result must be static.
+	Name() string
+	Compress(data []byte) ([]byte, error)
+	Decompress(data []byte) ([]byte, error)
+}
+
+var payloadCompressors = map[string]PayloadCompressor{}
+
+// RegisterPayloadCompressor registers a compressor for use by payload
+// compression. It is not thread-safe and is intended to be called from init
+// functions, before any codec using the compressor is constructed.
+func RegisterPayloadCompressor(c PayloadCompressor) {
+	payloadCompressors[c.Name()] = c
+}
+
+func getPayloadCompressor(name string) (PayloadCompressor, error) {
+	if c, ok := payloadCompressors[name]; ok {
+		return c, nil
+	}
+	return nil, perrors.NewProtocolErrorWithMsg(fmt.Sprintf("payload compressor %q not registered", name))
+}
+
+func init() {
+	RegisterPayloadCompressor(gzipCompressor{})
+}
+
+// gzipCompressor is the built-in PayloadCompressor based on compress/gzip.
+type gzipCompressor struct{}
+
+func (gzipCompressor) Name() string {
+	return CompressorGZip
+}
+
+func (gzipCompressor) Compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gzipCompressor) Decompress(data []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
+
+// decompressPayload reads the compressed payload region from in and returns a
+// buffer holding the decompressed payload. The buffer is stashed on the
+// message so that DecodePayload reads from it instead of the wire.
+func decompressPayload(name string, message remote.Message, in remote.ByteBuffer) (remote.ByteBuffer, error) {
+	compressor, err := getPayloadCompressor(name)
+	if err != nil {
+		return nil, err
+	}
+	compressed, err := in.Next(message.PayloadLen())
+	if err != nil {
+		return nil, perrors.NewProtocolErrorWithErrMsg(err, fmt.Sprintf("compressed payload read failed: %s", err.Error()))
+	}
+	payload, err := compressor.Decompress(compressed)
+	if err != nil {
+		return nil, perrors.NewProtocolErrorWithErrMsg(err, fmt.Sprintf("payload decompress failed: %s", err.Error()))
+	}
+	message.SetPayloadLen(len(payload))
+	buf := remote.NewReaderBuffer(payload)
+	message.Tags()[replacedPayloadTagKey] = buf
+	return buf, nil
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package codec
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/cloudwego/netpoll"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/remote"
+	netpolltrans "github.com/cloudwego/kitex/pkg/remote/trans/netpoll"
+	"github.com/cloudwego/kitex/pkg/remote/transmeta"
+	"github.com/cloudwego/kitex/pkg/serviceinfo"
+	"github.com/cloudwego/kitex/transport"
+)
+
+func TestGzipPayloadCompressor(t *testing.T) {
+	c, err := getPayloadCompressor(CompressorGZip)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, c.Name() == CompressorGZip)
+
+	payload := preparePayload()
+	compressed, err := c.Compress(payload)
+	test.Assert(t, err == nil, err)
+	raw, err := c.Decompress(compressed)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, bytes.Equal(raw, payload))
+
+	// snappy and zstd are reserved names without built-in implementations
+	_, err = getPayloadCompressor(CompressorSnappy)
+	test.Assert(t, err != nil)
+	_, err = getPayloadCompressor(CompressorZstd)
+	test.Assert(t, err != nil)
+}
+
+func TestDefaultCodecWithCompression_Encode_Decode(t *testing.T) {
+	remote.PutPayloadCode(serviceinfo.Thrift, mpc)
+
+	dc := NewDefaultCodecWithConfig(CodecConfig{PayloadCompressor: CompressorGZip})
+	ctx := context.Background()
+	intKVInfo := prepareIntKVInfo()
+	strKVInfo := prepareStrKVInfo()
+	payloadLen := 32 * 1024
+	sendMsg := initClientSendMsg(transport.TTHeaderFramed, payloadLen)
+	sendMsg.TransInfo().PutTransIntInfo(intKVInfo)
+	sendMsg.TransInfo().PutTransStrInfo(strKVInfo)
+
+	// encode with netpollBytebuffer
+	writer := netpoll.NewLinkBuffer()
+	npBuffer := netpolltrans.NewReaderWriterByteBuffer(writer)
+	err := dc.Encode(ctx, sendMsg, npBuffer)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, sendMsg.TransInfo().TransStrInfo()[transmeta.HeaderPayloadCompress] == CompressorGZip)
+
+	// the zero-filled payload shrinks on the wire
+	buf, err := getWrittenBytes(writer)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, len(buf) < payloadLen, len(buf))
+
+	// the receiving side auto-detects compression with a plain default codec
+	recvMsg := initServerRecvMsg()
+	in := remote.NewReaderBuffer(buf)
+	err = NewDefaultCodec().Decode(ctx, recvMsg, in)
+	test.Assert(t, err == nil, err)
+	intKVInfoRecv := recvMsg.TransInfo().TransIntInfo()
+	strKVInfoRecv := recvMsg.TransInfo().TransStrInfo()
+	test.DeepEqual(t, intKVInfoRecv, intKVInfo)
+	test.Assert(t, strKVInfoRecv[transmeta.HeaderPayloadCompress] == CompressorGZip)
+	test.Assert(t, sendMsg.RPCInfo().Invocation().SeqID() == recvMsg.RPCInfo().Invocation().SeqID())
+}
+
+func TestDefaultCodecWithCompression_MinSize(t *testing.T) {
+	remote.PutPayloadCode(serviceinfo.Thrift, mpc)
+
+	// the payload stays below DefaultCompressMinSize: sent as-is, no header key
+	dc := NewDefaultCodecWithConfig(CodecConfig{PayloadCompressor: CompressorGZip})
+	ctx := context.Background()
+	sendMsg := initClientSendMsg(transport.TTHeaderFramed, 64)
+
+	writer := netpoll.NewLinkBuffer()
+	npBuffer := netpolltrans.NewReaderWriterByteBuffer(writer)
+	err := dc.Encode(ctx, sendMsg, npBuffer)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, sendMsg.TransInfo().TransStrInfo()[transmeta.HeaderPayloadCompress] == "")
+
+	recvMsg := initServerRecvMsg()
+	buf, err := getWrittenBytes(writer)
+	test.Assert(t, err == nil, err)
+	in := remote.NewReaderBuffer(buf)
+	err = NewDefaultCodec().Decode(ctx, recvMsg, in)
+	test.Assert(t, err == nil, err)
+}
+
+func TestDefaultCodecWithCompression_UnknownCompressor(t *testing.T) {
+	remote.PutPayloadCode(serviceinfo.Thrift, mpc)
+
+	dc := NewDefaultCodecWithConfig(CodecConfig{PayloadCompressor: CompressorSnappy})
+	sendMsg := initClientSendMsg(transport.TTHeaderFramed, 32*1024)
+	npBuffer := netpolltrans.NewReaderWriterByteBuffer(netpoll.NewLinkBuffer())
+	err := dc.Encode(context.Background(), sendMsg, npBuffer)
+	test.Assert(t, err != nil, err)
+}
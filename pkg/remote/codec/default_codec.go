@@ -19,6 +19,7 @@ package codec
 import (
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"sync/atomic"
 
@@ -26,9 +27,11 @@ import (
 	"github.com/cloudwego/netpoll"
 
 	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/cloudwego/kitex/pkg/klog"
 	"github.com/cloudwego/kitex/pkg/remote"
 	"github.com/cloudwego/kitex/pkg/remote/codec/perrors"
 	netpolltrans "github.com/cloudwego/kitex/pkg/remote/trans/netpoll"
+	"github.com/cloudwego/kitex/pkg/remote/transmeta"
 	"github.com/cloudwego/kitex/pkg/retry"
 	"github.com/cloudwego/kitex/pkg/rpcinfo"
 	"github.com/cloudwego/kitex/pkg/serviceinfo"
@@ -46,6 +49,8 @@ const (
 	ThriftV1Magic = 0x80010000
 	// ProtobufV1Magic is the magic code for kitex protobuf
 	ProtobufV1Magic = 0x90010000
+	// JSONV1Magic is the magic code for kitex JSON
+	JSONV1Magic = 0xa0010000
 
 	// MagicMask is bit mask for checking version.
 	MagicMask = 0xffff0000
@@ -98,6 +103,30 @@ type CodecConfig struct {
 	// It prepares a value based on payload in sender-side and validates the value in receiver-side.
 	// It can only be used when ttheader is enabled.
 	PayloadValidator PayloadValidator
+
+	// PayloadCompressor is the name of a registered PayloadCompressor used to
+	// compress payloads larger than CompressMinSize bytes before sending.
+	// The name travels in a TTHeader key so the peer auto-detects compression,
+	// hence it can only be used when ttheader is enabled.
+	// It is ignored when PayloadValidator (or CRC32Check) is set.
+	PayloadCompressor string
+
+	// CompressMinSize is the minimum payload size in bytes worth compressing,
+	// DefaultCompressMinSize when 0. Smaller payloads are sent as-is.
+	CompressMinSize int
+
+	// ValidationPolicy decides whether a message failing payload checksum
+	// validation is rejected (default) or only logged. It applies to both
+	// CRC32Check and a customized PayloadValidator.
+	ValidationPolicy ValidationPolicy
+
+	// ChunkSize splits payloads larger than this many bytes into
+	// length-prefixed chunk frames, so a very large payload does not need one
+	// contiguous region in the transport buffers on either side. The raw size
+	// travels in a TTHeader key so the peer auto-detects chunking, hence it
+	// can only be used when ttheader is enabled. 0 disables chunking; it is
+	// ignored when PayloadValidator (or CRC32Check) or PayloadCompressor is set.
+	ChunkSize int
 }
 
 type defaultCodec struct {
@@ -157,6 +186,12 @@ func (c *defaultCodec) EncodeMetaAndPayload(ctx context.Context, message remote.
 	if c.PayloadValidator != nil && tp&transport.TTHeader == transport.TTHeader {
 		return c.encodeMetaAndPayloadWithPayloadValidator(ctx, message, out, me)
 	}
+	if c.PayloadCompressor != "" && tp&transport.TTHeader == transport.TTHeader {
+		return c.encodeMetaAndPayloadWithCompression(ctx, message, out, me)
+	}
+	if c.ChunkSize > 0 && tp&transport.TTHeader == transport.TTHeader {
+		return c.encodeMetaAndPayloadWithChunking(ctx, message, out, me)
+	}
 
 	var err error
 	var totalLenField []byte
@@ -211,7 +246,28 @@ func (c *defaultCodec) DecodeMeta(ctx context.Context, message remote.Message, i
 		}
 		if c.PayloadValidator != nil {
 			if pErr := payloadChecksumValidate(ctx, c.PayloadValidator, in, message); pErr != nil {
-				return pErr
+				if c.ValidationPolicy != ValidationPolicyLog || !errors.Is(pErr, kerrors.ErrPayloadValidation) {
+					return pErr
+				}
+				klog.CtxWarnf(ctx, "KITEX: payload checksum validation failed, message kept due to log-only policy, error=%s", pErr.Error())
+			}
+		}
+		if name := message.TransInfo().TransStrInfo()[transmeta.HeaderPayloadCompress]; name != "" {
+			// the sender compressed the payload: decompress before protocol sniffing
+			if in, err = decompressPayload(name, message, in); err != nil {
+				return err
+			}
+			if flagBuf, err = in.Peek(2 * Size32); err != nil {
+				return perrors.NewProtocolErrorWithErrMsg(err, fmt.Sprintf("decompressed payload read first 8 byte failed: %s", err.Error()))
+			}
+		}
+		if rawSize := message.TransInfo().TransStrInfo()[transmeta.HeaderPayloadChunked]; rawSize != "" {
+			// the sender chunked the payload: reassemble before protocol sniffing
+			if in, err = reassembleChunkedPayload(rawSize, message, in); err != nil {
+				return err
+			}
+			if flagBuf, err = in.Peek(2 * Size32); err != nil {
+				return perrors.NewProtocolErrorWithErrMsg(err, fmt.Sprintf("chunked payload read first 8 byte failed: %s", err.Error()))
 			}
 		}
 	} else if isMeshHeader(flagBuf) {
@@ -237,6 +293,11 @@ func (c *defaultCodec) DecodePayload(ctx context.Context, message remote.Message
 		}
 	}()
 
+	if b, ok := message.Tags()[replacedPayloadTagKey].(remote.ByteBuffer); ok {
+		// the payload was decompressed during DecodeMeta, read it from there
+		delete(message.Tags(), replacedPayloadTagKey)
+		in = b
+	}
 	hasRead := in.ReadLen()
 	pCodec, err := remote.GetPayloadCodec(message)
 	if err != nil {
@@ -316,6 +377,72 @@ func (c *defaultCodec) encodeMetaAndPayloadWithPayloadValidator(ctx context.Cont
 	return err
 }
 
+// encodeMetaAndPayloadWithCompression encodes the payload into a side buffer
+// first so that it can be compressed before the TTHeader, which carries the
+// compressor name for the peer, is written.
+func (c *defaultCodec) encodeMetaAndPayloadWithCompression(ctx context.Context, message remote.Message, out remote.ByteBuffer, me remote.MetaEncoder) (err error) {
+	compressor, err := getPayloadCompressor(c.PayloadCompressor)
+	if err != nil {
+		return err
+	}
+	writer := netpoll.NewLinkBuffer()
+	payloadOut := netpolltrans.NewWriterByteBuffer(writer)
+	defer func() {
+		payloadOut.Release(err)
+	}()
+
+	// 1. encode payload
+	if err = me.EncodePayload(ctx, message, payloadOut); err != nil {
+		return err
+	}
+	payload, err := getWrittenBytes(writer)
+	if err != nil {
+		return err
+	}
+
+	// 2. compress when the payload is large enough to be worth it and the
+	// result actually got smaller
+	minSize := c.CompressMinSize
+	if minSize <= 0 {
+		minSize = DefaultCompressMinSize
+	}
+	strInfo := message.TransInfo().TransStrInfo()
+	if strInfo != nil && len(payload) >= minSize {
+		compressed, cErr := compressor.Compress(payload)
+		if cErr != nil {
+			return perrors.NewProtocolErrorWithErrMsg(cErr, fmt.Sprintf("payload compress failed: %s", cErr.Error()))
+		}
+		if len(compressed) < len(payload) {
+			payload = compressed
+			strInfo[transmeta.HeaderPayloadCompress] = compressor.Name()
+		}
+	}
+	// set payload length before encode TTHeader
+	message.SetPayloadLen(len(payload))
+
+	// 3. encode header and return totalLenField if needed
+	totalLenField, err := ttHeaderCodec.encode(ctx, message, out)
+	if err != nil {
+		return err
+	}
+
+	// 4. write payload to the buffer after TTHeader
+	if ncWriter, ok := out.(remote.NocopyWrite); ok {
+		err = ncWriter.WriteDirect(payload, 0)
+	} else {
+		_, err = out.WriteBinary(payload)
+	}
+
+	// 5. fill totalLen field for header if needed
+	// FIXME: if the `out` buffer using copy to grow when the capacity is not enough, setting the pre-allocated `totalLenField` may not take effect.
+	if totalLenField == nil {
+		return perrors.NewProtocolErrorWithMsg("no buffer allocated for the header length field")
+	}
+	payloadLen := out.WrittenLen() - Size32
+	binary.BigEndian.PutUint32(totalLenField, uint32(payloadLen))
+	return err
+}
+
 // Select to use thrift or protobuf according to the protocol.
 func (c *defaultCodec) encodePayload(ctx context.Context, message remote.Message, out remote.ByteBuffer) error {
 	pCodec, err := remote.GetPayloadCodec(message)
@@ -359,6 +486,10 @@ func isProtobufKitex(flagBuf []byte) bool {
 	return binary.BigEndian.Uint32(flagBuf[Size32:])&MagicMask == ProtobufV1Magic
 }
 
+func isJSONKitex(flagBuf []byte) bool {
+	return binary.BigEndian.Uint32(flagBuf[Size32:])&MagicMask == JSONV1Magic
+}
+
 /**
  * +-------------------+
  * |       2Byte       |
@@ -432,6 +563,18 @@ func checkPayload(flagBuf []byte, message remote.Message, in remote.ByteBuffer,
 		if err := in.Skip(Size32); err != nil {
 			return err
 		}
+	} else if isJSONKitex(flagBuf) {
+		codecType = serviceinfo.JSON
+		if isTTHeader {
+			transProto = transport.TTHeaderFramed
+		} else {
+			transProto = transport.Framed
+		}
+		payloadLen := binary.BigEndian.Uint32(flagBuf[:Size32])
+		message.SetPayloadLen(int(payloadLen))
+		if err := in.Skip(Size32); err != nil {
+			return err
+		}
 	} else {
 		first4Bytes := binary.BigEndian.Uint32(flagBuf[:Size32])
 		second4Bytes := binary.BigEndian.Uint32(flagBuf[Size32:])
@@ -314,6 +314,41 @@ func TestDefaultCodecWithCRC32_Encode_Decode(t *testing.T) {
 	test.Assert(t, err != nil, err)
 }
 
+func TestDefaultCodecWithCRC32LogOnlyPolicy(t *testing.T) {
+	remote.PutPayloadCode(serviceinfo.Thrift, mpc)
+
+	dc := NewDefaultCodecWithConfig(CodecConfig{CRC32Check: true, ValidationPolicy: ValidationPolicyLog})
+	ctx := context.Background()
+	payloadLen := 32 * 1024
+	sendMsg := initClientSendMsg(transport.TTHeaderFramed, payloadLen)
+
+	writer := netpoll.NewLinkBuffer()
+	npBuffer := netpolltrans.NewReaderWriterByteBuffer(writer)
+	err := dc.Encode(ctx, sendMsg, npBuffer)
+	test.Assert(t, err == nil, err)
+	buf, err := getWrittenBytes(writer)
+	test.Assert(t, err == nil, err)
+
+	// corrupt the tail of the payload: the checksum mismatch is only logged
+	bufLen := len(buf)
+	modifiedBuf := make([]byte, bufLen)
+	copy(modifiedBuf, buf)
+	for i := bufLen - 1; i > bufLen-10; i-- {
+		modifiedBuf[i] = 123
+	}
+	recvMsg := initServerRecvMsg()
+	in := remote.NewReaderBuffer(modifiedBuf)
+	err = dc.Decode(ctx, recvMsg, in)
+	test.Assert(t, err == nil, err)
+
+	// the reject policy still drops the same message
+	strict := NewDefaultCodecWithConfig(CodecConfig{CRC32Check: true})
+	recvMsg = initServerRecvMsg()
+	in = remote.NewReaderBuffer(modifiedBuf)
+	err = strict.Decode(ctx, recvMsg, in)
+	test.Assert(t, err != nil, err)
+}
+
 func TestDefaultCodecWithCustomizedValidator(t *testing.T) {
 	remote.PutPayloadCode(serviceinfo.Thrift, mpc)
 
@@ -0,0 +1,173 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package codec
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/remote/codec/perrors"
+	"github.com/cloudwego/kitex/pkg/serviceinfo"
+)
+
+/**
+ *  Kitex JSON Protocol, the meta info mirrors the Kitex Protobuf layout
+ *  |----------Len--------|--------------------------------MetaInfo--------------------------------|
+ *  |---------4Byte-------|----2Byte----|----2Byte----|---------String-------|---------4Byte-------|
+ *	+----------------------------------------------------------------------------------------------+
+ *	|      PayloadLen     |    Magic    |   MsgType   |      MethodName      |        SeqID        |
+ *	+----------------------------------------------------------------------------------------------+
+ *	|  									 												           |
+ *	|                            JSON  Argument/Result/Error   			                       |
+ *	|   							 													           |
+ *	+----------------------------------------------------------------------------------------------+
+ */
+
+const jsonMetaInfoFixLen = 8
+
+// JSON is the JSON payload codec of the native Kitex protocol. It frames
+// messages like Kitex Protobuf but encodes the argument with encoding/json,
+// so debugging proxies and scripting clients can talk to Kitex services
+// without Thrift or Protobuf tooling. It must be paired with a framed
+// transport, e.g. client.WithPayloadCodec(codec.JSON) together with
+// client.WithTransportProtocol(transport.TTHeaderFramed). Servers decode it
+// out of the box.
+var JSON remote.PayloadCodec = jsonCodec{}
+
+// IsJSONCodec checks if the codec is the JSON payload codec.
+func IsJSONCodec(c remote.PayloadCodec) bool {
+	_, ok := c.(jsonCodec)
+	return ok
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(ctx context.Context, message remote.Message, out remote.ByteBuffer) error {
+	// 1. prepare info
+	methodName := message.RPCInfo().Invocation().MethodName()
+	if methodName == "" {
+		return errors.New("empty methodName in JSON Marshal")
+	}
+	if err := NewDataIfNeeded(methodName, message); err != nil {
+		return err
+	}
+	data := message.Data()
+	if message.MessageType() == remote.Exception {
+		transErr, isTransErr := data.(*remote.TransError)
+		if isTransErr {
+			data = &jsonError{TypeID: transErr.TypeID(), Message: transErr.Error()}
+		} else if err, isError := data.(error); isError {
+			data = &jsonError{TypeID: remote.InternalError, Message: err.Error()}
+		} else {
+			return errors.New("exception relay need error type data")
+		}
+	}
+
+	// 2. encode the message body
+	body, err := json.Marshal(data)
+	if err != nil {
+		return perrors.NewProtocolErrorWithErrMsg(err, fmt.Sprintf("JSON marshal message failed: %s", err.Error()))
+	}
+
+	// 3. encode metainfo
+	// 3.1 magic && msgType
+	if err := WriteUint32(JSONV1Magic+uint32(message.MessageType()), out); err != nil {
+		return perrors.NewProtocolErrorWithMsg(fmt.Sprintf("JSON marshal, write meta info failed: %s", err.Error()))
+	}
+	// 3.2 methodName
+	if _, err := WriteString(methodName, out); err != nil {
+		return perrors.NewProtocolErrorWithMsg(fmt.Sprintf("JSON marshal, write method name failed: %s", err.Error()))
+	}
+	// 3.3 seqID
+	if err := WriteUint32(uint32(message.RPCInfo().Invocation().SeqID()), out); err != nil {
+		return perrors.NewProtocolErrorWithMsg(fmt.Sprintf("JSON marshal, write seqID failed: %s", err.Error()))
+	}
+
+	// 4. write the message body
+	if _, err := out.WriteBinary(body); err != nil {
+		return perrors.NewProtocolErrorWithMsg(fmt.Sprintf("JSON marshal, write message buffer failed: %s", err.Error()))
+	}
+	return nil
+}
+
+func (jsonCodec) Unmarshal(ctx context.Context, message remote.Message, in remote.ByteBuffer) error {
+	payloadLen := message.PayloadLen()
+	magicAndMsgType, err := ReadUint32(in)
+	if err != nil {
+		return err
+	}
+	if magicAndMsgType&MagicMask != JSONV1Magic {
+		return perrors.NewProtocolErrorWithType(perrors.BadVersion, "Bad version in JSON Unmarshal")
+	}
+	msgType := magicAndMsgType & FrontMask
+	if err := UpdateMsgType(msgType, message); err != nil {
+		return err
+	}
+
+	methodName, methodFieldLen, err := ReadString(in)
+	if err != nil {
+		return perrors.NewProtocolErrorWithErrMsg(err, fmt.Sprintf("JSON unmarshal, read method name failed: %s", err.Error()))
+	}
+	if err = SetOrCheckMethodName(methodName, message); err != nil && msgType != uint32(remote.Exception) {
+		return err
+	}
+	seqID, err := ReadUint32(in)
+	if err != nil {
+		return perrors.NewProtocolErrorWithErrMsg(err, fmt.Sprintf("JSON unmarshal, read seqID failed: %s", err.Error()))
+	}
+	if err = SetOrCheckSeqID(int32(seqID), message); err != nil && msgType != uint32(remote.Exception) {
+		return err
+	}
+	body, err := in.Next(payloadLen - jsonMetaInfoFixLen - methodFieldLen)
+	if err != nil {
+		return perrors.NewProtocolErrorWithErrMsg(err, fmt.Sprintf("JSON unmarshal, read message buffer failed: %s", err.Error()))
+	}
+	// exception message
+	if message.MessageType() == remote.Exception {
+		var exception jsonError
+		if err := json.Unmarshal(body, &exception); err != nil {
+			return perrors.NewProtocolErrorWithMsg(fmt.Sprintf("JSON unmarshal Exception failed: %s", err.Error()))
+		}
+		return remote.NewTransError(exception.TypeID, &exception)
+	}
+
+	if err = NewDataIfNeeded(methodName, message); err != nil {
+		return err
+	}
+	if err = json.Unmarshal(body, message.Data()); err != nil {
+		return remote.NewTransErrorWithMsg(remote.ProtocolError, err.Error())
+	}
+	return nil
+}
+
+func (jsonCodec) Name() string {
+	return serviceinfo.JSON.String()
+}
+
+// jsonError carries an exception reply, the JSON counterpart of the protobuf
+// codec's error message.
+type jsonError struct {
+	TypeID  int32  `json:"type_id"`
+	Message string `json:"message"`
+}
+
+func (e *jsonError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package codec
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/cloudwego/netpoll"
+
+	"github.com/cloudwego/kitex/internal/mocks"
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/remote"
+	netpolltrans "github.com/cloudwego/kitex/pkg/remote/trans/netpoll"
+	"github.com/cloudwego/kitex/pkg/serviceinfo"
+	"github.com/cloudwego/kitex/transport"
+)
+
+func TestJSONCodec_Encode_Decode(t *testing.T) {
+	remote.PutPayloadCode(serviceinfo.JSON, JSON)
+	test.Assert(t, IsJSONCodec(JSON))
+
+	ctx := context.Background()
+	args := mocks.NewMockArgs()
+	err := json.Unmarshal([]byte(`{"req":{"Name":"kitex"}}`), args)
+	test.Assert(t, err == nil, err)
+	sendMsg := remote.NewMessage(args, mocks.ServiceInfo(), mockCliRPCInfo, remote.Call, remote.Client)
+	sendMsg.SetProtocolInfo(remote.NewProtocolInfo(transport.TTHeaderFramed, serviceinfo.JSON))
+
+	// encode with netpollBytebuffer
+	writer := netpoll.NewLinkBuffer()
+	npBuffer := netpolltrans.NewReaderWriterByteBuffer(writer)
+	err = NewDefaultCodec().Encode(ctx, sendMsg, npBuffer)
+	test.Assert(t, err == nil, err)
+
+	// the receiving side auto-detects the JSON payload by its magic
+	recvMsg := initServerRecvMsg()
+	buf, err := getWrittenBytes(writer)
+	test.Assert(t, err == nil, err)
+	in := remote.NewReaderBuffer(buf)
+	err = NewDefaultCodec().Decode(ctx, recvMsg, in)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, recvMsg.ProtocolInfo().CodecType == serviceinfo.JSON)
+	test.Assert(t, recvMsg.RPCInfo().Invocation().MethodName() == mockMethod)
+	test.Assert(t, sendMsg.RPCInfo().Invocation().SeqID() == recvMsg.RPCInfo().Invocation().SeqID())
+	test.DeepEqual(t, recvMsg.Data(), args)
+}
+
+func TestJSONCodec_Exception(t *testing.T) {
+	remote.PutPayloadCode(serviceinfo.JSON, JSON)
+
+	ctx := context.Background()
+	transErr := remote.NewTransErrorWithMsg(remote.InternalError, "mock error")
+	sendMsg := remote.NewMessage(transErr, mocks.ServiceInfo(), mockCliRPCInfo, remote.Exception, remote.Server)
+	sendMsg.SetProtocolInfo(remote.NewProtocolInfo(transport.TTHeaderFramed, serviceinfo.JSON))
+
+	writer := netpoll.NewLinkBuffer()
+	npBuffer := netpolltrans.NewReaderWriterByteBuffer(writer)
+	err := NewDefaultCodec().Encode(ctx, sendMsg, npBuffer)
+	test.Assert(t, err == nil, err)
+
+	recvMsg := initClientRecvMsg()
+	buf, err := getWrittenBytes(writer)
+	test.Assert(t, err == nil, err)
+	in := remote.NewReaderBuffer(buf)
+	err = NewDefaultCodec().Decode(ctx, recvMsg, in)
+	test.Assert(t, err != nil)
+	gotErr, ok := err.(*remote.TransError)
+	test.Assert(t, ok, err)
+	test.Assert(t, gotErr.TypeID() == remote.InternalError, gotErr.TypeID())
+	test.Assert(t, gotErr.Error() == "mock error", gotErr.Error())
+}
@@ -19,6 +19,8 @@ package thrift
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/cloudwego/gopkg/bufiox"
@@ -238,6 +240,42 @@ func BenchmarkNormalParallel(b *testing.B) {
 	}
 }
 
+// BenchmarkFastMarshalLargePayload measures the fast-codec write path on large
+// struct payloads. With NetpollBuffer the codec mallocs regions of the
+// connection's linked buffer and serializes into them directly, while
+// BytesBuffer has no nocopy support and pays for an extra copy of the field data.
+func BenchmarkFastMarshalLargePayload(b *testing.B) {
+	largeVal := strings.Repeat("x", 16*1024)
+	strMap := make(map[string]string, 16)
+	for i := 0; i < 16; i++ {
+		strMap[fmt.Sprintf("key%d", i)] = largeVal
+	}
+	strList := make([]string, 64)
+	for i := range strList {
+		strList[i] = largeVal
+	}
+	var _args mt.MockTestArgs
+	_args.Req = &mt.MockReq{Msg: "MockReq", StrMap: strMap, StrList: strList}
+	sendMsg := newMsg(&_args)
+	sendMsg.SetProtocolInfo(remote.NewProtocolInfo(transport.TTHeader, svcInfo.PayloadCodec))
+
+	for _, tb := range transportBuffers {
+		b.Run(tb.Name, func(b *testing.B) {
+			ctx := context.Background()
+			b.SetBytes(int64(_args.BLength()))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				bw, br := tb.NewBuffer()
+				bb := remote.NewByteBufferFromBufiox(bw, br)
+				err := payloadCodec.Marshal(ctx, sendMsg, bb)
+				test.Assert(b, err == nil, err)
+				bw.Flush()
+			}
+		})
+	}
+}
+
 func TestException(t *testing.T) {
 	for _, tb := range transportBuffers {
 		t.Run(tb.Name, func(t *testing.T) {
@@ -38,6 +38,19 @@ const (
 	maxPayloadChecksumLength = 4096 // maximum 4k
 )
 
+// ValidationPolicy decides what happens to a message whose payload checksum
+// validation fails on receive.
+type ValidationPolicy int
+
+const (
+	// ValidationPolicyReject rejects the message with ErrPayloadValidation, the default.
+	ValidationPolicyReject ValidationPolicy = iota
+	// ValidationPolicyLog only logs a warning and keeps processing the message.
+	// It is useful for observing checksum mismatches, e.g. when rolling the
+	// feature out, before enforcing them.
+	ValidationPolicyLog
+)
+
 // PayloadValidator is the interface for validating the payload of RPC requests, which allows customized Checksum function.
 type PayloadValidator interface {
 	// Key returns a key for your validator, which will be the key in ttheader
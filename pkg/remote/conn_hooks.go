@@ -0,0 +1,41 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package remote
+
+import (
+	"context"
+	"net"
+
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+// ConnHook is a callback observing a connection lifecycle event.
+// ri may be nil for events which are not bound to a request, e.g. a
+// connection closed while idling in the pool.
+type ConnHook func(ctx context.Context, ri rpcinfo.RPCInfo, conn net.Conn)
+
+// ConnLifecycleHooks carries callbacks observing the lifecycle of pooled
+// connections. Any of the hooks may be nil.
+type ConnLifecycleHooks struct {
+	// OnDial is invoked after a new connection is established.
+	OnDial ConnHook
+	// OnReuse is invoked when a pooled connection is checked out for a request.
+	OnReuse ConnHook
+	// OnClose is invoked when the pool gives up a connection, i.e. it is
+	// discarded rather than put back.
+	OnClose ConnHook
+}
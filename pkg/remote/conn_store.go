@@ -0,0 +1,61 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package remote
+
+import (
+	"context"
+	"sync"
+)
+
+// ConnStore is a per-connection key-value store. It lives as long as the
+// connection it is attached to, so stateful protocols or auth handshakes can
+// cache data (e.g. a verified identity) across the requests served over the
+// same connection. It is safe for concurrent use, which matters on the mux
+// transport where requests of one connection are handled in parallel.
+type ConnStore struct {
+	kvs sync.Map
+}
+
+// Get returns the value stored under key and whether it is present.
+func (s *ConnStore) Get(key interface{}) (value interface{}, ok bool) {
+	return s.kvs.Load(key)
+}
+
+// Set stores value under key, replacing any existing value.
+func (s *ConnStore) Set(key, value interface{}) {
+	s.kvs.Store(key, value)
+}
+
+// Delete removes the value stored under key.
+func (s *ConnStore) Delete(key interface{}) {
+	s.kvs.Delete(key)
+}
+
+type connStoreCtxKey struct{}
+
+// WithConnStore returns ctx with a fresh ConnStore attached. It is meant to
+// be called once per connection, e.g. from an InboundHandler's OnActive.
+func WithConnStore(ctx context.Context) context.Context {
+	return context.WithValue(ctx, connStoreCtxKey{}, &ConnStore{})
+}
+
+// GetConnStore retrieves the ConnStore of the connection serving the current
+// request from ctx, or nil if none is attached (see server.WithConnStore).
+func GetConnStore(ctx context.Context) *ConnStore {
+	s, _ := ctx.Value(connStoreCtxKey{}).(*ConnStore)
+	return s
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connpool
+
+import "sync/atomic"
+
+// defaultAdaptiveGrowthFactor caps the adaptive per-address idle limit at a
+// multiple of the configured MaxIdlePerAddress when no explicit cap is set.
+const defaultAdaptiveGrowthFactor = 4
+
+// AdaptiveConfig configures EnableAdaptive.
+type AdaptiveConfig struct {
+	// MaxIdlePerAddress caps how far the per-address idle limit may grow,
+	// four times the configured static limit when 0. The global idle budget
+	// (MaxIdleGlobal) stays in force regardless.
+	MaxIdlePerAddress int
+}
+
+// EnableAdaptive turns on adaptive idle sizing: the per-address idle limit
+// grows for hot addresses and shrinks for cold ones instead of staying at the
+// static MaxIdlePerAddress. Each eviction tick inspects the traffic since the
+// previous one: connections that could not be recycled for lack of room grow
+// the limit (they would have been reuse hits), a window without a single
+// checkout shrinks it back toward MinIdlePerAddress.
+// Like EnableReporter, call it right after NewLongPool, before the pool is used.
+func (lp *LongPool) EnableAdaptive(cfg AdaptiveConfig) {
+	if cfg.MaxIdlePerAddress <= 0 {
+		cfg.MaxIdlePerAddress = lp.idleConfig.MaxIdlePerAddress * defaultAdaptiveGrowthFactor
+	}
+	if cfg.MaxIdlePerAddress < lp.idleConfig.MaxIdlePerAddress {
+		cfg.MaxIdlePerAddress = lp.idleConfig.MaxIdlePerAddress
+	}
+	lp.adaptiveCfg = &cfg
+}
+
+// adjust resizes every peer's idle limit, a no-op unless EnableAdaptive was
+// called.
+func (lp *LongPool) adjust() {
+	cfg := lp.adaptiveCfg
+	if cfg == nil {
+		return
+	}
+	floor := lp.idleConfig.MinIdlePerAddress
+	if floor < 1 {
+		floor = 1
+	}
+	lp.peerMap.Range(func(key, value interface{}) bool {
+		value.(*peer).adjust(floor, cfg.MaxIdlePerAddress)
+		return true
+	})
+}
+
+func (p *peer) adjust(floor, ceiling int) {
+	n := p.pool.adjust(floor, ceiling)
+	p.globalIdle.DecN(int64(n))
+	atomic.AddInt64(&p.stats.evicted, int64(n))
+}
+
+// adjust applies one adaptive sizing step from the stats gathered since the
+// previous step and returns how many idle connections were closed by
+// shrinking.
+func (p *pool) adjust(floor, ceiling int) (closed int) {
+	p.mu.Lock()
+	gets, drops := p.gets, p.drops
+	p.gets, p.drops = 0, 0
+	switch {
+	case drops > 0:
+		// recycling was rejected for lack of room: every drop was a future
+		// reuse hit lost, so grow accordingly
+		if p.maxIdle += drops; p.maxIdle > ceiling {
+			p.maxIdle = ceiling
+		}
+	case gets == 0 && p.maxIdle > floor:
+		p.maxIdle--
+		// trim the oldest idle connections beyond the new limit
+		for len(p.idleList) > p.maxIdle {
+			p.idleList[0].Close()
+			p.idleList[0] = nil
+			p.idleList = p.idleList[1:]
+			closed++
+		}
+	}
+	p.mu.Unlock()
+	return closed
+}
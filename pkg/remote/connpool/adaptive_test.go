@@ -0,0 +1,122 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connpool
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	mocksnetpoll "github.com/cloudwego/kitex/internal/mocks/netpoll"
+	mocksremote "github.com/cloudwego/kitex/internal/mocks/remote"
+	"github.com/cloudwego/kitex/internal/test"
+	dialer "github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/utils"
+)
+
+func TestPoolAdjustGrow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p := newPool(0, 2, time.Second)
+	// recycle more connections than the limit holds: the overflow is dropped
+	for i := 0; i < 5; i++ {
+		p.Put(newLongConnForTest(ctrl, mockAddr0))
+	}
+	test.Assert(t, p.Len() == 2)
+
+	closed := p.adjust(1, 8)
+	test.Assert(t, closed == 0)
+	test.Assert(t, p.maxIdle == 5, p.maxIdle) // 2 + 3 drops
+
+	// growth is capped by the ceiling
+	for i := 0; i < 10; i++ {
+		p.Put(newLongConnForTest(ctrl, mockAddr0))
+	}
+	p.adjust(1, 8)
+	test.Assert(t, p.maxIdle == 8, p.maxIdle)
+}
+
+func TestPoolAdjustShrink(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p := newPool(0, 3, time.Second)
+	for i := 0; i < 3; i++ {
+		p.Put(newLongConnForTest(ctrl, mockAddr0))
+	}
+
+	// a window without any checkout shrinks the limit and trims the overflow
+	p.gets, p.drops = 0, 0
+	closed := p.adjust(1, 8)
+	test.Assert(t, closed == 1, closed)
+	test.Assert(t, p.maxIdle == 2)
+	test.Assert(t, p.Len() == 2)
+
+	// a window with traffic leaves the limit alone
+	p.Get()
+	p.adjust(1, 8)
+	test.Assert(t, p.maxIdle == 2, p.maxIdle)
+
+	// shrinking stops at the floor
+	for i := 0; i < 4; i++ {
+		p.gets, p.drops = 0, 0
+		p.adjust(1, 8)
+	}
+	test.Assert(t, p.maxIdle == 1, p.maxIdle)
+}
+
+func TestLongPoolAdaptive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	lp := newLongPoolForTest(0, 2, 5, time.Second)
+	defer lp.Close()
+	lp.EnableAdaptive(AdaptiveConfig{MaxIdlePerAddress: 3})
+
+	d := mocksremote.NewMockDialer(ctrl)
+	d.EXPECT().DialTimeout(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(network, address string, timeout time.Duration) (net.Conn, error) {
+		na := utils.NewNetAddr(network, address)
+		conn := mocksnetpoll.NewMockConnection(ctrl)
+		conn.EXPECT().IsActive().Return(true).AnyTimes()
+		conn.EXPECT().RemoteAddr().Return(na).AnyTimes()
+		conn.EXPECT().Close().AnyTimes()
+		return conn, nil
+	}).AnyTimes()
+	opt := dialer.ConnOption{Dialer: d, ConnectTimeout: time.Second}
+
+	var conns []net.Conn
+	for i := 0; i < 3; i++ {
+		conn, err := lp.Get(context.TODO(), "tcp", mockAddr0, opt)
+		test.Assert(t, err == nil)
+		conns = append(conns, conn)
+	}
+	for _, conn := range conns {
+		lp.Put(conn)
+	}
+	// the third recycle overflowed the static limit of 2
+	lp.Tick()
+	p, _ := lp.peerMap.Load(netAddr{"tcp", mockAddr0})
+	test.Assert(t, p.(*peer).pool.maxIdle == 3, p.(*peer).pool.maxIdle)
+
+	// idle windows shrink it back, bounded by the global budget the whole time
+	lp.Tick()
+	test.Assert(t, p.(*peer).pool.maxIdle == 2, p.(*peer).pool.maxIdle)
+}
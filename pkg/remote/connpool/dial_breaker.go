@@ -0,0 +1,125 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connpool
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultDialBreakerThreshold is how many consecutive dial failures open
+	// the breaker when DialBreakerConfig.FailureThreshold is not set.
+	DefaultDialBreakerThreshold = 3
+	// DefaultDialBreakerBackoff is the initial fail-fast window when
+	// DialBreakerConfig.Backoff is not set.
+	DefaultDialBreakerBackoff = time.Second
+	// DefaultDialBreakerMaxBackoff caps the doubling backoff when
+	// DialBreakerConfig.MaxBackoff is not set.
+	DefaultDialBreakerMaxBackoff = 30 * time.Second
+)
+
+// ErrDialBreakerOpen is returned by Get while an address's dial breaker is
+// open: recent dials failed consecutively and the backoff window has not
+// passed yet. It surfaces to the caller as a connection error, so instance
+// circuit breakers count it like the dial failures it stands in for.
+var ErrDialBreakerOpen = errors.New("connection pool: dial breaker open, address recently unreachable")
+
+// DialBreakerConfig configures EnableDialBreaker.
+type DialBreakerConfig struct {
+	// FailureThreshold is how many consecutive dial failures open the
+	// breaker, DefaultDialBreakerThreshold when 0.
+	FailureThreshold int
+	// Backoff is the initial fail-fast window, doubled on every failed
+	// probe, DefaultDialBreakerBackoff when 0.
+	Backoff time.Duration
+	// MaxBackoff caps the doubling, DefaultDialBreakerMaxBackoff when 0.
+	MaxBackoff time.Duration
+}
+
+// EnableDialBreaker makes repeated dial failures to one address fail fast:
+// after FailureThreshold consecutive failures, Get returns
+// ErrDialBreakerOpen for the backoff window instead of re-dialing the dead
+// node on every request. When the window passes a single probe dial goes
+// through; success closes the breaker, failure doubles the window up to
+// MaxBackoff. Idle connections keep being served regardless.
+// Like EnableReporter, call it right after NewLongPool, before the pool is used.
+func (lp *LongPool) EnableDialBreaker(cfg DialBreakerConfig) {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = DefaultDialBreakerThreshold
+	}
+	if cfg.Backoff <= 0 {
+		cfg.Backoff = DefaultDialBreakerBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = DefaultDialBreakerMaxBackoff
+	}
+	lp.dialBreakerCfg = &cfg
+}
+
+// dialBreaker tracks consecutive dial failures to one address.
+type dialBreaker struct {
+	cfg *DialBreakerConfig
+
+	mu        sync.Mutex
+	failures  int
+	backoff   time.Duration
+	openUntil time.Time
+	probing   bool
+}
+
+// allow reports whether a dial may go ahead. While the breaker is open it
+// lets a single probe through once the backoff window has passed.
+func (b *dialBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < b.cfg.FailureThreshold {
+		return true
+	}
+	if time.Now().Before(b.openUntil) {
+		return false
+	}
+	if b.probing {
+		// a probe is already in flight, keep failing fast
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// onResult records the outcome of a dial.
+func (b *dialBreaker) onResult(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	if err == nil {
+		b.failures = 0
+		b.backoff = 0
+		return
+	}
+	b.failures++
+	if b.failures < b.cfg.FailureThreshold {
+		return
+	}
+	if b.backoff == 0 {
+		b.backoff = b.cfg.Backoff
+	} else if b.backoff *= 2; b.backoff > b.cfg.MaxBackoff {
+		b.backoff = b.cfg.MaxBackoff
+	}
+	b.openUntil = time.Now().Add(b.backoff)
+}
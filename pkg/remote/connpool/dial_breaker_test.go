@@ -0,0 +1,94 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connpool
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	mocksnetpoll "github.com/cloudwego/kitex/internal/mocks/netpoll"
+	mocksremote "github.com/cloudwego/kitex/internal/mocks/remote"
+	"github.com/cloudwego/kitex/internal/test"
+	dialer "github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/utils"
+)
+
+func TestLongPoolDialBreaker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	lp := newLongPoolForTest(0, 2, 5, time.Second)
+	defer lp.Close()
+	lp.EnableDialBreaker(DialBreakerConfig{
+		FailureThreshold: 2,
+		Backoff:          20 * time.Millisecond,
+	})
+
+	dialErr := errors.New("connection refused")
+	failDial := true
+	dials := 0
+	d := mocksremote.NewMockDialer(ctrl)
+	d.EXPECT().DialTimeout(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(network, address string, timeout time.Duration) (net.Conn, error) {
+		dials++
+		if failDial {
+			return nil, dialErr
+		}
+		na := utils.NewNetAddr(network, address)
+		conn := mocksnetpoll.NewMockConnection(ctrl)
+		conn.EXPECT().IsActive().Return(true).AnyTimes()
+		conn.EXPECT().RemoteAddr().Return(na).AnyTimes()
+		conn.EXPECT().Close().AnyTimes()
+		return conn, nil
+	}).AnyTimes()
+	opt := dialer.ConnOption{Dialer: d, ConnectTimeout: time.Second}
+
+	// failures below the threshold dial every time
+	_, err := lp.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == dialErr)
+	_, err = lp.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == dialErr)
+	test.Assert(t, dials == 2, dials)
+
+	// the breaker is open now: no dial happens during the backoff window
+	_, err = lp.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == ErrDialBreakerOpen, err)
+	test.Assert(t, dials == 2, dials)
+
+	// after the window one probe goes through; it fails and reopens
+	time.Sleep(30 * time.Millisecond)
+	_, err = lp.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == dialErr)
+	test.Assert(t, dials == 3, dials)
+	_, err = lp.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == ErrDialBreakerOpen)
+
+	// the backoff doubled to 40ms: after it a successful probe closes the
+	// breaker again
+	failDial = false
+	time.Sleep(50 * time.Millisecond)
+	conn, err := lp.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == nil, err)
+	lp.Put(conn)
+	conn, err = lp.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == nil)
+	lp.Put(conn)
+}
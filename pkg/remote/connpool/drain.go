@@ -0,0 +1,53 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connpool
+
+import "sync/atomic"
+
+// Drain recycles all connections to the given address: idle ones are closed
+// now, in-use ones are closed when returned instead of being pooled, and the
+// requests using them finish undisturbed. New connections dialed after the
+// call are pooled as usual, so operators can rotate connections after a TLS
+// cert renewal or a downstream config change without restarting the client.
+// Unlike Clean, the peer keeps its stats and connection slots.
+func (lp *LongPool) Drain(network, address string) {
+	if p, ok := lp.peerMap.Load(netAddr{network, address}); ok {
+		p.(*peer).Drain()
+	}
+}
+
+// DrainAll drains the connections to every known address, see Drain.
+func (lp *LongPool) DrainAll() {
+	lp.peerMap.Range(func(key, value interface{}) bool {
+		value.(*peer).Drain()
+		return true
+	})
+}
+
+// Drain retires every connection dialed so far: the epoch bump makes Put
+// close them on return, then the idle ones are closed right away.
+func (p *peer) Drain() {
+	atomic.AddUint64(&p.epoch, 1)
+	n := p.pool.Close()
+	p.globalIdle.DecN(int64(n))
+	atomic.AddInt64(&p.stats.evicted, int64(n))
+}
+
+// drained reports whether the connection predates the last Drain call.
+func (p *peer) drained(c *longConn) bool {
+	return c.epoch < atomic.LoadUint64(&p.epoch)
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connpool
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	mocksnetpoll "github.com/cloudwego/kitex/internal/mocks/netpoll"
+	mocksremote "github.com/cloudwego/kitex/internal/mocks/remote"
+	"github.com/cloudwego/kitex/internal/test"
+	dialer "github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/utils"
+)
+
+func TestLongPoolDrain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	lp := newLongPoolForTest(0, 2, 5, time.Second)
+	defer lp.Close()
+
+	d := mocksremote.NewMockDialer(ctrl)
+	d.EXPECT().DialTimeout(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(network, address string, timeout time.Duration) (net.Conn, error) {
+		na := utils.NewNetAddr(network, address)
+		conn := mocksnetpoll.NewMockConnection(ctrl)
+		conn.EXPECT().IsActive().Return(true).AnyTimes()
+		conn.EXPECT().RemoteAddr().Return(na).AnyTimes()
+		conn.EXPECT().Close().AnyTimes()
+		return conn, nil
+	}).AnyTimes()
+	opt := dialer.ConnOption{Dialer: d, ConnectTimeout: time.Second}
+
+	// one idle, one in use when the drain hits
+	conn1, err := lp.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == nil)
+	conn2, err := lp.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == nil)
+	lp.Put(conn1)
+
+	lp.Drain("tcp", mockAddr0)
+	p, _ := lp.peerMap.Load(netAddr{"tcp", mockAddr0})
+	test.Assert(t, p.(*peer).Len() == 0) // the idle one is gone
+
+	// the in-use one is closed on return, not recycled
+	lp.Put(conn2)
+	test.Assert(t, p.(*peer).Len() == 0)
+
+	// connections dialed after the drain are pooled as usual
+	conn3, err := lp.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == nil)
+	test.Assert(t, conn3.(*longConn).Conn != conn2.(*longConn).Conn)
+	lp.Put(conn3)
+	test.Assert(t, p.(*peer).Len() == 1)
+
+	// DrainAll covers every address
+	connOther, err := lp.Get(context.TODO(), "tcp", mockAddr1, opt)
+	test.Assert(t, err == nil)
+	lp.Put(connOther)
+	lp.DrainAll()
+	lp.peerMap.Range(func(key, value interface{}) bool {
+		test.Assert(t, value.(*peer).Len() == 0)
+		return true
+	})
+}
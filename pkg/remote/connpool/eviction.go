@@ -0,0 +1,70 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connpool
+
+import "net"
+
+// EvictionPolicy decides which idle connections to close on each eviction
+// tick, on top of the built-in idle timeout. Typical policies drop
+// connections to addresses that left the discovery result or whose TLS
+// certificates are about to rotate.
+type EvictionPolicy interface {
+	// ShouldEvict reports whether the idle connection to addr should be
+	// closed. It is called under the pool lock, so it must not block.
+	ShouldEvict(addr net.Addr, conn net.Conn) bool
+}
+
+// EvictionPolicyFunc adapts a func to the EvictionPolicy interface.
+type EvictionPolicyFunc func(addr net.Addr, conn net.Conn) bool
+
+// ShouldEvict implements the EvictionPolicy interface.
+func (f EvictionPolicyFunc) ShouldEvict(addr net.Addr, conn net.Conn) bool {
+	return f(addr, conn)
+}
+
+// EvictStaleAddresses is an EvictionPolicy closing idle connections to
+// addresses keep no longer reports, e.g. ones dropped from the discovery
+// result.
+func EvictStaleAddresses(keep func(address string) bool) EvictionPolicy {
+	return EvictionPolicyFunc(func(addr net.Addr, conn net.Conn) bool {
+		return !keep(addr.String())
+	})
+}
+
+// SetEvictionPolicy installs a policy run on every eviction tick in addition
+// to the idle timeout; connections it rejects are closed. Connections in use
+// are never touched.
+// Like EnableReporter, call it right after NewLongPool, before the pool is used.
+func (lp *LongPool) SetEvictionPolicy(p EvictionPolicy) {
+	lp.evictionPolicy = p
+}
+
+// evictByPolicy applies the installed EvictionPolicy to every peer's idle
+// connections, a no-op unless SetEvictionPolicy was called.
+func (lp *LongPool) evictByPolicy() {
+	policy := lp.evictionPolicy
+	if policy == nil {
+		return
+	}
+	lp.peerMap.Range(func(key, value interface{}) bool {
+		p := value.(*peer)
+		p.sweep(func(conn net.Conn) bool {
+			return !policy.ShouldEvict(p.addr, conn)
+		})
+		return true
+	})
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connpool
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	mocksnetpoll "github.com/cloudwego/kitex/internal/mocks/netpoll"
+	mocksremote "github.com/cloudwego/kitex/internal/mocks/remote"
+	"github.com/cloudwego/kitex/internal/test"
+	dialer "github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/utils"
+)
+
+func TestLongPoolEvictionPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	lp := newLongPoolForTest(0, 2, 5, time.Hour) // idle timeout never fires
+	defer lp.Close()
+	known := map[string]bool{mockAddr0: true, mockAddr1: true}
+	lp.SetEvictionPolicy(EvictStaleAddresses(func(address string) bool {
+		return known[address]
+	}))
+
+	d := mocksremote.NewMockDialer(ctrl)
+	d.EXPECT().DialTimeout(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(network, address string, timeout time.Duration) (net.Conn, error) {
+		na := utils.NewNetAddr(network, address)
+		conn := mocksnetpoll.NewMockConnection(ctrl)
+		conn.EXPECT().IsActive().Return(true).AnyTimes()
+		conn.EXPECT().RemoteAddr().Return(na).AnyTimes()
+		conn.EXPECT().Close().AnyTimes()
+		return conn, nil
+	}).AnyTimes()
+	opt := dialer.ConnOption{Dialer: d, ConnectTimeout: time.Second}
+
+	for _, addr := range []string{mockAddr0, mockAddr1} {
+		conn, err := lp.Get(context.TODO(), "tcp", addr, opt)
+		test.Assert(t, err == nil)
+		lp.Put(conn)
+	}
+
+	// both addresses still known: nothing is evicted
+	lp.Tick()
+	p0, _ := lp.peerMap.Load(netAddr{"tcp", mockAddr0})
+	p1, _ := lp.peerMap.Load(netAddr{"tcp", mockAddr1})
+	test.Assert(t, p0.(*peer).Len() == 1)
+	test.Assert(t, p1.(*peer).Len() == 1)
+
+	// mockAddr1 drops out of discovery: only its idle connection goes
+	delete(known, mockAddr1)
+	lp.Tick()
+	test.Assert(t, p0.(*peer).Len() == 1)
+	test.Assert(t, p1.(*peer).Len() == 0)
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connpool
+
+import (
+	"net"
+
+	"github.com/cloudwego/kitex/pkg/remote"
+)
+
+// ConnCheckFunc reports whether an idle connection is still usable when it is
+// checked out of the pool. A custom func can implement a protocol-level ping
+// where a transport-level probe is not enough.
+type ConnCheckFunc func(conn net.Conn) bool
+
+// EnableConnCheck turns on liveness validation on checkout: connections that
+// fail the check are closed and the next idle one is tried, so a request
+// doesn't burn a retry on a connection closed by the peer or dropped by an LB
+// idle timeout while it sat in the pool. A nil check installs
+// DefaultConnCheck. Like EnableReporter, call it right after NewLongPool,
+// before the pool is used.
+func (lp *LongPool) EnableConnCheck(check ConnCheckFunc) {
+	if check == nil {
+		check = DefaultConnCheck
+	}
+	lp.connCheck = check
+}
+
+// DefaultConnCheck probes liveness with a non-blocking read of the raw fd:
+// a peer that closed the connection answers immediately (EOF or reset) while
+// a healthy idle connection reports nothing to read. Connections that expose
+// their own state through the remote.IsActive interface (netpoll detects
+// closes through the poller) are trusted as-is instead of being read from,
+// and connections offering neither pass unchecked.
+func DefaultConnCheck(conn net.Conn) bool {
+	if ia, ok := conn.(remote.IsActive); ok {
+		return ia.IsActive()
+	}
+	return rawConnCheck(conn)
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connpool
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	mocksnetpoll "github.com/cloudwego/kitex/internal/mocks/netpoll"
+	mocksremote "github.com/cloudwego/kitex/internal/mocks/remote"
+	"github.com/cloudwego/kitex/internal/test"
+	dialer "github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/utils"
+)
+
+// tcpConnPair returns both ends of a real TCP connection.
+func tcpConnPair(t *testing.T) (client, server net.Conn) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	test.Assert(t, err == nil, err)
+	defer ln.Close()
+	done := make(chan struct{})
+	go func() {
+		server, _ = ln.Accept()
+		close(done)
+	}()
+	client, err = net.Dial("tcp", ln.Addr().String())
+	test.Assert(t, err == nil, err)
+	<-done
+	return client, server
+}
+
+func TestDefaultConnCheck(t *testing.T) {
+	client, server := tcpConnPair(t)
+	defer server.Close()
+
+	// a healthy idle connection passes
+	test.Assert(t, DefaultConnCheck(client))
+
+	// closed by the peer: the probe read sees it right away
+	server.Close()
+	for i := 0; i < 100 && DefaultConnCheck(client); i++ {
+		time.Sleep(time.Millisecond) // the FIN may still be in flight
+	}
+	test.Assert(t, !DefaultConnCheck(client))
+	client.Close()
+}
+
+func TestLongPoolConnCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	lp := newLongPoolForTest(0, 2, 3, time.Second)
+	defer lp.Close()
+	checked := 0
+	healthy := false
+	lp.EnableConnCheck(func(conn net.Conn) bool {
+		checked++
+		return healthy
+	})
+
+	d := mocksremote.NewMockDialer(ctrl)
+	d.EXPECT().DialTimeout(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(network, address string, timeout time.Duration) (net.Conn, error) {
+		na := utils.NewNetAddr(network, address)
+		conn := mocksnetpoll.NewMockConnection(ctrl)
+		conn.EXPECT().IsActive().Return(true).AnyTimes()
+		conn.EXPECT().RemoteAddr().Return(na).AnyTimes()
+		conn.EXPECT().Close().AnyTimes()
+		return conn, nil
+	}).AnyTimes()
+	opt := dialer.ConnOption{Dialer: d, ConnectTimeout: time.Second}
+
+	conn1, err := lp.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == nil)
+	test.Assert(t, checked == 0) // fresh dials are not probed
+	lp.Put(conn1)
+
+	// the pooled conn fails the check: it is dropped and a new one dialed
+	conn2, err := lp.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == nil)
+	test.Assert(t, checked == 1, checked)
+	test.Assert(t, conn2.(*longConn).Conn != conn1.(*longConn).Conn)
+	lp.Put(conn2)
+
+	// a passing check reuses the pooled conn
+	healthy = true
+	conn3, err := lp.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == nil)
+	test.Assert(t, checked == 2, checked)
+	test.Assert(t, conn3.(*longConn).Conn == conn2.(*longConn).Conn)
+}
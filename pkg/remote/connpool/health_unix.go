@@ -0,0 +1,58 @@
+//go:build !windows
+// +build !windows
+
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connpool
+
+import (
+	"net"
+	"syscall"
+)
+
+// rawConnCheck does a non-blocking read of one byte from the raw fd without
+// consuming anything a healthy connection could deliver later: an idle RPC
+// connection has nothing in flight, so EAGAIN is the healthy answer and
+// anything else (EOF, reset, or unsolicited data) means the connection is
+// unusable. Connections that do not expose their fd pass unchecked.
+func rawConnCheck(conn net.Conn) bool {
+	sc, ok := conn.(syscall.Conn)
+	if !ok {
+		return true
+	}
+	rc, err := sc.SyscallConn()
+	if err != nil {
+		return false
+	}
+	alive := true
+	rerr := rc.Read(func(fd uintptr) bool {
+		var buf [1]byte
+		n, err := syscall.Read(int(fd), buf[:])
+		switch {
+		case err == syscall.EAGAIN || err == syscall.EWOULDBLOCK:
+			// nothing to read, the connection is idle and open
+		case n > 0:
+			// unsolicited data on an idle RPC connection
+			alive = false
+		default:
+			// EOF (n == 0, err == nil) or a real error such as ECONNRESET
+			alive = false
+		}
+		return true // don't wait for readability, this is a one-shot probe
+	})
+	return rerr == nil && alive
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connpool
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+var (
+	_ remote.ConnPool     = &hookPool{}
+	_ remote.LongConnPool = &hookPool{}
+)
+
+// hookPool decorates a ConnPool and fires ConnLifecycleHooks on dial,
+// reuse and discard, without changing the pooling behavior.
+type hookPool struct {
+	pool  remote.ConnPool
+	hooks remote.ConnLifecycleHooks
+}
+
+// NewConnLifecycleHookPool wraps pool so that the given hooks observe the
+// lifecycle of its connections.
+func NewConnLifecycleHookPool(pool remote.ConnPool, hooks remote.ConnLifecycleHooks) remote.ConnPool {
+	return &hookPool{pool: pool, hooks: hooks}
+}
+
+// markDialer records whether the underlying dialer was actually invoked,
+// which distinguishes a fresh connection from a pooled one.
+type markDialer struct {
+	remote.Dialer
+	dialed bool
+}
+
+func (md *markDialer) DialTimeout(network, address string, timeout time.Duration) (net.Conn, error) {
+	md.dialed = true
+	return md.Dialer.DialTimeout(network, address, timeout)
+}
+
+// Get returns a connection to the given address and reports it to the
+// OnDial or OnReuse hook.
+func (hp *hookPool) Get(ctx context.Context, network, address string, opt remote.ConnOption) (net.Conn, error) {
+	md := &markDialer{Dialer: opt.Dialer}
+	opt.Dialer = md
+	conn, err := hp.pool.Get(ctx, network, address, opt)
+	if err != nil {
+		return nil, err
+	}
+	ri := rpcinfo.GetRPCInfo(ctx)
+	if md.dialed {
+		if hp.hooks.OnDial != nil {
+			hp.hooks.OnDial(ctx, ri, conn)
+		}
+	} else if hp.hooks.OnReuse != nil {
+		hp.hooks.OnReuse(ctx, ri, conn)
+	}
+	return conn, nil
+}
+
+// Put puts the connection back to pool.
+func (hp *hookPool) Put(conn net.Conn) error {
+	return hp.pool.Put(conn)
+}
+
+// Discard discards the connection and reports it to the OnClose hook.
+func (hp *hookPool) Discard(conn net.Conn) error {
+	if hp.hooks.OnClose != nil {
+		hp.hooks.OnClose(context.Background(), nil, conn)
+	}
+	return hp.pool.Discard(conn)
+}
+
+// Clean implements the LongConnPool interface.
+func (hp *hookPool) Clean(network, address string) {
+	if long, ok := hp.pool.(remote.LongConnPool); ok {
+		long.Clean(network, address)
+	}
+}
+
+// Close is to release resource of ConnPool, it is executed when client is closed.
+func (hp *hookPool) Close() error {
+	return hp.pool.Close()
+}
+
+// Dump is used to dump the info of the wrapped pool.
+func (hp *hookPool) Dump() interface{} {
+	if df, ok := hp.pool.(interface{ Dump() interface{} }); ok {
+		return df.Dump()
+	}
+	return nil
+}
+
+// EnableReporter enables the reporter of the wrapped pool.
+func (hp *hookPool) EnableReporter() {
+	if r, ok := hp.pool.(remote.ConnPoolReporter); ok {
+		r.EnableReporter()
+	}
+}
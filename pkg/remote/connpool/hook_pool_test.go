@@ -0,0 +1,58 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connpool
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	mocksnet "github.com/cloudwego/kitex/internal/mocks/net"
+	mocksremote "github.com/cloudwego/kitex/internal/mocks/remote"
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+func TestConnLifecycleHookPool(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	conn := mocksnet.NewMockConn(ctrl)
+	conn.EXPECT().Close().AnyTimes()
+	d := mocksremote.NewMockDialer(ctrl)
+	d.EXPECT().DialTimeout(gomock.Any(), gomock.Any(), gomock.Any()).Return(conn, nil).AnyTimes()
+
+	var dialed, reused, closed int
+	p := NewConnLifecycleHookPool(NewShortPool("test"), remote.ConnLifecycleHooks{
+		OnDial:  func(ctx context.Context, ri rpcinfo.RPCInfo, conn net.Conn) { dialed++ },
+		OnReuse: func(ctx context.Context, ri rpcinfo.RPCInfo, conn net.Conn) { reused++ },
+		OnClose: func(ctx context.Context, ri rpcinfo.RPCInfo, conn net.Conn) { closed++ },
+	})
+
+	opt := remote.ConnOption{Dialer: d, ConnectTimeout: time.Second}
+	c, err := p.Get(context.TODO(), "tcp", "127.0.0.1:8080", opt)
+	test.Assert(t, err == nil)
+	test.Assert(t, dialed == 1 && reused == 0, dialed, reused)
+
+	err = p.Discard(c)
+	test.Assert(t, err == nil)
+	test.Assert(t, closed == 1, closed)
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connpool
+
+import (
+	"net"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultKeepaliveInterval is how often idle connections are probed when
+// KeepaliveConfig.Interval is not set.
+const DefaultKeepaliveInterval = 30 * time.Second
+
+// KeepaliveConfig configures EnableKeepalive.
+type KeepaliveConfig struct {
+	// Interval is how often idle connections are probed,
+	// DefaultKeepaliveInterval when 0.
+	Interval time.Duration
+	// TCPKeepAlivePeriod, when positive, turns on TCP keepalive with this
+	// period on every dialed *net.TCPConn so NATs and LBs see traffic even
+	// while the connection idles in the pool.
+	TCPKeepAlivePeriod time.Duration
+	// Check probes an idle connection, DefaultConnCheck when nil. A custom
+	// func can send a protocol-level ping instead of a transport probe.
+	Check ConnCheckFunc
+}
+
+// EnableKeepalive proactively evicts idle connections that died in the pool:
+// every Interval all idle connections are probed with Check and the dead ones
+// closed, so they are caught between requests instead of by the request that
+// picks them up. With TCPKeepAlivePeriod set, dialed TCP connections also get
+// kernel keepalive to hold NAT and LB mappings open.
+// Like EnableReporter, call it right after NewLongPool, before the pool is used.
+func (lp *LongPool) EnableKeepalive(cfg KeepaliveConfig) {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultKeepaliveInterval
+	}
+	if cfg.Check == nil {
+		cfg.Check = DefaultConnCheck
+	}
+	lp.keepaliveCfg = &cfg
+	lp.keepaliveTask = &keepaliveTask{lp: lp}
+	lp.keepaliveTicker = getSharedTicker(lp.keepaliveTask, cfg.Interval)
+}
+
+// keepaliveTask adapts the keepalive sweep to the utils.TickerTask interface,
+// keeping it on its own interval apart from the eviction Tick.
+type keepaliveTask struct {
+	lp *LongPool
+}
+
+// Tick implements the interface utils.TickerTask.
+func (t *keepaliveTask) Tick() {
+	t.lp.sweepIdle()
+}
+
+// sweepIdle probes all idle connections and closes the dead ones.
+func (lp *LongPool) sweepIdle() {
+	check := lp.keepaliveCfg.Check
+	lp.peerMap.Range(func(key, value interface{}) bool {
+		value.(*peer).sweep(check)
+		return true
+	})
+}
+
+func (p *peer) sweep(check ConnCheckFunc) {
+	n := p.pool.sweep(check)
+	p.globalIdle.DecN(int64(n))
+	atomic.AddInt64(&p.stats.evicted, int64(n))
+}
+
+// sweep closes the idle connections that fail check and returns how many
+// were closed.
+func (p *pool) sweep(check ConnCheckFunc) (closed int) {
+	p.mu.Lock()
+	kept := p.idleList[:0]
+	for _, o := range p.idleList {
+		if check(o.Conn) {
+			kept = append(kept, o)
+		} else {
+			o.Close()
+			closed++
+		}
+	}
+	// reset the vacated tail to nil so the connections can be collected
+	for i := len(kept); i < len(p.idleList); i++ {
+		p.idleList[i] = nil
+	}
+	p.idleList = kept
+	p.mu.Unlock()
+	return closed
+}
+
+// setTCPKeepAlive applies the configured kernel keepalive to a dialed
+// connection, a no-op for non-TCP connections.
+func setTCPKeepAlive(c net.Conn, period time.Duration) {
+	if tc, ok := c.(*net.TCPConn); ok {
+		tc.SetKeepAlive(true)
+		tc.SetKeepAlivePeriod(period)
+	}
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connpool
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	mocksnetpoll "github.com/cloudwego/kitex/internal/mocks/netpoll"
+	mocksremote "github.com/cloudwego/kitex/internal/mocks/remote"
+	"github.com/cloudwego/kitex/internal/test"
+	dialer "github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/utils"
+)
+
+func TestPoolSweep(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p := newPool(0, 3, time.Second)
+	for i := 0; i < 3; i++ {
+		p.Put(newLongConnForTest(ctrl, mockAddr0))
+	}
+
+	// all healthy: nothing closed
+	closed := p.sweep(func(conn net.Conn) bool { return true })
+	test.Assert(t, closed == 0)
+	test.Assert(t, p.Len() == 3)
+
+	// fail every other one
+	healthy := true
+	closed = p.sweep(func(conn net.Conn) bool {
+		healthy = !healthy
+		return healthy
+	})
+	test.Assert(t, closed == 2, closed)
+	test.Assert(t, p.Len() == 1)
+}
+
+func TestLongPoolKeepalive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	lp := newLongPoolForTest(0, 2, 5, time.Second)
+	defer lp.Close()
+	alive := true
+	lp.EnableKeepalive(KeepaliveConfig{
+		Interval: time.Hour, // swept by hand below
+		Check:    func(conn net.Conn) bool { return alive },
+	})
+
+	d := mocksremote.NewMockDialer(ctrl)
+	d.EXPECT().DialTimeout(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(network, address string, timeout time.Duration) (net.Conn, error) {
+		na := utils.NewNetAddr(network, address)
+		conn := mocksnetpoll.NewMockConnection(ctrl)
+		conn.EXPECT().IsActive().Return(true).AnyTimes()
+		conn.EXPECT().RemoteAddr().Return(na).AnyTimes()
+		conn.EXPECT().Close().AnyTimes()
+		return conn, nil
+	}).AnyTimes()
+	opt := dialer.ConnOption{Dialer: d, ConnectTimeout: time.Second}
+
+	conn1, err := lp.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == nil)
+	lp.Put(conn1)
+
+	// a healthy sweep keeps the connection in the pool
+	lp.keepaliveTask.Tick()
+	p, _ := lp.peerMap.Load(netAddr{"tcp", mockAddr0})
+	test.Assert(t, p.(*peer).Len() == 1)
+
+	// a dead connection is evicted and its global idle slot freed
+	alive = false
+	lp.keepaliveTask.Tick()
+	test.Assert(t, p.(*peer).Len() == 0)
+
+	conn2, err := lp.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == nil)
+	test.Assert(t, conn2.(*longConn).Conn != conn1.(*longConn).Conn)
+	lp.Put(conn2)
+}
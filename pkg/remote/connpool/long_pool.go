@@ -43,7 +43,7 @@ const (
 	configDumpKey = "idle_config"
 )
 
-func getSharedTicker(p *LongPool, refreshInterval time.Duration) *utils.SharedTicker {
+func getSharedTicker(p utils.TickerTask, refreshInterval time.Duration) *utils.SharedTicker {
 	sti, ok := sharedTickers.Load(refreshInterval)
 	if ok {
 		st := sti.(*utils.SharedTicker)
@@ -74,6 +74,7 @@ type longConn struct {
 	sync.RWMutex
 	deadline time.Time
 	address  string
+	epoch    uint64 // the peer epoch at dial time, see drain.go
 }
 
 // Close implements the net.Conn interface.
@@ -123,11 +124,15 @@ type pool struct {
 	minIdle        int
 	maxIdle        int           // currIdle <= maxIdle.
 	maxIdleTimeout time.Duration // the idle connection will be cleaned if the idle time exceeds maxIdleTimeout.
+	// adaptive sizing stats since the last adjust, see adaptive.go
+	gets  int
+	drops int
 }
 
 // Get gets the first active connection from the idleList. Return the number of connections decreased during the Get.
 func (p *pool) Get() (*longConn, bool, int) {
 	p.mu.Lock()
+	p.gets++
 	// Get the first active one
 	n := len(p.idleList)
 	selected := n - 1
@@ -160,6 +165,8 @@ func (p *pool) Put(o *longConn) bool {
 		o.deadline = time.Now().Add(p.maxIdleTimeout)
 		p.idleList = append(p.idleList, o)
 		recycled = true
+	} else {
+		p.drops++
 	}
 	p.mu.Unlock()
 	return recycled
@@ -230,13 +237,20 @@ func newPeer(
 	maxIdle int,
 	maxIdleTimeout time.Duration,
 	globalIdle *utils.MaxCounter,
+	wait *WaitConfig,
 ) *peer {
-	return &peer{
+	p := &peer{
 		serviceName: serviceName,
 		addr:        addr,
 		globalIdle:  globalIdle,
 		pool:        newPool(minIdle, maxIdle, maxIdleTimeout),
 	}
+	if wait != nil {
+		p.sem = make(chan struct{}, wait.MaxConnPerAddress)
+		p.waitTimeout = wait.MaxWaitTimeout
+		p.waitStats = &waitStats{}
+	}
+	return p
 }
 
 // peer has one address, it manages all connections base on this address
@@ -247,32 +261,88 @@ type peer struct {
 	globalIdle  *utils.MaxCounter
 	// pool
 	pool *pool
+	// connection slots, nil unless EnableWait was called, see wait.go
+	sem         chan struct{}
+	waitTimeout time.Duration
+	waitStats   *waitStats
+	// kernel keepalive for dialed conns, zero unless EnableKeepalive was
+	// called, see keepalive.go
+	tcpKeepAlive time.Duration
+	// cumulative counters, see snapshot.go
+	stats peerStats
+	// bumped by Drain to retire connections on return, see drain.go
+	epoch uint64
+	// nil unless EnableDialBreaker was called, see dial_breaker.go
+	breaker *dialBreaker
 }
 
 // Get gets a connection with dialer and timeout. Dial a new connection if no idle connection in pool is available.
-func (p *peer) Get(d remote.Dialer, timeout time.Duration, reporter Reporter, addr string) (net.Conn, error) {
-	var c net.Conn
-	c, reused, decNum := p.pool.Get()
-	p.globalIdle.DecN(int64(decNum))
-	if reused {
+func (p *peer) Get(d remote.Dialer, timeout time.Duration, reporter Reporter, addr string, check ConnCheckFunc) (net.Conn, error) {
+	atomic.AddInt64(&p.stats.gets, 1)
+	if err := p.acquire(); err != nil {
+		reporter.ConnFailed(Long, p.serviceName, p.addr)
+		return nil, err
+	}
+	for {
+		c, reused, decNum := p.pool.Get()
+		p.globalIdle.DecN(int64(decNum))
+		if !reused {
+			break
+		}
+		if check != nil && !check(c.Conn) {
+			// closed by the peer or an LB idle timeout, try the next one
+			c.Close()
+			continue
+		}
+		atomic.AddInt64(&p.stats.reuses, 1)
+		atomic.AddInt32(&p.stats.active, 1)
 		reporter.ReuseSucceed(Long, p.serviceName, p.addr)
 		return c, nil
 	}
 	// dial a new connection
+	if p.breaker != nil && !p.breaker.allow() {
+		p.release()
+		reporter.ConnFailed(Long, p.serviceName, p.addr)
+		return nil, ErrDialBreakerOpen
+	}
 	c, err := d.DialTimeout(p.addr.Network(), p.addr.String(), timeout)
+	if p.breaker != nil {
+		p.breaker.onResult(err)
+	}
 	if err != nil {
+		p.release()
+		atomic.AddInt64(&p.stats.dialFails, 1)
 		reporter.ConnFailed(Long, p.serviceName, p.addr)
 		return nil, err
 	}
+	if p.tcpKeepAlive > 0 {
+		setTCPKeepAlive(c, p.tcpKeepAlive)
+	}
+	atomic.AddInt64(&p.stats.created, 1)
+	atomic.AddInt32(&p.stats.active, 1)
 	reporter.ConnSucceed(Long, p.serviceName, p.addr)
 	return &longConn{
 		Conn:    c,
 		address: addr,
+		epoch:   atomic.LoadUint64(&p.epoch),
 	}, nil
 }
 
 // Put puts a connection back to the peer.
 func (p *peer) Put(c *longConn) error {
+	// recycle into the idle list first so a queued Get can grab it right
+	// after the slot is freed
+	err := p.put(c)
+	atomic.AddInt32(&p.stats.active, -1)
+	p.release()
+	return err
+}
+
+func (p *peer) put(c *longConn) error {
+	if p.drained(c) {
+		// drained while in use: close on return instead of recycling
+		return c.Close()
+	}
 	if !p.globalIdle.Inc() {
 		return c.Close()
 	}
@@ -290,6 +360,7 @@ func (p *peer) Len() int {
 func (p *peer) Evict() {
 	n := p.pool.Evict()
 	p.globalIdle.DecN(int64(n))
+	atomic.AddInt64(&p.stats.evicted, int64(n))
 }
 
 // Close closes the peer and all the connections in the ring.
@@ -298,23 +369,46 @@ func (p *peer) Close() {
 	p.globalIdle.DecN(int64(n))
 }
 
+// Dump dumps the info of the peer, including wait stats if EnableWait is on.
+func (p *peer) Dump() interface{} {
+	d := p.pool.Dump()
+	if p.sem == nil {
+		return d
+	}
+	return waitPoolDump{PoolDump: d, Wait: p.waitStats.dump(cap(p.sem))}
+}
+
 // NewLongPool creates a long pool using the given IdleConfig.
 func NewLongPool(serviceName string, idlConfig connpool.IdleConfig) *LongPool {
 	limit := utils.NewMaxCounter(idlConfig.MaxIdleGlobal)
 	lp := &LongPool{
 		reporter:   &DummyReporter{},
 		globalIdle: limit,
-		newPeer: func(addr net.Addr) *peer {
-			return newPeer(
-				serviceName,
-				addr,
-				idlConfig.MinIdlePerAddress,
-				idlConfig.MaxIdlePerAddress,
-				idlConfig.MaxIdleTimeout,
-				limit)
-		},
 		idleConfig: idlConfig,
 	}
+	lp.newPeer = func(addr net.Addr) *peer {
+		wait := lp.waitCfg
+		if wait == nil && idlConfig.MaxActivePerAddress > 0 {
+			// cap active connections without waiting: Get fails fast once
+			// MaxActivePerAddress connections are out
+			wait = &WaitConfig{MaxConnPerAddress: idlConfig.MaxActivePerAddress}
+		}
+		p := newPeer(
+			serviceName,
+			addr,
+			idlConfig.MinIdlePerAddress,
+			idlConfig.MaxIdlePerAddress,
+			idlConfig.MaxIdleTimeout,
+			limit,
+			wait)
+		if cfg := lp.keepaliveCfg; cfg != nil {
+			p.tcpKeepAlive = cfg.TCPKeepAlivePeriod
+		}
+		if cfg := lp.dialBreakerCfg; cfg != nil {
+			p.breaker = &dialBreaker{cfg: cfg}
+		}
+		return p
+	}
 	// add this long pool into the sharedTicker
 	lp.sharedTicker = getSharedTicker(lp, idlConfig.MaxIdleTimeout)
 	return lp
@@ -322,11 +416,25 @@ func NewLongPool(serviceName string, idlConfig connpool.IdleConfig) *LongPool {
 
 // LongPool manages a pool of long connections.
 type LongPool struct {
-	reporter     Reporter
-	peerMap      sync.Map
-	newPeer      func(net.Addr) *peer
-	globalIdle   *utils.MaxCounter
-	idleConfig   connpool.IdleConfig
+	reporter       Reporter
+	peerMap        sync.Map
+	newPeer        func(net.Addr) *peer
+	globalIdle     *utils.MaxCounter
+	idleConfig     connpool.IdleConfig
+	adaptiveCfg    *AdaptiveConfig    // nil unless EnableAdaptive was called
+	connCheck      ConnCheckFunc      // nil unless EnableConnCheck was called
+	waitCfg        *WaitConfig        // nil unless EnableWait was called
+	evictionPolicy EvictionPolicy     // nil unless SetEvictionPolicy was called
+	dialBreakerCfg *DialBreakerConfig // nil unless EnableDialBreaker was called
+	// keepalive sweep state, nil unless EnableKeepalive was called
+	keepaliveCfg    *KeepaliveConfig
+	keepaliveTask   *keepaliveTask
+	keepaliveTicker *utils.SharedTicker
+	// snapshot reporting state, nil unless EnableStatsReporter was called
+	statsTask   *statsTask
+	statsTicker *utils.SharedTicker
+	// handshake stats source, nil unless AttachTLSDialer was called
+	tlsDialer    *TLSDialer
 	sharedTicker *utils.SharedTicker
 	closed       int32 // active: 0, closed: 1
 }
@@ -334,9 +442,10 @@ type LongPool struct {
 // Get pick or generate a net.Conn and return
 // The context is not used but leave it for now.
 func (lp *LongPool) Get(ctx context.Context, network, address string, opt remote.ConnOption) (net.Conn, error) {
+	network, address = NormalizeNetAddr(network, address)
 	addr := netAddr{network, address}
 	p := lp.getPeer(addr)
-	return p.Get(opt.Dialer, opt.ConnectTimeout, lp.reporter, address)
+	return p.Get(opt.Dialer, opt.ConnectTimeout, lp.reporter, address, lp.connCheck)
 }
 
 // Put implements the ConnPool interface.
@@ -360,6 +469,10 @@ func (lp *LongPool) Put(conn net.Conn) error {
 func (lp *LongPool) Discard(conn net.Conn) error {
 	c, ok := conn.(*longConn)
 	if ok {
+		if p, ok := lp.peerMap.Load(netAddr{conn.RemoteAddr().Network(), c.address}); ok {
+			atomic.AddInt32(&p.(*peer).stats.active, -1)
+			p.(*peer).release()
+		}
 		return c.Close()
 	}
 	return conn.Close()
@@ -367,6 +480,7 @@ func (lp *LongPool) Discard(conn net.Conn) error {
 
 // Clean implements the LongConnPool interface.
 func (lp *LongPool) Clean(network, address string) {
+	network, address = NormalizeNetAddr(network, address)
 	na := netAddr{network, address}
 	if p, ok := lp.peerMap.Load(na); ok {
 		lp.peerMap.Delete(na)
@@ -379,8 +493,7 @@ func (lp *LongPool) Dump() interface{} {
 	m := make(map[string]interface{})
 	m[configDumpKey] = lp.idleConfig
 	lp.peerMap.Range(func(key, value interface{}) bool {
-		t := value.(*peer).pool.Dump()
-		m[key.(netAddr).String()] = t
+		m[key.(netAddr).String()] = value.(*peer).Dump()
 		return true
 	})
 	return m
@@ -400,6 +513,12 @@ func (lp *LongPool) Close() error {
 	})
 	// remove from the shared ticker
 	lp.sharedTicker.Delete(lp)
+	if lp.keepaliveTicker != nil {
+		lp.keepaliveTicker.Delete(lp.keepaliveTask)
+	}
+	if lp.statsTicker != nil {
+		lp.statsTicker.Delete(lp.statsTask)
+	}
 	return nil
 }
 
@@ -429,6 +548,8 @@ func (lp *LongPool) Evict() {
 // Tick implements the interface utils.TickerTask.
 func (lp *LongPool) Tick() {
 	lp.Evict()
+	lp.evictByPolicy()
+	lp.adjust()
 }
 
 // getPeer gets a peer from the pool based on the addr, or create a new one if not exist.
@@ -0,0 +1,164 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connpool
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultStatsInterval is how often snapshots are reported when
+// EnableStatsReporter gets a non-positive interval.
+const DefaultStatsInterval = 10 * time.Second
+
+// AddrSnapshot is the state of one address's connection pool at snapshot
+// time. Counters are cumulative since the pool was created.
+type AddrSnapshot struct {
+	ServiceName string `json:"service_name"`
+	Network     string `json:"network"`
+	Address     string `json:"address"`
+	// gauges
+	Idle   int `json:"idle"`
+	Active int `json:"active"`
+	// counters
+	Gets         int64 `json:"gets"`
+	Reuses       int64 `json:"reuses"`
+	Created      int64 `json:"created"`
+	DialFailures int64 `json:"dial_failures"`
+	Evicted      int64 `json:"evicted"`
+	// ReuseRatio is Reuses over Gets, 0 before the first Get.
+	ReuseRatio float64 `json:"reuse_ratio"`
+	// TLS handshake stats, nil unless AttachTLSDialer was called.
+	TLS *HandshakeStats `json:"tls,omitempty"`
+}
+
+// StatsReporter receives periodic per-address pool snapshots, one call per
+// interval with one entry per address. Implementations decide how to expose
+// them, e.g. as labeled gauges and counters of a metrics system.
+type StatsReporter interface {
+	ReportPoolStats(stats []AddrSnapshot)
+}
+
+// EnableStatsReporter reports a snapshot of every address's pool to r each
+// interval, DefaultStatsInterval when non-positive. Unlike the Reporter set
+// by EnableReporter, which sees individual connection events, r sees the
+// aggregated per-address state.
+// Like EnableReporter, call it right after NewLongPool, before the pool is used.
+func (lp *LongPool) EnableStatsReporter(r StatsReporter, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultStatsInterval
+	}
+	lp.statsTask = &statsTask{lp: lp, reporter: r}
+	lp.statsTicker = getSharedTicker(lp.statsTask, interval)
+}
+
+// statsTask adapts snapshot reporting to the utils.TickerTask interface.
+type statsTask struct {
+	lp       *LongPool
+	reporter StatsReporter
+}
+
+// Tick implements the interface utils.TickerTask.
+func (t *statsTask) Tick() {
+	t.reporter.ReportPoolStats(t.lp.Snapshot())
+}
+
+// Snapshot returns the current state of every address's pool.
+func (lp *LongPool) Snapshot() []AddrSnapshot {
+	var stats []AddrSnapshot
+	lp.peerMap.Range(func(key, value interface{}) bool {
+		s := value.(*peer).snapshot()
+		if lp.tlsDialer != nil {
+			if hs, ok := lp.tlsDialer.statsFor(s.Address); ok {
+				s.TLS = &hs
+			}
+		}
+		stats = append(stats, s)
+		return true
+	})
+	return stats
+}
+
+// AttachTLSDialer merges the dialer's per-address handshake stats into
+// Snapshot and the reports of EnableStatsReporter.
+// Like EnableReporter, call it right after NewLongPool, before the pool is used.
+func (lp *LongPool) AttachTLSDialer(d *TLSDialer) {
+	lp.tlsDialer = d
+}
+
+// peerStats holds the cumulative per-address counters behind AddrSnapshot,
+// all accessed atomically.
+type peerStats struct {
+	active    int32
+	gets      int64
+	reuses    int64
+	created   int64
+	dialFails int64
+	evicted   int64
+}
+
+func (p *peer) snapshot() AddrSnapshot {
+	s := AddrSnapshot{
+		ServiceName:  p.serviceName,
+		Network:      p.addr.Network(),
+		Address:      p.addr.String(),
+		Idle:         p.pool.Len(),
+		Active:       int(atomic.LoadInt32(&p.stats.active)),
+		Gets:         atomic.LoadInt64(&p.stats.gets),
+		Reuses:       atomic.LoadInt64(&p.stats.reuses),
+		Created:      atomic.LoadInt64(&p.stats.created),
+		DialFailures: atomic.LoadInt64(&p.stats.dialFails),
+		Evicted:      atomic.LoadInt64(&p.stats.evicted),
+	}
+	if s.Active < 0 {
+		// more connections returned than taken, e.g. put from another pool
+		s.Active = 0
+	}
+	if s.Gets > 0 {
+		s.ReuseRatio = float64(s.Reuses) / float64(s.Gets)
+	}
+	return s
+}
+
+// StatsCollector is a StatsReporter that retains the latest snapshots for
+// pull-style metric systems: register it with EnableStatsReporter and read
+// Snapshots from the scrape handler.
+type StatsCollector struct {
+	mu     sync.RWMutex
+	latest []AddrSnapshot
+}
+
+// NewStatsCollector creates a StatsCollector.
+func NewStatsCollector() *StatsCollector {
+	return &StatsCollector{}
+}
+
+// ReportPoolStats implements the StatsReporter interface.
+func (c *StatsCollector) ReportPoolStats(stats []AddrSnapshot) {
+	c.mu.Lock()
+	c.latest = stats
+	c.mu.Unlock()
+}
+
+// Snapshots returns the most recently reported snapshots.
+func (c *StatsCollector) Snapshots() []AddrSnapshot {
+	c.mu.RLock()
+	s := c.latest
+	c.mu.RUnlock()
+	return s
+}
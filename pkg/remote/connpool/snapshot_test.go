@@ -0,0 +1,87 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connpool
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	mocksnetpoll "github.com/cloudwego/kitex/internal/mocks/netpoll"
+	mocksremote "github.com/cloudwego/kitex/internal/mocks/remote"
+	"github.com/cloudwego/kitex/internal/test"
+	dialer "github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/utils"
+)
+
+func TestLongPoolStatsReporter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	lp := newLongPoolForTest(0, 2, 5, time.Second)
+	defer lp.Close()
+	collector := NewStatsCollector()
+	lp.EnableStatsReporter(collector, time.Hour) // reported by hand below
+
+	dialErr := errors.New("dial failed")
+	failDial := false
+	d := mocksremote.NewMockDialer(ctrl)
+	d.EXPECT().DialTimeout(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(network, address string, timeout time.Duration) (net.Conn, error) {
+		if failDial {
+			return nil, dialErr
+		}
+		na := utils.NewNetAddr(network, address)
+		conn := mocksnetpoll.NewMockConnection(ctrl)
+		conn.EXPECT().IsActive().Return(true).AnyTimes()
+		conn.EXPECT().RemoteAddr().Return(na).AnyTimes()
+		conn.EXPECT().Close().AnyTimes()
+		return conn, nil
+	}).AnyTimes()
+	opt := dialer.ConnOption{Dialer: d, ConnectTimeout: time.Second}
+
+	conn1, err := lp.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == nil)
+	conn2, err := lp.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == nil)
+	lp.Put(conn1)
+	conn3, err := lp.Get(context.TODO(), "tcp", mockAddr0, opt) // reuse
+	test.Assert(t, err == nil)
+	failDial = true
+	_, err = lp.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == dialErr)
+	lp.Discard(conn2)
+
+	lp.statsTask.Tick()
+	stats := collector.Snapshots()
+	test.Assert(t, len(stats) == 1, len(stats))
+	s := stats[0]
+	test.Assert(t, s.ServiceName == mockDestService)
+	test.Assert(t, s.Network == "tcp")
+	test.Assert(t, s.Address == mockAddr0)
+	test.Assert(t, s.Idle == 0)
+	test.Assert(t, s.Active == 1, s.Active) // conn3 is still out
+	test.Assert(t, s.Gets == 4, s.Gets)
+	test.Assert(t, s.Reuses == 1, s.Reuses)
+	test.Assert(t, s.Created == 2, s.Created)
+	test.Assert(t, s.DialFailures == 1, s.DialFailures)
+	test.Assert(t, s.ReuseRatio == 0.25, s.ReuseRatio)
+	lp.Put(conn3)
+}
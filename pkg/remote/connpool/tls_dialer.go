@@ -0,0 +1,153 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connpool
+
+import (
+	"crypto/tls"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudwego/kitex/pkg/remote"
+)
+
+// defaultSessionCacheSize is the capacity of the client session cache
+// installed when the tls.Config doesn't bring its own.
+const defaultSessionCacheSize = 256
+
+// HandshakeStats summarizes the TLS handshakes to one address.
+type HandshakeStats struct {
+	Address    string  `json:"address"`
+	Handshakes int64   `json:"handshakes"`
+	Resumed    int64   `json:"resumed"`
+	LastCostMs float64 `json:"last_cost_ms"`
+	AvgCostMs  float64 `json:"avg_cost_ms"`
+}
+
+// NewTLSDialer wraps a dialer with TLS. The config is used as-is except that
+// a shared client session cache is installed when it has none, so repeated
+// pool dials to the same address resume with session tickets or PSKs instead
+// of paying a full handshake each time. Per-address handshake timing is kept
+// and can be merged into the pool's stats reports with AttachTLSDialer.
+func NewTLSDialer(inner remote.Dialer, cfg *tls.Config) *TLSDialer {
+	cfg = cfg.Clone()
+	if cfg.ClientSessionCache == nil {
+		cfg.ClientSessionCache = tls.NewLRUClientSessionCache(defaultSessionCacheSize)
+	}
+	return &TLSDialer{inner: inner, cfg: cfg}
+}
+
+// TLSDialer is a remote.Dialer performing a TLS handshake on top of the
+// connections of an inner dialer, see NewTLSDialer.
+type TLSDialer struct {
+	inner    remote.Dialer
+	cfg      *tls.Config
+	statsMap sync.Map // address -> *handshakeStats
+}
+
+var _ remote.Dialer = &TLSDialer{}
+
+// DialTimeout implements the remote.Dialer interface. The timeout covers the
+// inner dial and the handshake together.
+func (d *TLSDialer) DialTimeout(network, address string, timeout time.Duration) (net.Conn, error) {
+	begin := time.Now()
+	conn, err := d.inner.DialTimeout(network, address, timeout)
+	if err != nil {
+		return nil, err
+	}
+	cfg := d.cfg
+	if cfg.ServerName == "" {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			host = address
+		}
+		cfg = cfg.Clone()
+		cfg.ServerName = host
+	}
+	tc := tls.Client(conn, cfg)
+	if timeout > 0 {
+		tc.SetDeadline(begin.Add(timeout))
+	}
+	if err := tc.Handshake(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if timeout > 0 {
+		tc.SetDeadline(time.Time{})
+	}
+	d.record(address, time.Since(begin), tc.ConnectionState().DidResume)
+	return tc, nil
+}
+
+// Stats returns the handshake stats per address.
+func (d *TLSDialer) Stats() []HandshakeStats {
+	var stats []HandshakeStats
+	d.statsMap.Range(func(key, value interface{}) bool {
+		stats = append(stats, value.(*handshakeStats).snapshot(key.(string)))
+		return true
+	})
+	return stats
+}
+
+// statsFor returns the handshake stats of one address if any were recorded.
+func (d *TLSDialer) statsFor(address string) (HandshakeStats, bool) {
+	if s, ok := d.statsMap.Load(address); ok {
+		return s.(*handshakeStats).snapshot(address), true
+	}
+	return HandshakeStats{}, false
+}
+
+func (d *TLSDialer) stats(address string) *handshakeStats {
+	if s, ok := d.statsMap.Load(address); ok {
+		return s.(*handshakeStats)
+	}
+	s, _ := d.statsMap.LoadOrStore(address, &handshakeStats{})
+	return s.(*handshakeStats)
+}
+
+func (d *TLSDialer) record(address string, cost time.Duration, resumed bool) {
+	s := d.stats(address)
+	atomic.AddInt64(&s.handshakes, 1)
+	if resumed {
+		atomic.AddInt64(&s.resumed, 1)
+	}
+	atomic.AddInt64(&s.totalNanos, int64(cost))
+	atomic.StoreInt64(&s.lastNanos, int64(cost))
+}
+
+// handshakeStats holds the cumulative counters behind HandshakeStats, all
+// accessed atomically.
+type handshakeStats struct {
+	handshakes int64
+	resumed    int64
+	totalNanos int64
+	lastNanos  int64
+}
+
+func (s *handshakeStats) snapshot(address string) HandshakeStats {
+	h := HandshakeStats{
+		Address:    address,
+		Handshakes: atomic.LoadInt64(&s.handshakes),
+		Resumed:    atomic.LoadInt64(&s.resumed),
+		LastCostMs: float64(atomic.LoadInt64(&s.lastNanos)) / 1e6,
+	}
+	if h.Handshakes > 0 {
+		h.AvgCostMs = float64(atomic.LoadInt64(&s.totalNanos)) / float64(h.Handshakes) / 1e6
+	}
+	return h
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connpool
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/remote"
+)
+
+// selfSignedCert generates a throwaway certificate for the test server.
+func selfSignedCert(t *testing.T) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.Assert(t, err == nil, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	test.Assert(t, err == nil, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestTLSDialerSessionResumption(t *testing.T) {
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{selfSignedCert(t)},
+		// session tickets ride along in the TLS 1.2 handshake; 1.3 delivers
+		// them post-handshake, which needs a read to observe
+		MaxVersion: tls.VersionTLS12,
+	})
+	test.Assert(t, err == nil, err)
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				conn.(*tls.Conn).Handshake()
+				_, _ = conn.Read(make([]byte, 1))
+				conn.Close()
+			}()
+		}
+	}()
+
+	d := NewTLSDialer(remote.NewDefaultDialer(), &tls.Config{
+		InsecureSkipVerify: true,
+		MaxVersion:         tls.VersionTLS12,
+	})
+	address := ln.Addr().String()
+
+	conn1, err := d.DialTimeout("tcp", address, time.Second)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, !conn1.(*tls.Conn).ConnectionState().DidResume)
+
+	// the second dial resumes with the cached session
+	conn2, err := d.DialTimeout("tcp", address, time.Second)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, conn2.(*tls.Conn).ConnectionState().DidResume)
+	conn1.Close()
+	conn2.Close()
+
+	stats := d.Stats()
+	test.Assert(t, len(stats) == 1, len(stats))
+	s := stats[0]
+	test.Assert(t, s.Address == address)
+	test.Assert(t, s.Handshakes == 2, s.Handshakes)
+	test.Assert(t, s.Resumed == 1, s.Resumed)
+	test.Assert(t, s.AvgCostMs > 0)
+	test.Assert(t, s.LastCostMs > 0)
+
+	// the handshake stats show up in the pool snapshots
+	lp := newLongPoolForTest(0, 2, 5, time.Second)
+	defer lp.Close()
+	lp.AttachTLSDialer(d)
+	conn3, err := lp.Get(context.TODO(), "tcp", address, remote.ConnOption{Dialer: d, ConnectTimeout: time.Second})
+	test.Assert(t, err == nil, err)
+	lp.Put(conn3)
+	snaps := lp.Snapshot()
+	test.Assert(t, len(snaps) == 1)
+	test.Assert(t, snaps[0].TLS != nil)
+	test.Assert(t, snaps[0].TLS.Handshakes == 3, snaps[0].TLS.Handshakes)
+	test.Assert(t, snaps[0].TLS.Resumed == 2)
+}
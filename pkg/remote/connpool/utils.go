@@ -18,9 +18,30 @@ package connpool
 
 import (
 	"net"
+	"strings"
 	"sync/atomic"
 )
 
+// NormalizeNetAddr canonicalizes a dial target so the same endpoint always
+// maps to the same pool entry. An empty network defaults to "tcp" unless the
+// address looks like a unix socket path, and Linux abstract socket addresses
+// written with a leading NUL are rewritten to the "@" form the net package
+// prints, matching what conn.RemoteAddr() reports back.
+func NormalizeNetAddr(network, address string) (string, string) {
+	switch network {
+	case "unix", "unixgram", "unixpacket":
+		if strings.HasPrefix(address, "\x00") {
+			address = "@" + address[1:]
+		}
+	case "":
+		if strings.HasPrefix(address, "/") || strings.HasPrefix(address, "@") || strings.HasPrefix(address, "\x00") {
+			return NormalizeNetAddr("unix", address)
+		}
+		network = "tcp"
+	}
+	return network, address
+}
+
 // ConnWithPkgSize wraps a connection and records the bytes read or written through it.
 type ConnWithPkgSize struct {
 	net.Conn
@@ -0,0 +1,90 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connpool
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/remote"
+)
+
+func TestNormalizeNetAddr(t *testing.T) {
+	// tcp stays as-is
+	network, address := NormalizeNetAddr("tcp", "127.0.0.1:8000")
+	test.Assert(t, network == "tcp" && address == "127.0.0.1:8000")
+
+	// empty network defaults by address shape
+	network, address = NormalizeNetAddr("", "127.0.0.1:8000")
+	test.Assert(t, network == "tcp" && address == "127.0.0.1:8000")
+	network, address = NormalizeNetAddr("", "/tmp/kitex.sock")
+	test.Assert(t, network == "unix" && address == "/tmp/kitex.sock")
+
+	// abstract sockets: the NUL form becomes the printable "@" form
+	network, address = NormalizeNetAddr("unix", "\x00kitex")
+	test.Assert(t, network == "unix" && address == "@kitex")
+	network, address = NormalizeNetAddr("", "@kitex")
+	test.Assert(t, network == "unix" && address == "@kitex")
+	network, address = NormalizeNetAddr("unixpacket", "\x00kitex")
+	test.Assert(t, network == "unixpacket" && address == "@kitex")
+}
+
+// unixEchoServer serves one-shot accepts on a unix socket until closed.
+func unixEchoServer(t *testing.T, address string) net.Listener {
+	ln, err := net.Listen("unix", address)
+	test.Assert(t, err == nil, err)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() { defer conn.Close(); _, _ = conn.Read(make([]byte, 1)) }()
+		}
+	}()
+	return ln
+}
+
+func TestPoolsUnixSocket(t *testing.T) {
+	address := filepath.Join(t.TempDir(), "kitex.sock")
+	ln := unixEchoServer(t, address)
+	defer ln.Close()
+	opt := remote.ConnOption{Dialer: remote.NewDefaultDialer(), ConnectTimeout: time.Second}
+
+	// short pool
+	sp := NewShortPool(mockDestService)
+	conn, err := sp.Get(context.TODO(), "unix", address, opt)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, conn.RemoteAddr().Network() == "unix")
+	sp.Put(conn)
+
+	// long pool: the connection round-trips through the idle list
+	lp := newLongPoolForTest(0, 2, 5, time.Second)
+	defer lp.Close()
+	conn1, err := lp.Get(context.TODO(), "unix", address, opt)
+	test.Assert(t, err == nil, err)
+	lp.Put(conn1)
+	conn2, err := lp.Get(context.TODO(), "unix", address, opt)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, conn2.(*longConn).Conn == conn1.(*longConn).Conn)
+	lp.Put(conn2)
+	lp.Clean("unix", address)
+}
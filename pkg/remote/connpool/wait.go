@@ -0,0 +1,170 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connpool
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultMaxWaitTimeout bounds how long Get blocks for a freed connection
+// when WaitConfig.MaxWaitTimeout is not set.
+const DefaultMaxWaitTimeout = time.Second
+
+// ErrConnWaitTimeout is returned by Get when the per-address connection cap
+// is reached and no connection is freed within the wait timeout.
+var ErrConnWaitTimeout = errors.New("connection pool exhausted: timed out waiting for a free connection")
+
+// ErrConnLimitPerAddress is returned by Get when
+// IdleConfig.MaxActivePerAddress is reached and waiting is not enabled.
+var ErrConnLimitPerAddress = errors.New("connection pool exhausted: per-address active connection limit reached")
+
+// WaitConfig configures EnableWait.
+type WaitConfig struct {
+	// MaxConnPerAddress is the hard cap on connections (idle and in-use) to
+	// one downstream address; when 0 it falls back to the IdleConfig's
+	// MaxActivePerAddress, then MaxIdlePerAddress.
+	MaxConnPerAddress int
+	// MaxWaitTimeout is how long Get may block for a freed connection once
+	// the cap is reached, DefaultMaxWaitTimeout when 0.
+	MaxWaitTimeout time.Duration
+}
+
+// EnableWait turns on strict connection capping: once MaxConnPerAddress
+// connections to an address are out, Get blocks up to MaxWaitTimeout for one
+// to be returned instead of dialing another, and fails with
+// ErrConnWaitTimeout if none is. Every connection taken from the pool must be
+// given back through Put or Discard, or its slot leaks.
+// Like EnableReporter, call it right after NewLongPool, before the pool is used.
+func (lp *LongPool) EnableWait(cfg WaitConfig) {
+	if cfg.MaxConnPerAddress <= 0 {
+		cfg.MaxConnPerAddress = lp.idleConfig.MaxActivePerAddress
+	}
+	if cfg.MaxConnPerAddress <= 0 {
+		cfg.MaxConnPerAddress = lp.idleConfig.MaxIdlePerAddress
+	}
+	if cfg.MaxConnPerAddress < 1 {
+		cfg.MaxConnPerAddress = 1
+	}
+	if cfg.MaxWaitTimeout <= 0 {
+		cfg.MaxWaitTimeout = DefaultMaxWaitTimeout
+	}
+	lp.waitCfg = &cfg
+}
+
+// WaitDump reports the wait stats of one peer, see LongPool.Dump.
+type WaitDump struct {
+	Cap       int     `json:"cap"`
+	Waiters   int     `json:"waiters"`
+	Waits     int64   `json:"waits"`
+	Timeouts  int64   `json:"timeouts"`
+	AvgWaitMs float64 `json:"avg_wait_ms"`
+	MaxWaitMs float64 `json:"max_wait_ms"`
+}
+
+// waitPoolDump is the per-address Dump entry when EnableWait is on.
+type waitPoolDump struct {
+	PoolDump
+	Wait WaitDump `json:"wait"`
+}
+
+// waitStats counts Get calls that had to queue for a free slot.
+type waitStats struct {
+	mu        sync.Mutex
+	waiters   int
+	waits     int64
+	timeouts  int64
+	totalWait time.Duration
+	maxWait   time.Duration
+}
+
+func (s *waitStats) enqueue() {
+	s.mu.Lock()
+	s.waiters++
+	s.mu.Unlock()
+}
+
+func (s *waitStats) dequeue(waited time.Duration, timedOut bool) {
+	s.mu.Lock()
+	s.waiters--
+	s.waits++
+	if timedOut {
+		s.timeouts++
+	}
+	s.totalWait += waited
+	if waited > s.maxWait {
+		s.maxWait = waited
+	}
+	s.mu.Unlock()
+}
+
+func (s *waitStats) dump(capacity int) WaitDump {
+	s.mu.Lock()
+	d := WaitDump{
+		Cap:      capacity,
+		Waiters:  s.waiters,
+		Waits:    s.waits,
+		Timeouts: s.timeouts,
+	}
+	if s.waits > 0 {
+		d.AvgWaitMs = float64(s.totalWait.Microseconds()) / float64(s.waits) / 1e3
+	}
+	d.MaxWaitMs = float64(s.maxWait.Microseconds()) / 1e3
+	s.mu.Unlock()
+	return d
+}
+
+// acquire takes a connection slot, blocking up to the wait timeout once the
+// cap is reached. A no-op unless EnableWait was called.
+func (p *peer) acquire() error {
+	if p.sem == nil {
+		return nil
+	}
+	select {
+	case p.sem <- struct{}{}:
+		return nil
+	default:
+	}
+	if p.waitTimeout <= 0 {
+		// MaxActivePerAddress without EnableWait: fail fast
+		return ErrConnLimitPerAddress
+	}
+	begin := time.Now()
+	p.waitStats.enqueue()
+	t := time.NewTimer(p.waitTimeout)
+	defer t.Stop()
+	select {
+	case p.sem <- struct{}{}:
+		p.waitStats.dequeue(time.Since(begin), false)
+		return nil
+	case <-t.C:
+		p.waitStats.dequeue(time.Since(begin), true)
+		return ErrConnWaitTimeout
+	}
+}
+
+// release frees a connection slot taken by acquire.
+func (p *peer) release() {
+	if p.sem == nil {
+		return
+	}
+	select {
+	case <-p.sem:
+	default:
+	}
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connpool
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	mocksnetpoll "github.com/cloudwego/kitex/internal/mocks/netpoll"
+	mocksremote "github.com/cloudwego/kitex/internal/mocks/remote"
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/connpool"
+	dialer "github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/utils"
+)
+
+func TestLongPoolWait(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	lp := newLongPoolForTest(0, 2, 5, time.Second)
+	defer lp.Close()
+	lp.EnableWait(WaitConfig{MaxConnPerAddress: 1, MaxWaitTimeout: 50 * time.Millisecond})
+
+	d := mocksremote.NewMockDialer(ctrl)
+	d.EXPECT().DialTimeout(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(network, address string, timeout time.Duration) (net.Conn, error) {
+		na := utils.NewNetAddr(network, address)
+		conn := mocksnetpoll.NewMockConnection(ctrl)
+		conn.EXPECT().IsActive().Return(true).AnyTimes()
+		conn.EXPECT().RemoteAddr().Return(na).AnyTimes()
+		conn.EXPECT().Close().AnyTimes()
+		return conn, nil
+	}).AnyTimes()
+	opt := dialer.ConnOption{Dialer: d, ConnectTimeout: time.Second}
+
+	conn1, err := lp.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == nil)
+
+	// the only slot is out: Get blocks and times out
+	begin := time.Now()
+	_, err = lp.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == ErrConnWaitTimeout, err)
+	test.Assert(t, time.Since(begin) >= 50*time.Millisecond)
+
+	// a Put while waiting frees the slot and the queued Get reuses the conn
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		lp.Put(conn1)
+	}()
+	conn2, err := lp.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == nil)
+	test.Assert(t, conn2.(*longConn).Conn == conn1.(*longConn).Conn)
+
+	// Discard frees the slot as well
+	lp.Discard(conn2)
+	conn3, err := lp.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == nil)
+	lp.Put(conn3)
+
+	// the wait stats made it into the dump
+	dump := lp.Dump().(map[string]interface{})
+	wd, ok := dump[mockAddr0].(waitPoolDump)
+	test.Assert(t, ok)
+	test.Assert(t, wd.Wait.Cap == 1)
+	test.Assert(t, wd.Wait.Waits == 2, wd.Wait.Waits)
+	test.Assert(t, wd.Wait.Timeouts == 1, wd.Wait.Timeouts)
+	test.Assert(t, wd.Wait.Waiters == 0)
+	test.Assert(t, wd.Wait.MaxWaitMs >= wd.Wait.AvgWaitMs)
+}
+
+func TestLongPoolMaxActivePerAddress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	lp := NewLongPool(mockDestService, connpool.IdleConfig{
+		MaxIdlePerAddress:   2,
+		MaxActivePerAddress: 2,
+		MaxIdleGlobal:       5,
+		MaxIdleTimeout:      time.Second,
+	})
+	defer lp.Close()
+
+	d := mocksremote.NewMockDialer(ctrl)
+	d.EXPECT().DialTimeout(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(network, address string, timeout time.Duration) (net.Conn, error) {
+		na := utils.NewNetAddr(network, address)
+		conn := mocksnetpoll.NewMockConnection(ctrl)
+		conn.EXPECT().IsActive().Return(true).AnyTimes()
+		conn.EXPECT().RemoteAddr().Return(na).AnyTimes()
+		conn.EXPECT().Close().AnyTimes()
+		return conn, nil
+	}).AnyTimes()
+	opt := dialer.ConnOption{Dialer: d, ConnectTimeout: time.Second}
+
+	conn1, err := lp.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == nil)
+	conn2, err := lp.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == nil)
+
+	// the cap is reached: no waiting configured, so Get fails right away
+	_, err = lp.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == ErrConnLimitPerAddress, err)
+
+	// the limit is per address
+	connOther, err := lp.Get(context.TODO(), "tcp", mockAddr1, opt)
+	test.Assert(t, err == nil)
+	lp.Put(connOther)
+
+	// returning a connection frees a slot
+	lp.Put(conn1)
+	conn3, err := lp.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == nil)
+	lp.Put(conn2)
+	lp.Put(conn3)
+}
@@ -17,6 +17,7 @@
 package remote
 
 import (
+	"context"
 	"net"
 	"time"
 )
@@ -26,6 +27,29 @@ type Dialer interface {
 	DialTimeout(network, address string, timeout time.Duration) (net.Conn, error)
 }
 
+// ContextDialer is a dialer driven by a context, it is the common shape of
+// proxy dialers such as SOCKS5 or HTTP CONNECT ones.
+// Use NewDialerWithContext to plug it into places expecting a Dialer.
+type ContextDialer interface {
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// NewDialerWithContext converts a ContextDialer into a Dialer.
+// The connect timeout is delivered to the ContextDialer as a context deadline.
+func NewDialerWithContext(d ContextDialer) Dialer {
+	return &SynthesizedDialer{
+		DialFunc: func(network, address string, timeout time.Duration) (net.Conn, error) {
+			ctx := context.Background()
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+			return d.DialContext(ctx, network, address)
+		},
+	}
+}
+
 // NewDefaultDialer is used to create a default dialer.
 func NewDefaultDialer() Dialer {
 	return &SynthesizedDialer{
@@ -90,6 +90,10 @@ type ServerOption struct {
 
 	ReusePort bool
 
+	// SocketOptions tunes the kernel options of the listen socket,
+	// see server.WithSocketOptions.
+	SocketOptions *SockOpts
+
 	// Duration that server waits for to allow any existing connection to be closed gracefully.
 	ExitWaitTime time.Duration
 
@@ -113,6 +117,18 @@ type ServerOption struct {
 
 	GRPCUnknownServiceHandler func(ctx context.Context, method string, stream streaming.Stream) error
 
+	// HeaderInspectors run after the transport headers are decoded but before
+	// the payload is decoded, see server.WithHeaderInspector.
+	HeaderInspectors []HeaderInspector
+
+	// SyncHandler processes requests inline on the I/O goroutine instead of
+	// spawning a goroutine per request, see server.WithSyncHandler.
+	SyncHandler bool
+
+	// SyncHandlerWatchdog is the duration after which a handler still running
+	// inline is reported for blocking the I/O goroutine, 0 to disable.
+	SyncHandlerWatchdog time.Duration
+
 	// TTHeaderStreaming
 	TTHeaderStreamingOptions TTHeaderStreamingOptions
 
@@ -123,6 +139,33 @@ type ServerOption struct {
 	CompatibleMiddlewareForUnary bool
 }
 
+// SockOpts is the set of socket options applied to the server listen socket.
+// Buffer sizes and TCP_DEFER_ACCEPT are set on the listening FD, so buffer
+// sizes are inherited by accepted connections on both the netpoll and gonet
+// transports. TCPNoDelay and KeepAliveInterval are applied per accepted
+// connection where the transport hands out *net.TCPConn (gonet); netpoll
+// manages TCP_NODELAY and keep-alive on its own.
+type SockOpts struct {
+	// SendBuf sets SO_SNDBUF in bytes, 0 to keep the kernel default.
+	SendBuf int
+	// RecvBuf sets SO_RCVBUF in bytes, 0 to keep the kernel default.
+	RecvBuf int
+	// TCPNoDelay disables Nagle's algorithm; note Go enables it by default.
+	TCPNoDelay bool
+	// KeepAliveInterval sets the TCP keep-alive period of accepted connections.
+	KeepAliveInterval time.Duration
+	// DeferAccept sets TCP_DEFER_ACCEPT so connections are only delivered to
+	// accept once data arrives; ignored on platforms without it.
+	DeferAccept bool
+}
+
+// HeaderInspector inspects a request after its transport headers are decoded
+// and before its payload is decoded. method is the target method name and
+// strInfo holds the string key-value pairs carried by the transport header;
+// both may be empty if the protocol does not carry them. Returning a non-nil
+// error rejects the request without decoding the payload.
+type HeaderInspector func(ctx context.Context, method string, strInfo map[string]string) error
+
 // ClientOption is used to init the remote client.
 type ClientOption struct {
 	SvcInfo *serviceinfo.ServiceInfo
@@ -0,0 +1,103 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package proxydialer provides remote.ContextDialer implementations which
+// connect through egress proxies, to be used with client.WithContextDialer.
+package proxydialer
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/proxy"
+
+	"github.com/cloudwego/kitex/pkg/remote"
+)
+
+// NewSOCKS5Dialer creates a ContextDialer connecting through the SOCKS5
+// proxy at proxyAddr. user may be empty to disable authentication.
+func NewSOCKS5Dialer(proxyAddr, user, password string) (remote.ContextDialer, error) {
+	var auth *proxy.Auth
+	if user != "" {
+		auth = &proxy.Auth{User: user, Password: password}
+	}
+	d, err := proxy.SOCKS5("tcp", proxyAddr, auth, &net.Dialer{})
+	if err != nil {
+		return nil, err
+	}
+	return d.(proxy.ContextDialer), nil
+}
+
+// NewHTTPConnectDialer creates a ContextDialer tunneling connections through
+// the HTTP proxy at proxyAddr with the CONNECT method.
+// header may carry extra headers, e.g. Proxy-Authorization, and may be nil.
+func NewHTTPConnectDialer(proxyAddr string, header http.Header) remote.ContextDialer {
+	return &httpConnectDialer{proxyAddr: proxyAddr, header: header}
+}
+
+type httpConnectDialer struct {
+	proxyAddr string
+	header    http.Header
+	dialer    net.Dialer
+}
+
+func (d *httpConnectDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	conn, err := d.dialer.DialContext(ctx, "tcp", d.proxyAddr)
+	if err != nil {
+		return nil, err
+	}
+	if ddl, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(ddl)
+	}
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: address},
+		Host:   address,
+		Header: d.header,
+	}
+	if err = req.Write(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("proxydialer: CONNECT %s via %s: %s", address, d.proxyAddr, resp.Status)
+	}
+	_ = conn.SetDeadline(time.Time{})
+	// bytes sent right after the CONNECT response may sit in the bufio buffer
+	return &bufferedConn{Conn: conn, br: br}, nil
+}
+
+type bufferedConn struct {
+	net.Conn
+	br *bufio.Reader
+}
+
+func (bc *bufferedConn) Read(b []byte) (int, error) {
+	return bc.br.Read(b)
+}
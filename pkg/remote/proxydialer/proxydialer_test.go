@@ -0,0 +1,92 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package proxydialer
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/remote"
+)
+
+func TestNewSOCKS5Dialer(t *testing.T) {
+	d, err := NewSOCKS5Dialer("127.0.0.1:1080", "user", "pass")
+	test.Assert(t, err == nil, err)
+	test.Assert(t, d != nil)
+}
+
+func TestHTTPConnectDialer(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	test.Assert(t, err == nil, err)
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		req, err := http.ReadRequest(bufio.NewReader(conn))
+		if err != nil || req.Method != http.MethodConnect {
+			return
+		}
+		conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+		conn.Write([]byte("hello"))
+	}()
+
+	d := NewHTTPConnectDialer(ln.Addr().String(), nil)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	conn, err := d.DialContext(ctx, "tcp", "downstream:9999")
+	test.Assert(t, err == nil, err)
+	defer conn.Close()
+	buf := make([]byte, 5)
+	_, err = conn.Read(buf)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, string(buf) == "hello")
+
+	// the Dialer adapter delivers the timeout as a context deadline
+	dialer := remote.NewDialerWithContext(d)
+	test.Assert(t, dialer != nil)
+}
+
+func TestHTTPConnectDialerRejected(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	test.Assert(t, err == nil, err)
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if _, err := http.ReadRequest(bufio.NewReader(conn)); err != nil {
+			return
+		}
+		conn.Write([]byte("HTTP/1.1 403 Forbidden\r\n\r\n"))
+	}()
+
+	d := NewHTTPConnectDialer(ln.Addr().String(), nil)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err = d.DialContext(ctx, "tcp", "downstream:9999")
+	test.Assert(t, err != nil)
+}
@@ -112,7 +112,9 @@ func (t *svrTransHandler) Read(ctx context.Context, conn net.Conn, recvMsg remot
 				ctx, tags = t.opt.ProfilerTransInfoTagging(ctx, recvMsg)
 				ctx = t.opt.Profiler.Tag(ctx, tags...)
 			}
-			err = codec.DecodePayload(ctx, recvMsg, bufReader)
+			if err = t.inspectHeaders(ctx, recvMsg); err == nil {
+				err = codec.DecodePayload(ctx, recvMsg, bufReader)
+			}
 		}
 	} else {
 		err = t.codec.Decode(ctx, recvMsg, bufReader)
@@ -124,6 +126,25 @@ func (t *svrTransHandler) Read(ctx context.Context, conn net.Conn, recvMsg remot
 	return ctx, nil
 }
 
+// inspectHeaders runs the registered HeaderInspectors against the decoded
+// header, so junk traffic can be rejected before its payload is decoded.
+func (t *svrTransHandler) inspectHeaders(ctx context.Context, recvMsg remote.Message) error {
+	if len(t.opt.HeaderInspectors) == 0 {
+		return nil
+	}
+	method := recvMsg.RPCInfo().Invocation().MethodName()
+	var strInfo map[string]string
+	if ti := recvMsg.TransInfo(); ti != nil {
+		strInfo = ti.TransStrInfo()
+	}
+	for _, f := range t.opt.HeaderInspectors {
+		if err := f(ctx, method, strInfo); err != nil {
+			return remote.NewTransError(remote.InvalidProtocol, err)
+		}
+	}
+	return nil
+}
+
 func (t *svrTransHandler) newCtxWithRPCInfo(ctx context.Context, conn net.Conn) (context.Context, rpcinfo.RPCInfo) {
 	var ri rpcinfo.RPCInfo
 	if rpcinfo.PoolEnabled() { // reuse per-connection rpcinfo
@@ -321,3 +321,81 @@ func TestSvrTransHandlerOnReadHeartbeat(t *testing.T) {
 	err = svrHandler.OnRead(ctx, &mocks.Conn{})
 	test.Assert(t, err == nil)
 }
+
+type mockMetaCodec struct {
+	MockCodec
+	DecodeMetaFunc    func(ctx context.Context, msg remote.Message, in remote.ByteBuffer) error
+	DecodePayloadFunc func(ctx context.Context, msg remote.Message, in remote.ByteBuffer) error
+}
+
+func (m *mockMetaCodec) DecodeMeta(ctx context.Context, msg remote.Message, in remote.ByteBuffer) error {
+	return m.DecodeMetaFunc(ctx, msg, in)
+}
+
+func (m *mockMetaCodec) DecodePayload(ctx context.Context, msg remote.Message, in remote.ByteBuffer) error {
+	return m.DecodePayloadFunc(ctx, msg, in)
+}
+
+type mockTransInfo struct {
+	remote.TransInfo
+	strInfo map[string]string
+}
+
+func (m *mockTransInfo) TransStrInfo() map[string]string { return m.strInfo }
+
+func TestSvrTransHandlerHeaderInspector(t *testing.T) {
+	buf := remote.NewReaderWriterBuffer(1024)
+	ext := &MockExtension{
+		NewReadByteBufferFunc: func(ctx context.Context, conn net.Conn, msg remote.Message) remote.ByteBuffer {
+			return buf
+		},
+	}
+	payloadDecoded := 0
+	mockErr := errors.New("mock reject")
+	var allow bool
+	opt := &remote.ServerOption{
+		Codec: &mockMetaCodec{
+			DecodeMetaFunc: func(ctx context.Context, msg remote.Message, in remote.ByteBuffer) error {
+				return nil
+			},
+			DecodePayloadFunc: func(ctx context.Context, msg remote.Message, in remote.ByteBuffer) error {
+				payloadDecoded++
+				return nil
+			},
+		},
+		SvcSearcher:   svcSearcher,
+		TargetSvcInfo: svcInfo,
+		HeaderInspectors: []remote.HeaderInspector{
+			func(ctx context.Context, method string, strInfo map[string]string) error {
+				test.Assert(t, method == mocks.MockMethod, method)
+				if !allow && strInfo["token"] != "valid" {
+					return mockErr
+				}
+				return nil
+			},
+		},
+	}
+	handler, err := NewDefaultSvrTransHandler(opt, ext)
+	test.Assert(t, err == nil)
+
+	ri := rpcinfo.NewRPCInfo(rpcinfo.EmptyEndpointInfo(), rpcinfo.FromBasicInfo(&rpcinfo.EndpointBasicInfo{}),
+		rpcinfo.NewInvocation("", mocks.MockMethod), nil, rpcinfo.NewRPCStats())
+	tags := make(map[string]interface{})
+	msg := &MockMessage{
+		RPCInfoFunc:   func() rpcinfo.RPCInfo { return ri },
+		TransInfoFunc: func() remote.TransInfo { return &mockTransInfo{strInfo: map[string]string{"token": "junk"}} },
+		TagsFunc:      func() map[string]interface{} { return tags },
+	}
+
+	// rejected before payload decode
+	_, err = handler.Read(context.Background(), &mocks.Conn{}, msg)
+	test.Assert(t, err != nil)
+	test.Assert(t, errors.Is(err, mockErr))
+	test.Assert(t, payloadDecoded == 0, payloadDecoded)
+
+	// accepted requests fall through to payload decode
+	allow = true
+	_, err = handler.Read(context.Background(), &mocks.Conn{}, msg)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, payloadDecoded == 1, payloadDecoded)
+}
@@ -69,6 +69,14 @@ func (f *svrTransHandlerFactory) NewTransHandler(opt *remote.ServerOption) (remo
 		}
 		t.registered = append(t.registered, handler)
 	}
+	// custom protocols registered via RegisterSniffFactory are matched after the builtin ones
+	for i := range sniffFactories {
+		h, err := sniffFactories[i].NewTransHandler(opt)
+		if err != nil {
+			return nil, err
+		}
+		t.registered = append(t.registered, h.(DetectableServerTransHandler))
+	}
 	if t.defaultHandler, err = f.defaultHandlerFactory.NewTransHandler(opt); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,109 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package detection
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/cloudwego/netpoll"
+
+	"github.com/cloudwego/kitex/pkg/endpoint"
+	"github.com/cloudwego/kitex/pkg/remote"
+)
+
+// SniffFunc reports whether the leading bytes of a new connection belong to
+// the protocol. header holds the bytes peeked from the connection without
+// consuming them, so a later handler can still read the full request.
+type SniffFunc func(header []byte) bool
+
+var sniffFactories []remote.ServerTransHandlerFactory
+
+// RegisterSniffFactory registers a trans handler factory for a bespoke
+// protocol so it can share a port with the builtin protocols. sniff is called
+// with the first headerLen bytes of each new connection, and the handlers
+// built by factory take over the connections it claims. Builtin protocols are
+// matched first; registered factories are tried in registration order. It is
+// not thread-safe and is intended to be called from init functions.
+func RegisterSniffFactory(sniff SniffFunc, headerLen int, factory remote.ServerTransHandlerFactory) {
+	sniffFactories = append(sniffFactories, NewSniffSvrTransHandlerFactory(sniff, headerLen, factory))
+}
+
+// NewSniffSvrTransHandlerFactory wraps factory so that the handlers it builds
+// implement DetectableServerTransHandler, matching connections whose first
+// headerLen bytes satisfy sniff. The result can be passed to
+// NewSvrTransHandlerFactory when assembling a detection handler manually.
+func NewSniffSvrTransHandlerFactory(sniff SniffFunc, headerLen int, factory remote.ServerTransHandlerFactory) remote.ServerTransHandlerFactory {
+	return &sniffHandlerFactory{sniff: sniff, headerLen: headerLen, factory: factory}
+}
+
+type sniffHandlerFactory struct {
+	sniff     SniffFunc
+	headerLen int
+	factory   remote.ServerTransHandlerFactory
+}
+
+func (f *sniffHandlerFactory) NewTransHandler(opt *remote.ServerOption) (remote.ServerTransHandler, error) {
+	h, err := f.factory.NewTransHandler(opt)
+	if err != nil {
+		return nil, err
+	}
+	return &sniffTransHandler{ServerTransHandler: h, sniff: f.sniff, headerLen: f.headerLen}, nil
+}
+
+type sniffTransHandler struct {
+	remote.ServerTransHandler
+	sniff     SniffFunc
+	headerLen int
+}
+
+var _ DetectableServerTransHandler = &sniffTransHandler{}
+
+func (t *sniffTransHandler) ProtocolMatch(ctx context.Context, conn net.Conn) error {
+	// FIXME: should not rely on netpoll.Reader
+	if withReader, ok := conn.(interface{ Reader() netpoll.Reader }); ok {
+		if npReader := withReader.Reader(); npReader != nil {
+			// read at most avoid block
+			header, err := npReader.Peek(t.headerLen)
+			if err != nil {
+				return err
+			}
+			if t.sniff(header) {
+				return nil
+			}
+		}
+	}
+	return errors.New("error protocol not match")
+}
+
+// SetInvokeHandleFunc and GracefulShutdown forward to the wrapped handler;
+// the promoted interface methods would hide its implementations from the
+// type assertions in svrTransHandler.
+
+func (t *sniffTransHandler) SetInvokeHandleFunc(inkHdlFunc endpoint.Endpoint) {
+	if s, ok := t.ServerTransHandler.(remote.InvokeHandleFuncSetter); ok {
+		s.SetInvokeHandleFunc(inkHdlFunc)
+	}
+}
+
+func (t *sniffTransHandler) GracefulShutdown(ctx context.Context) error {
+	if g, ok := t.ServerTransHandler.(remote.GracefulShutdown); ok {
+		return g.GracefulShutdown(ctx)
+	}
+	return nil
+}
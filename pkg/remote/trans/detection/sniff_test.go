@@ -0,0 +1,112 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package detection
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/cloudwego/kitex/internal/mocks"
+	npmocks "github.com/cloudwego/kitex/internal/mocks/netpoll"
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/remote/trans/netpoll"
+)
+
+var mockMagic = []byte("MOCK")
+
+func mockSniff(header []byte) bool {
+	return len(header) >= len(mockMagic) && bytes.Equal(header[:len(mockMagic)], mockMagic)
+}
+
+type mockHandlerFactory struct {
+	handler remote.ServerTransHandler
+}
+
+func (f *mockHandlerFactory) NewTransHandler(opt *remote.ServerOption) (remote.ServerTransHandler, error) {
+	return f.handler, nil
+}
+
+func TestSniffTransHandlerProtocolMatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	subHandler := &mocks.MockSvrTransHandler{}
+	factory := NewSniffSvrTransHandlerFactory(mockSniff, len(mockMagic), &mockHandlerFactory{handler: subHandler})
+	transHdlr, err := factory.NewTransHandler(&remote.ServerOption{})
+	test.Assert(t, err == nil, err)
+	detectableHdlr, ok := transHdlr.(DetectableServerTransHandler)
+	test.Assert(t, ok)
+
+	npConn := npmocks.NewMockConnection(ctrl)
+	npReader := npmocks.NewMockReader(ctrl)
+	npConn.EXPECT().Reader().Return(npReader).AnyTimes()
+
+	// case1 the header carries the magic: matched
+	npReader.EXPECT().Peek(len(mockMagic)).Return(mockMagic, nil)
+	err = detectableHdlr.ProtocolMatch(context.Background(), npConn)
+	test.Assert(t, err == nil, err)
+
+	// case2 another protocol: not matched
+	npReader.EXPECT().Peek(len(mockMagic)).Return([]byte("GET "), nil)
+	err = detectableHdlr.ProtocolMatch(context.Background(), npConn)
+	test.Assert(t, err != nil)
+
+	// case3 not a netpoll connection: not matched
+	err = detectableHdlr.ProtocolMatch(context.Background(), &mocks.Conn{})
+	test.Assert(t, err != nil)
+}
+
+func TestRegisterSniffFactory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		sniffFactories = nil
+		ctrl.Finish()
+	}()
+
+	onReadCalled := false
+	subHandler := &mocks.MockSvrTransHandler{
+		OnReadFunc: func(ctx context.Context, conn net.Conn) error {
+			onReadCalled = true
+			return nil
+		},
+	}
+	RegisterSniffFactory(mockSniff, len(mockMagic), &mockHandlerFactory{handler: subHandler})
+
+	transHdlr, err := NewSvrTransHandlerFactory(netpoll.NewSvrTransHandlerFactory()).NewTransHandler(&remote.ServerOption{
+		SvcSearcher:   svcSearcher,
+		TargetSvcInfo: svcInfo,
+	})
+	test.Assert(t, err == nil, err)
+	test.Assert(t, len(transHdlr.(*svrTransHandler).registered) == 1)
+	transHdlr.(*svrTransHandler).defaultHandler = &mocks.MockSvrTransHandler{}
+
+	npConn := npmocks.NewMockConnection(ctrl)
+	npReader := npmocks.NewMockReader(ctrl)
+	npConn.EXPECT().Reader().Return(npReader).AnyTimes()
+	npConn.EXPECT().RemoteAddr().Return(nil).AnyTimes()
+	npReader.EXPECT().Peek(len(mockMagic)).Return(mockMagic, nil).AnyTimes()
+
+	// the registered handler claims the connection and serves the reads
+	err = mockCall(transHdlr, npConn)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, onReadCalled)
+}
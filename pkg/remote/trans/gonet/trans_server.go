@@ -90,6 +90,11 @@ func (ts *transServer) BootstrapServer(ln net.Listener) error {
 			klog.Errorf("KITEX: BootstrapServer accept failed, err=%s", err.Error())
 			return err
 		}
+		if so := ts.opt.SocketOptions; so != nil {
+			if tc, ok := conn.(*net.TCPConn); ok {
+				tc.SetNoDelay(so.TCPNoDelay)
+			}
+		}
 		go ts.serveConn(context.Background(), conn)
 	}
 }
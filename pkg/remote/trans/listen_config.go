@@ -21,6 +21,7 @@ package trans
 
 import (
 	"net"
+	"strings"
 	"syscall"
 
 	"golang.org/x/sys/unix"
@@ -30,10 +31,15 @@ import (
 
 // NewListenConfig return an new net.ListenConfig.
 func NewListenConfig(opt *remote.ServerOption) net.ListenConfig {
-	return net.ListenConfig{
+	lncfg := net.ListenConfig{
 		Control: func(network, address string, c syscall.RawConn) error {
 			var err error
 			c.Control(func(fd uintptr) {
+				if so := opt.SocketOptions; so != nil {
+					if err = applySockOpts(fd, network, so); err != nil {
+						return
+					}
+				}
 				if !opt.ReusePort {
 					return
 				}
@@ -73,4 +79,30 @@ func NewListenConfig(opt *remote.ServerOption) net.ListenConfig {
 			return err
 		},
 	}
+	if so := opt.SocketOptions; so != nil && so.KeepAliveInterval > 0 {
+		// applied by the net package to connections accepted from net.Listener
+		lncfg.KeepAlive = so.KeepAliveInterval
+	}
+	return lncfg
+}
+
+// applySockOpts sets the buffer sizes and TCP_DEFER_ACCEPT on the listen
+// socket; buffer sizes are inherited by accepted connections.
+func applySockOpts(fd uintptr, network string, so *remote.SockOpts) (err error) {
+	if so.SendBuf > 0 {
+		if err = unix.SetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_SNDBUF, so.SendBuf); err != nil {
+			return err
+		}
+	}
+	if so.RecvBuf > 0 {
+		if err = unix.SetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_RCVBUF, so.RecvBuf); err != nil {
+			return err
+		}
+	}
+	if so.DeferAccept && strings.HasPrefix(network, "tcp") {
+		if err = setDeferAccept(fd); err != nil {
+			return err
+		}
+	}
+	return nil
 }
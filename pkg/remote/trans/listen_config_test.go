@@ -0,0 +1,63 @@
+//go:build !windows
+// +build !windows
+
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package trans
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/remote"
+)
+
+func TestNewListenConfigSocketOptions(t *testing.T) {
+	opt := &remote.ServerOption{
+		SocketOptions: &remote.SockOpts{
+			SendBuf:           64 * 1024,
+			RecvBuf:           64 * 1024,
+			KeepAliveInterval: time.Minute,
+			DeferAccept:       true,
+		},
+	}
+	lncfg := NewListenConfig(opt)
+	test.Assert(t, lncfg.KeepAlive == time.Minute)
+
+	ln, err := lncfg.Listen(context.Background(), "tcp", "127.0.0.1:0")
+	test.Assert(t, err == nil, err)
+	defer ln.Close()
+
+	rawConn, err := ln.(*net.TCPListener).SyscallConn()
+	test.Assert(t, err == nil, err)
+	var sndBuf, rcvBuf int
+	cerr := rawConn.Control(func(fd uintptr) {
+		sndBuf, err = unix.GetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_SNDBUF)
+		test.Assert(t, err == nil, err)
+		rcvBuf, err = unix.GetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_RCVBUF)
+		test.Assert(t, err == nil, err)
+	})
+	test.Assert(t, cerr == nil, cerr)
+	// the kernel may round the value up (linux doubles it), never down to less
+	test.Assert(t, sndBuf >= 64*1024, sndBuf)
+	test.Assert(t, rcvBuf >= 64*1024, rcvBuf)
+}
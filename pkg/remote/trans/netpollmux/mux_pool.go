@@ -25,6 +25,7 @@ import (
 	"github.com/cloudwego/netpoll"
 
 	"github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/remote/connpool"
 
 	"golang.org/x/sync/singleflight"
 )
@@ -39,24 +40,50 @@ func NewMuxConnPool(size int) *MuxPool {
 	return lp
 }
 
+// NewMuxConnPoolWithMaxStreams works like NewMuxConnPool but caps how many
+// requests are multiplexed on one connection: Get picks the connection with
+// the fewest in flight and dials into a free slot once all dialed ones reach
+// maxStreams. When every slot is saturated the least loaded connection is
+// used anyway, so size*maxStreams is where requests start piling up on each
+// other rather than a hard limit.
+func NewMuxConnPoolWithMaxStreams(size, maxStreams int) *MuxPool {
+	lp := NewMuxConnPool(size)
+	lp.maxStreams = int32(maxStreams)
+	return lp
+}
+
 // MuxPool manages a pool of long connections.
 type MuxPool struct {
 	// mod     int32
-	size    int32
-	sfg     singleflight.Group
-	connMap sync.Map // key address, value *muxCliConn
+	size       int32
+	maxStreams int32 // see NewMuxConnPoolWithMaxStreams, 0 means no cap
+	sfg        singleflight.Group
+	connMap    sync.Map // key "network,address", value *conns
+}
+
+// connKey builds the connMap key. The network is part of the key so a unix
+// socket path and a tcp address can never collide.
+func connKey(network, address string) string {
+	return network + "," + address
 }
 
 // Get pick or generate a net.Conn and return
 func (mp *MuxPool) Get(ctx context.Context, network, address string, opt remote.ConnOption) (net.Conn, error) {
-	v, ok := mp.connMap.Load(address)
+	network, address = connpool.NormalizeNetAddr(network, address)
+	key := connKey(network, address)
+	v, ok := mp.connMap.Load(key)
 	if ok {
-		connection := v.(*conns).get()
+		var connection *muxCliConn
+		if mp.maxStreams > 0 {
+			connection = v.(*conns).getWithLimit(mp.maxStreams)
+		} else {
+			connection = v.(*conns).get()
+		}
 		if connection != nil && connection.IsActive() {
 			return connection, nil
 		}
 	}
-	connection, err, _ := mp.sfg.Do(address, func() (i interface{}, e error) {
+	connection, err, _ := mp.sfg.Do(key, func() (i interface{}, e error) {
 		conn, err := opt.Dialer.DialTimeout(network, address, opt.ConnectTimeout)
 		if err != nil {
 			return nil, err
@@ -69,7 +96,7 @@ func (mp *MuxPool) Get(ctx context.Context, network, address string, opt remote.
 			cs = &conns{size: uint32(mp.size), conns: make([]*muxCliConn, mp.size)}
 		}
 		cs.put(connection)
-		mp.connMap.Store(address, cs)
+		mp.connMap.Store(key, cs)
 		return connection, nil
 	})
 	if err != nil {
@@ -96,8 +123,10 @@ func (mp *MuxPool) Discard(conn net.Conn) error {
 
 // Clean implements the LongConnPool interface.
 func (mp *MuxPool) Clean(network, address string) {
-	if v, ok := mp.connMap.Load(address); ok {
-		mp.connMap.Delete(address)
+	network, address = connpool.NormalizeNetAddr(network, address)
+	key := connKey(network, address)
+	if v, ok := mp.connMap.Load(key); ok {
+		mp.connMap.Delete(key)
 		v.(*conns).close()
 	}
 }
@@ -123,6 +152,30 @@ func (c *conns) get() *muxCliConn {
 	return c.conns[i%c.size]
 }
 
+// getWithLimit returns the active connection with the fewest requests in
+// flight. It returns nil when that connection already carries maxStreams
+// and a slot is still free, so the caller spills onto a new connection
+// instead of piling more streams on a loaded one.
+func (c *conns) getWithLimit(maxStreams int32) *muxCliConn {
+	var best *muxCliConn
+	var bestLoad int32
+	hasFree := false
+	for _, conn := range c.conns {
+		if conn == nil || !conn.IsActive() {
+			hasFree = true
+			continue
+		}
+		load := conn.seqIDMap.len()
+		if best == nil || load < bestLoad {
+			best, bestLoad = conn, load
+		}
+	}
+	if best == nil || (bestLoad >= maxStreams && hasFree) {
+		return nil
+	}
+	return best
+}
+
 // put and close together
 func (c *conns) put(conn *muxCliConn) {
 	for i := 0; i < int(c.size); i++ {
@@ -125,6 +125,59 @@ func TestMuxConnPoolReuse(t *testing.T) {
 	test.Assert(t, len(count) == 2)
 }
 
+func TestMuxConnPoolMaxStreams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p := NewMuxConnPoolWithMaxStreams(2, 2)
+
+	d := mocksremote.NewMockDialer(ctrl)
+	d.EXPECT().DialTimeout(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(network, address string, timeout time.Duration) (net.Conn, error) {
+		na := utils.NewNetAddr(network, address)
+		conn := mocksnetpoll.NewMockConnection(ctrl)
+		conn.EXPECT().RemoteAddr().Return(na).AnyTimes()
+		conn.EXPECT().SetOnRequest(gomock.Any())
+		conn.EXPECT().AddCloseCallback(gomock.Any())
+		conn.EXPECT().IsActive().Return(true).AnyTimes()
+		return conn, nil
+	}).AnyTimes()
+	opt := dialer.ConnOption{Dialer: d, ConnectTimeout: time.Second}
+
+	c1, err := p.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == nil)
+
+	// under the limit, the single connection keeps being reused
+	c, err := p.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == nil)
+	test.Assert(t, c == c1)
+
+	// saturate it: the next Get spills onto a new connection
+	c1.(*muxCliConn).seqIDMap.store(1, nil)
+	c1.(*muxCliConn).seqIDMap.store(2, nil)
+	c2, err := p.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == nil)
+	test.Assert(t, c2 != c1)
+
+	// the least loaded connection wins
+	c, err = p.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == nil)
+	test.Assert(t, c == c2)
+
+	// all slots saturated: the least loaded one is used anyway
+	c2.(*muxCliConn).seqIDMap.store(3, nil)
+	c2.(*muxCliConn).seqIDMap.store(4, nil)
+	c2.(*muxCliConn).seqIDMap.store(5, nil)
+	c, err = p.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == nil)
+	test.Assert(t, c == c1)
+
+	// a finished request frees capacity again
+	c1.(*muxCliConn).seqIDMap.delete(1)
+	c, err = p.Get(context.TODO(), "tcp", mockAddr0, opt)
+	test.Assert(t, err == nil)
+	test.Assert(t, c == c1)
+}
+
 func TestMuxConnPoolDiscardClean(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -178,6 +178,12 @@ func (t *svrTransHandler) OnRead(muxSvrConnCtx context.Context, conn net.Conn) e
 			connection.Close()
 			return nil
 		}
+		if t.opt.SyncHandler {
+			// process inline on the I/O goroutine to save the per-request
+			// goroutine hop, see server.WithSyncHandler.
+			t.syncTask(muxSvrConnCtx, conn, reader)
+			continue
+		}
 		fs = append(fs, func() {
 			t.task(muxSvrConnCtx, conn, reader)
 		})
@@ -186,6 +192,24 @@ func (t *svrTransHandler) OnRead(muxSvrConnCtx context.Context, conn net.Conn) e
 	return nil
 }
 
+// syncTask runs one task inline with a watchdog that reports handlers
+// blocking the I/O goroutine longer than the configured threshold.
+func (t *svrTransHandler) syncTask(muxSvrConnCtx context.Context, conn net.Conn, reader netpoll.Reader) {
+	if d := t.opt.SyncHandlerWatchdog; d > 0 {
+		start := time.Now()
+		watchdog := time.AfterFunc(d, func() {
+			klog.Warnf("KITEX: sync handler is still running after %v and is blocking the I/O goroutine, remoteAddress=%s", d, conn.RemoteAddr())
+		})
+		defer func() {
+			watchdog.Stop()
+			if cost := time.Since(start); cost > d {
+				klog.Warnf("KITEX: sync handler took %v (watchdog threshold %v), remoteAddress=%s", cost, d, conn.RemoteAddr())
+			}
+		}()
+	}
+	t.task(muxSvrConnCtx, conn, reader)
+}
+
 // batchGoTasks centrally creates goroutines to execute tasks.
 func (t *svrTransHandler) batchGoTasks(fs []func()) {
 	for n := range fs {
@@ -776,3 +776,109 @@ func TestMuxSvrOnReadHeartbeat(t *testing.T) {
 	// InvokeHandleFunc has not been invoked
 	test.Assert(t, isInvoked.Load() == nil)
 }
+
+// TestMuxSvrOnReadSyncHandler tests requests being processed inline on the
+// I/O goroutine when SyncHandler is enabled.
+func TestMuxSvrOnReadSyncHandler(t *testing.T) {
+	var isInvoked atomic.Value
+
+	buf := netpoll.NewLinkBuffer(1024)
+	npconn := &MockNetpollConn{
+		ReaderFunc: func() (r netpoll.Reader) {
+			return buf
+		},
+		WriterFunc: func() (r netpoll.Writer) {
+			return buf
+		},
+		Conn: mocks.Conn{
+			RemoteAddrFunc: func() (r net.Addr) {
+				return addr
+			},
+		},
+	}
+
+	ctx := context.Background()
+	body := "hello world"
+	rpcInfo := newTestRpcInfo()
+	ctx = rpcinfo.NewCtxWithRPCInfo(ctx, rpcInfo)
+
+	// build an independent option with its own rpcInfo and svcInfo so the test
+	// does not depend on the shared package-level opt mutated by other tests
+	testSvcInfo := &serviceinfo.ServiceInfo{
+		ServiceName: mocks.MockServiceName,
+		Methods: map[string]serviceinfo.MethodInfo{
+			method: serviceinfo.NewMethodInfo(nil, mocks.NewMockArgs, mocks.NewMockResult, false),
+		},
+	}
+	testSvcSearcher := mocksremote.NewMockSvcSearcher(
+		map[string]*serviceinfo.ServiceInfo{mocks.MockServiceName: testSvcInfo},
+		map[string]*serviceinfo.ServiceInfo{mocks.MockMethod: testSvcInfo})
+	syncOpt := &remote.ServerOption{
+		InitOrResetRPCInfoFunc: func(ri rpcinfo.RPCInfo, addr net.Addr) rpcinfo.RPCInfo {
+			return rpcInfo
+		},
+		Codec: &MockCodec{
+			EncodeFunc: func(ctx context.Context, msg remote.Message, out remote.ByteBuffer) error {
+				r := mockHeader(msg.RPCInfo().Invocation().SeqID(), body)
+				_, err := out.WriteBinary(r.Bytes())
+				return err
+			},
+			DecodeFunc: func(ctx context.Context, msg remote.Message, in remote.ByteBuffer) error {
+				in.Skip(3 * codec.Size32)
+				_, err := in.ReadString(len(body))
+				msg.SpecifyServiceInfo(mocks.MockServiceName, mocks.MockMethod)
+				return err
+			},
+		},
+		SvcSearcher:         testSvcSearcher,
+		TargetSvcInfo:       testSvcInfo,
+		TracerCtl:           &rpcinfo.TraceController{},
+		ReadWriteTimeout:    rwTimeout,
+		SyncHandler:         true,
+		SyncHandlerWatchdog: time.Second,
+	}
+	svrTransHdlr, _ := NewSvrTransHandlerFactory().NewTransHandler(syncOpt)
+
+	msg := &MockMessage{
+		RPCInfoFunc: func() rpcinfo.RPCInfo {
+			return rpcInfo
+		},
+		ServiceInfoFunc: func() *serviceinfo.ServiceInfo {
+			return &serviceinfo.ServiceInfo{
+				Methods: map[string]serviceinfo.MethodInfo{
+					"method": serviceinfo.NewMethodInfo(nil, nil, nil, false),
+				},
+			}
+		},
+	}
+
+	pool := &sync.Pool{}
+	muxSvrCon := newMuxSvrConn(npconn, pool)
+
+	ctx, err := svrTransHdlr.Write(ctx, muxSvrCon, msg)
+	test.Assert(t, ctx != nil, ctx)
+	test.Assert(t, err == nil, err)
+
+	time.Sleep(10 * time.Millisecond)
+	buf.Flush()
+	test.Assert(t, npconn.Reader().Len() > 0, npconn.Reader().Len())
+
+	ctx, err = svrTransHdlr.OnActive(ctx, muxSvrCon)
+	test.Assert(t, ctx != nil, ctx)
+	test.Assert(t, err == nil, err)
+
+	pl := remote.NewTransPipeline(svrTransHdlr)
+	svrTransHdlr.SetPipeline(pl)
+
+	if setter, ok := svrTransHdlr.(remote.InvokeHandleFuncSetter); ok {
+		setter.SetInvokeHandleFunc(func(ctx context.Context, req, resp interface{}) (err error) {
+			isInvoked.Store(1)
+			return nil
+		})
+	}
+
+	err = svrTransHdlr.OnRead(ctx, npconn)
+	test.Assert(t, err == nil, err)
+	// inline processing: the handler has run by the time OnRead returns
+	test.Assert(t, isInvoked.Load() == 1)
+}
@@ -18,6 +18,7 @@ package netpollmux
 
 import (
 	"sync"
+	"sync/atomic"
 
 	"github.com/cloudwego/kitex/pkg/remote"
 )
@@ -30,8 +31,9 @@ type EventHandler interface {
 // A concurrent safe <seqID,EventHandler> map
 // To avoid lock bottlenecks this map is dived to several (SHARD_COUNT) map shards.
 type shardMap struct {
-	size   int32
-	shards []*shard
+	size     int32
+	inflight int32 // current number of stored entries
+	shards   []*shard
 }
 
 // A "thread" safe string to anything map.
@@ -67,6 +69,9 @@ func (m *shardMap) store(seqID int32, msg EventHandler) {
 	// Get map shard.
 	shard := m.getShard(seqID)
 	shard.Lock()
+	if _, ok := shard.msgs[seqID]; !ok {
+		atomic.AddInt32(&m.inflight, 1)
+	}
 	shard.msgs[seqID] = msg
 	shard.Unlock()
 }
@@ -90,10 +95,18 @@ func (m *shardMap) delete(seqID int32) {
 	}
 	shard := m.getShard(seqID)
 	shard.Lock()
+	if _, ok := shard.msgs[seqID]; ok {
+		atomic.AddInt32(&m.inflight, -1)
+	}
 	delete(shard.msgs, seqID)
 	shard.Unlock()
 }
 
+// len returns how many entries are stored, i.e. the requests in flight.
+func (m *shardMap) len() int32 {
+	return atomic.LoadInt32(&m.inflight)
+}
+
 // rangeMap iterates over the map.
 func (m *shardMap) rangeMap(fn func(seqID int32, msg EventHandler)) {
 	for _, shard := range m.shards {
@@ -65,6 +65,12 @@ const (
 	HeaderProcessAtTime          = "K_ProcessAtTime"
 	// HeaderCRC32C is used to store the crc32c checksum of payload
 	HeaderCRC32C = "crc32c"
+	// HeaderPayloadCompress is used to negotiate payload compression,
+	// it stores the name of the compressor applied to the payload
+	HeaderPayloadCompress = "pcmp"
+	// HeaderPayloadChunked is used to negotiate payload chunking,
+	// it stores the size of the payload before it was split into chunks
+	HeaderPayloadChunked = "chnk"
 )
 
 // key of acl token
@@ -70,9 +70,17 @@ func (p *BackupPolicy) WithRetrySameNode() {
 	p.RetrySameNode = true
 }
 
+// WithSuppressOnCBHalfOpen suppresses the backup request when the instance
+// circuit breaker of the destination is half-open, it requires the Container
+// to be built with WithContainerInstanceCBPanel.
+func (p *BackupPolicy) WithSuppressOnCBHalfOpen() {
+	p.SuppressOnCBHalfOpen = true
+}
+
 // String is used to print human readable debug info.
 func (p *BackupPolicy) String() string {
-	return fmt.Sprintf("{RetryDelayMS:%+v StopPolicy:%+v RetrySameNode:%+v}", p.RetryDelayMS, p.StopPolicy, p.RetrySameNode)
+	return fmt.Sprintf("{RetryDelayMS:%+v StopPolicy:%+v RetrySameNode:%+v SuppressOnCBHalfOpen:%+v}",
+		p.RetryDelayMS, p.StopPolicy, p.RetrySameNode, p.SuppressOnCBHalfOpen)
 }
 
 // Equals to check if BackupPolicy is equal
@@ -92,6 +100,9 @@ func (p *BackupPolicy) Equals(np *BackupPolicy) bool {
 	if p.RetrySameNode != np.RetrySameNode {
 		return false
 	}
+	if p.SuppressOnCBHalfOpen != np.SuppressOnCBHalfOpen {
+		return false
+	}
 
 	return true
 }
@@ -101,8 +112,9 @@ func (p *BackupPolicy) DeepCopy() *BackupPolicy {
 		return nil
 	}
 	return &BackupPolicy{
-		RetryDelayMS:  p.RetryDelayMS,
-		StopPolicy:    p.StopPolicy, // not a pointer, will copy the value here
-		RetrySameNode: p.RetrySameNode,
+		RetryDelayMS:         p.RetryDelayMS,
+		StopPolicy:           p.StopPolicy, // not a pointer, will copy the value here
+		RetrySameNode:        p.RetrySameNode,
+		SuppressOnCBHalfOpen: p.SuppressOnCBHalfOpen,
 	}
 }
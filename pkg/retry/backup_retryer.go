@@ -27,6 +27,8 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/bytedance/gopkg/cloud/circuitbreaker"
+
 	"github.com/cloudwego/kitex/pkg/circuitbreak"
 	"github.com/cloudwego/kitex/pkg/gofunc"
 	"github.com/cloudwego/kitex/pkg/kerrors"
@@ -70,6 +72,9 @@ func (r *backupRetryer) ShouldRetry(ctx context.Context, err error, callTimes in
 	if stop, msg := circuitBreakerStop(ctx, r.policy.StopPolicy, r.cbContainer, req, cbKey); stop {
 		return msg, false
 	}
+	if stop, msg := budgetStop(r.cbContainer.budget); stop {
+		return msg, false
+	}
 	return "", true
 }
 
@@ -91,6 +96,7 @@ func (r *backupRetryer) Do(ctx context.Context, rpcCall RPCCallFunc, firstRI rpc
 	r.RLock()
 	retryTimes := r.policy.StopPolicy.MaxRetryTimes
 	retryDelay := r.retryDelay
+	suppressHalfOpen := r.policy.SuppressOnCBHalfOpen
 	r.RUnlock()
 
 	var callTimes int32 = 0
@@ -144,6 +150,11 @@ func (r *backupRetryer) Do(ctx context.Context, rpcCall RPCCallFunc, firstRI rpc
 		}
 		select {
 		case <-timer.C:
+			if suppressHalfOpen && instanceCBHalfOpen(r.cbContainer.instCBPanel, firstRI) {
+				// the destination is in the breaker's recovery trial, hedging
+				// would fight it, wait for the in-flight request instead
+				continue
+			}
 			if _, ok := r.ShouldRetry(ctx, nil, i, req, cbKey); ok && i <= retryTimes {
 				doCall = true
 				timer.Reset(retryDelay)
@@ -166,6 +177,24 @@ func (r *backupRetryer) Do(ctx context.Context, rpcCall RPCCallFunc, firstRI rpc
 	}
 }
 
+// instanceCBHalfOpen reports whether the instance circuit breaker of the
+// destination of ri is half-open. The state is read via DumpBreakers instead
+// of IsAllowed, since IsAllowed consumes the half-open trial quota.
+func instanceCBHalfOpen(panel circuitbreaker.Panel, ri rpcinfo.RPCInfo) bool {
+	if panel == nil || ri == nil {
+		return false
+	}
+	addr := ri.To().Address()
+	if addr == nil {
+		return false
+	}
+	// the instance cb key is the instance address, see CBSuite.initInstanceCB
+	if b, ok := panel.DumpBreakers()[addr.String()]; ok {
+		return b.State() == circuitbreaker.HalfOpen
+	}
+	return false
+}
+
 // Prepare implements the Retryer interface.
 func (r *backupRetryer) Prepare(ctx context.Context, prevRI, retryRI rpcinfo.RPCInfo) {
 	handleRetryInstance(r.policy.RetrySameNode, prevRI, retryRI)
@@ -0,0 +1,106 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retry
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bytedance/gopkg/cloud/circuitbreaker"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/discovery"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+	"github.com/cloudwego/kitex/pkg/rpcinfo/remoteinfo"
+)
+
+const mockInstAddr = "10.20.30.40:8888"
+
+// halfOpenInstancePanel builds an instance panel whose breaker for
+// mockInstAddr is in the half-open state.
+func halfOpenInstancePanel(t *testing.T) circuitbreaker.Panel {
+	panel, err := circuitbreaker.NewPanel(nil, circuitbreaker.Options{
+		CoolingTimeout: 10 * time.Millisecond,
+		ShouldTrip:     circuitbreaker.ConsecutiveTripFunc(1),
+	})
+	test.Assert(t, err == nil, err)
+	panel.Fail(mockInstAddr)
+	time.Sleep(20 * time.Millisecond)
+	// the first IsAllowed after cooling moves the breaker to half-open
+	panel.IsAllowed(mockInstAddr)
+	b := panel.DumpBreakers()[mockInstAddr]
+	test.Assert(t, b != nil && b.State() == circuitbreaker.HalfOpen, b)
+	return panel
+}
+
+func genRPCInfoWithInstance() rpcinfo.RPCInfo {
+	ri := genRPCInfo()
+	remoteinfo.AsRemoteInfo(ri.To()).SetInstance(discovery.NewInstance("tcp", mockInstAddr, 10, nil))
+	return ri
+}
+
+func TestInstanceCBHalfOpen(t *testing.T) {
+	panel := halfOpenInstancePanel(t)
+	defer panel.Close()
+	ri := genRPCInfoWithInstance()
+	test.Assert(t, instanceCBHalfOpen(panel, ri))
+	// no panel, no address or an unknown breaker never suppresses
+	test.Assert(t, !instanceCBHalfOpen(nil, ri))
+	test.Assert(t, !instanceCBHalfOpen(panel, genRPCInfo()))
+	panel.Succeed(mockInstAddr)
+	panel.Succeed(mockInstAddr)
+	test.Assert(t, !instanceCBHalfOpen(panel, ri))
+}
+
+func TestBackupSuppressOnCBHalfOpen(t *testing.T) {
+	panel := halfOpenInstancePanel(t)
+	defer panel.Close()
+	rc := NewRetryContainer(WithContainerInstanceCBPanel(panel))
+	defer rc.Close()
+	bp := NewBackupPolicy(10)
+	bp.WithSuppressOnCBHalfOpen()
+	err := rc.Init(map[string]Policy{Wildcard: BuildBackupRequest(bp)}, nil)
+	test.Assert(t, err == nil, err)
+
+	// the first request is slow but the backup is suppressed by the half-open breaker
+	firstRI := genRPCInfoWithInstance()
+	ctx := rpcinfo.NewCtxWithRPCInfo(context.Background(), firstRI)
+	var callTimes int32
+	_, _, err = rc.WithRetryIfNeeded(ctx, &Policy{}, func(ctx context.Context, r Retryer) (rpcinfo.RPCInfo, interface{}, error) {
+		atomic.AddInt32(&callTimes, 1)
+		time.Sleep(50 * time.Millisecond)
+		return firstRI, nil, nil
+	}, firstRI, nil)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, atomic.LoadInt32(&callTimes) == 1, callTimes)
+
+	// without the switch the backup request still fires
+	rc2 := NewRetryContainer(WithContainerInstanceCBPanel(panel))
+	defer rc2.Close()
+	err = rc2.Init(map[string]Policy{Wildcard: BuildBackupRequest(NewBackupPolicy(10))}, nil)
+	test.Assert(t, err == nil, err)
+	atomic.StoreInt32(&callTimes, 0)
+	_, _, err = rc2.WithRetryIfNeeded(ctx, &Policy{}, func(ctx context.Context, r Retryer) (rpcinfo.RPCInfo, interface{}, error) {
+		atomic.AddInt32(&callTimes, 1)
+		time.Sleep(50 * time.Millisecond)
+		return firstRI, nil, nil
+	}, firstRI, nil)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, atomic.LoadInt32(&callTimes) > 1, callTimes)
+}
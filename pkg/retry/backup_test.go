@@ -34,7 +34,7 @@ func TestBackupPolicy_String(t *testing.T) {
 	r.WithRetryBreaker(0.3)
 	r.WithRetrySameNode()
 	msg := "{RetryDelayMS:100 StopPolicy:{MaxRetryTimes:1 MaxDurationMS:0 " +
-		"DisableChainStop:false DDLStop:false CBPolicy:{ErrorRate:0.3}} RetrySameNode:true}"
+		"DisableChainStop:false DDLStop:false CBPolicy:{ErrorRate:0.3}} RetrySameNode:true SuppressOnCBHalfOpen:false}"
 	test.Assert(t, r.String() == msg)
 }
 
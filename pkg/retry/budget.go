@@ -0,0 +1,70 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retry
+
+import "sync"
+
+// budgetMaxTokens caps the tokens a Budget can accumulate, so a long quiet
+// period cannot bank an unbounded burst of retries.
+const budgetMaxTokens = 100
+
+// Budget is a token-bucket style retry budget shared across all methods of
+// a client. Every request deposits `ratio` tokens and every retry attempt
+// withdraws one token, so at a ratio of 0.2 at most 20% of the recent
+// traffic can be retries, preventing a downstream brownout from amplifying
+// the traffic several times over.
+type Budget struct {
+	mu     sync.Mutex
+	tokens float64
+	ratio  float64
+}
+
+// NewBudget creates a retry Budget with the given deposit ratio.
+// The bucket starts full so that retries are not starved right after start.
+func NewBudget(ratio float64) *Budget {
+	return &Budget{tokens: budgetMaxTokens, ratio: ratio}
+}
+
+// OnRequest deposits tokens for one normal (non-retry) request.
+func (b *Budget) OnRequest() {
+	b.mu.Lock()
+	if b.tokens += b.ratio; b.tokens > budgetMaxTokens {
+		b.tokens = budgetMaxTokens
+	}
+	b.mu.Unlock()
+}
+
+// AllowRetry withdraws one token for a retry attempt.
+// It returns false if the budget is exhausted, in which case no token is
+// consumed and the retry should not be sent.
+func (b *Budget) AllowRetry() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// budgetStop returns true if the budget forbids sending another retry.
+func budgetStop(budget *Budget) (bool, string) {
+	if budget == nil || budget.AllowRetry() {
+		return false, ""
+	}
+	return true, "retry budget exhausted"
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retry
+
+import (
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+)
+
+func TestBudgetWithdrawAndDeposit(t *testing.T) {
+	b := NewBudget(0.5)
+	// the bucket starts full
+	for i := 0; i < budgetMaxTokens; i++ {
+		test.Assert(t, b.AllowRetry())
+	}
+	test.Assert(t, !b.AllowRetry())
+	// two requests earn one retry at ratio 0.5
+	b.OnRequest()
+	test.Assert(t, !b.AllowRetry())
+	b.OnRequest()
+	test.Assert(t, b.AllowRetry())
+	test.Assert(t, !b.AllowRetry())
+}
+
+func TestBudgetStop(t *testing.T) {
+	stop, msg := budgetStop(nil)
+	test.Assert(t, !stop && msg == "")
+
+	b := NewBudget(0.2)
+	stop, _ = budgetStop(b)
+	test.Assert(t, !stop)
+	for i := 0; i < budgetMaxTokens; i++ {
+		b.AllowRetry()
+	}
+	stop, msg = budgetStop(b)
+	test.Assert(t, stop)
+	test.Assert(t, msg == "retry budget exhausted", msg)
+}
+
+func TestContainerWithRetryBudget(t *testing.T) {
+	rc := NewRetryContainer(WithContainerRetryBudget(0.1))
+	defer rc.Close()
+	test.Assert(t, rc.cbContainer.budget != nil)
+
+	// percentage limit rebuilds the cbContainer, the budget must survive it
+	rc2 := NewRetryContainer(WithContainerEnablePercentageLimit(), WithContainerRetryBudget(0.1))
+	defer rc2.Close()
+	test.Assert(t, rc2.cbContainer.budget != nil)
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retry
+
+import (
+	"context"
+
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+// FailoverFunc reroutes the last allowed attempt of a failure retry to a
+// backup cluster/idc after all same-cluster attempts failed. It receives the
+// context of the attempt and the rpcinfo of the first call, and returns the
+// context the attempt is issued with; returning nil keeps the original one.
+// Implementations typically mark the backup target in the context (e.g. a
+// metainfo key) which a custom discovery.Resolver or proxy reads to resolve
+// instances from the backup cluster. The hook runs after chain-stop, ddl-stop
+// and the retry circuit breaker have allowed the attempt, so failover traffic
+// is throttled like any other retry.
+type FailoverFunc func(ctx context.Context, firstRI rpcinfo.RPCInfo) context.Context
+
+// WithContainerFailover sets the failover hook applied to every failure
+// retryer built by the Container, including ones rebuilt on policy change.
+func WithContainerFailover(f FailoverFunc) ContainerOption {
+	return func(rc *Container) {
+		rc.failover = f
+	}
+}
+
+// applyFailover hands the container-level failover hook to retryers that
+// support it, and returns the retryer for convenience.
+func (rc *Container) applyFailover(r Retryer) Retryer {
+	if rc.failover != nil {
+		if fr, ok := r.(*failureRetryer); ok {
+			fr.setFailover(rc.failover)
+		}
+	}
+	return r
+}
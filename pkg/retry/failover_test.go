@@ -0,0 +1,71 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retry
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+type failoverCtxKey struct{}
+
+func TestWithContainerFailover(t *testing.T) {
+	var failoverTimes int32
+	rc := NewRetryContainer(WithContainerFailover(func(ctx context.Context, firstRI rpcinfo.RPCInfo) context.Context {
+		atomic.AddInt32(&failoverTimes, 1)
+		return context.WithValue(ctx, failoverCtxKey{}, "backup")
+	}))
+	defer rc.Close()
+	fp := NewFailurePolicy()
+	fp.WithMaxRetryTimes(2)
+	err := rc.Init(map[string]Policy{Wildcard: BuildFailurePolicy(fp)}, nil)
+	test.Assert(t, err == nil, err)
+
+	// every attempt fails: only the last allowed attempt carries the failover mark
+	firstRI := genRPCInfo()
+	var callTimes int32
+	var backupCalls int32
+	ctx := rpcinfo.NewCtxWithRPCInfo(context.Background(), firstRI)
+	_, _, err = rc.WithRetryIfNeeded(ctx, &Policy{}, func(ctx context.Context, r Retryer) (rpcinfo.RPCInfo, interface{}, error) {
+		atomic.AddInt32(&callTimes, 1)
+		if ctx.Value(failoverCtxKey{}) != nil {
+			atomic.AddInt32(&backupCalls, 1)
+		}
+		return firstRI, nil, kerrors.ErrRPCTimeout
+	}, firstRI, nil)
+	test.Assert(t, err != nil)
+	test.Assert(t, atomic.LoadInt32(&callTimes) == 3, callTimes)
+	test.Assert(t, atomic.LoadInt32(&failoverTimes) == 1, failoverTimes)
+	test.Assert(t, atomic.LoadInt32(&backupCalls) == 1, backupCalls)
+
+	// a call that succeeds before the last attempt never fails over
+	atomic.StoreInt32(&failoverTimes, 0)
+	atomic.StoreInt32(&callTimes, 0)
+	_, _, err = rc.WithRetryIfNeeded(ctx, &Policy{}, func(ctx context.Context, r Retryer) (rpcinfo.RPCInfo, interface{}, error) {
+		if atomic.AddInt32(&callTimes, 1) == 1 {
+			return firstRI, nil, kerrors.ErrRPCTimeout
+		}
+		return genRPCInfoWithFirstStats(firstRI), nil, nil
+	}, firstRI, nil)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, atomic.LoadInt32(&failoverTimes) == 0, failoverTimes)
+}
@@ -99,6 +99,21 @@ func (p *FailurePolicy) WithRandomBackOff(minMS, maxMS int) {
 	p.BackOffPolicy.CfgItems[MaxMSBackOffCfgKey] = float64(maxMS)
 }
 
+// WithExponentialBackOff sets exponential backoff: the n-th retry waits
+// initialMS * multiplier^(n-1) milliseconds, capped at maxMS. jitter is one of
+// NoneJitter, FullJitter (wait a random duration up to the computed value) and
+// EqualJitter (wait half the computed value plus a random half).
+func (p *FailurePolicy) WithExponentialBackOff(initialMS int, multiplier float64, maxMS int, jitter float64) {
+	if err := checkExponentialBackOff(initialMS, multiplier, maxMS, jitter); err != nil {
+		panic(err)
+	}
+	p.BackOffPolicy = &BackOffPolicy{ExponentialBackOffType, make(map[BackOffCfgKey]float64, 4)}
+	p.BackOffPolicy.CfgItems[InitialMSBackOffCfgKey] = float64(initialMS)
+	p.BackOffPolicy.CfgItems[MultiplierBackOffCfgKey] = multiplier
+	p.BackOffPolicy.CfgItems[MaxMSBackOffCfgKey] = float64(maxMS)
+	p.BackOffPolicy.CfgItems[JitterBackOffCfgKey] = jitter
+}
+
 // WithRetryBreaker sets the error rate.
 func (p *FailurePolicy) WithRetryBreaker(errRate float64) {
 	p.StopPolicy.CBPolicy.ErrorRate = errRate
@@ -107,6 +122,21 @@ func (p *FailurePolicy) WithRetryBreaker(errRate float64) {
 	}
 }
 
+// WithAttemptDeadlinePercents splits the overall rpc deadline across attempts,
+// so a slow first attempt cannot consume the whole budget and leave nothing
+// for the retries. Each percent is the fraction of the deadline window granted
+// to one attempt, e.g. (0.6, 0.4) caps the first attempt at 60% of the budget
+// and lets the retry use the rest. Percents must be positive and sum to at
+// most 1; attempts beyond len(percents) inherit the remaining deadline. It
+// takes effect when the call carries a deadline, either on the context or via
+// the configured rpc timeout.
+func (p *FailurePolicy) WithAttemptDeadlinePercents(percents ...float64) {
+	if err := checkAttemptDeadlinePercents(percents); err != nil {
+		panic(err)
+	}
+	p.AttemptDeadlinePercents = percents
+}
+
 // WithRetrySameNode sets to retry the same node.
 func (p *FailurePolicy) WithRetrySameNode() {
 	p.RetrySameNode = true
@@ -145,6 +175,14 @@ func (p *FailurePolicy) Equals(np *FailurePolicy) bool {
 	if p.Extra != np.Extra {
 		return false
 	}
+	if len(p.AttemptDeadlinePercents) != len(np.AttemptDeadlinePercents) {
+		return false
+	}
+	for i := range p.AttemptDeadlinePercents {
+		if p.AttemptDeadlinePercents[i] != np.AttemptDeadlinePercents[i] {
+			return false
+		}
+	}
 	// don't need to check `ShouldResultRetry`, ShouldResultRetry is only setup by option
 	// in remote config case will always return false if check it
 	return true
@@ -154,13 +192,18 @@ func (p *FailurePolicy) DeepCopy() *FailurePolicy {
 	if p == nil {
 		return nil
 	}
-	return &FailurePolicy{
+	np := &FailurePolicy{
 		StopPolicy:        p.StopPolicy,
 		BackOffPolicy:     p.BackOffPolicy.DeepCopy(),
 		RetrySameNode:     p.RetrySameNode,
 		ShouldResultRetry: p.ShouldResultRetry, // don't need DeepCopy
 		Extra:             p.Extra,
 	}
+	if p.AttemptDeadlinePercents != nil {
+		np.AttemptDeadlinePercents = make([]float64, len(p.AttemptDeadlinePercents))
+		copy(np.AttemptDeadlinePercents, p.AttemptDeadlinePercents)
+	}
+	return np
 }
 
 // isRespRetryWithCtxNonNil is used to check if RespRetryWithCtx is nil.
@@ -287,6 +330,56 @@ func (p randomBackOff) String() string {
 	return fmt.Sprintf("RandomBackOff(%dms-%dms)", p.minMS, p.maxMS)
 }
 
+func newExponentialBackOff(initialMS int, multiplier float64, maxMS int, jitter float64) BackOff {
+	return &exponentialBackOff{
+		initialMS:  initialMS,
+		multiplier: multiplier,
+		maxMS:      maxMS,
+		jitter:     jitter,
+	}
+}
+
+type exponentialBackOff struct {
+	initialMS  int
+	multiplier float64
+	maxMS      int
+	jitter     float64
+}
+
+func (p *exponentialBackOff) backOffDuration(callTimes int) time.Duration {
+	attempt := callTimes - 1
+	if attempt < 1 {
+		attempt = 1
+	}
+	ms := float64(p.initialMS)
+	for i := 1; i < attempt; i++ {
+		ms *= p.multiplier
+		if ms >= float64(p.maxMS) {
+			break
+		}
+	}
+	if ms > float64(p.maxMS) {
+		ms = float64(p.maxMS)
+	}
+	switch p.jitter {
+	case FullJitter:
+		ms = fastrand.Float64() * ms
+	case EqualJitter:
+		ms = ms/2 + fastrand.Float64()*ms/2
+	}
+	return time.Duration(ms * float64(time.Millisecond))
+}
+
+// Wait implements the BackOff interface.
+func (p *exponentialBackOff) Wait(callTimes int) {
+	time.Sleep(p.backOffDuration(callTimes))
+}
+
+// String prints human readable information.
+func (p exponentialBackOff) String() string {
+	return fmt.Sprintf("ExponentialBackOff(%dms*%g, cap %dms, jitter %g)", p.initialMS, p.multiplier, p.maxMS, p.jitter)
+}
+
 func checkFixedBackOff(fixMS int) error {
 	if fixMS == 0 {
 		return fmt.Errorf("invalid FixedBackOff, fixMS=%d", fixMS)
@@ -300,3 +393,33 @@ func checkRandomBackOff(minMS, maxMS int) error {
 	}
 	return nil
 }
+
+func checkAttemptDeadlinePercents(percents []float64) error {
+	if len(percents) == 0 {
+		return fmt.Errorf("invalid AttemptDeadlinePercents: empty")
+	}
+	var sum float64
+	for _, pct := range percents {
+		if pct <= 0 {
+			return fmt.Errorf("invalid AttemptDeadlinePercents, percent=%g", pct)
+		}
+		sum += pct
+	}
+	if sum > 1 {
+		return fmt.Errorf("invalid AttemptDeadlinePercents, sum=%g exceeds 1", sum)
+	}
+	return nil
+}
+
+func checkExponentialBackOff(initialMS int, multiplier float64, maxMS int, jitter float64) error {
+	if initialMS <= 0 || maxMS < initialMS {
+		return fmt.Errorf("invalid ExponentialBackOff, initialMS=%d, maxMS=%d", initialMS, maxMS)
+	}
+	if multiplier < 1 {
+		return fmt.Errorf("invalid ExponentialBackOff, multiplier=%g", multiplier)
+	}
+	if jitter != NoneJitter && jitter != FullJitter && jitter != EqualJitter {
+		return fmt.Errorf("invalid ExponentialBackOff, jitter=%g", jitter)
+	}
+	return nil
+}
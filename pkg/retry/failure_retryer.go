@@ -26,6 +26,8 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/bytedance/gopkg/cloud/metainfo"
+
 	"github.com/cloudwego/kitex/pkg/circuitbreak"
 	"github.com/cloudwego/kitex/pkg/kerrors"
 	"github.com/cloudwego/kitex/pkg/klog"
@@ -44,10 +46,19 @@ type failureRetryer struct {
 	enable bool
 	*failureCommon
 	policy *FailurePolicy
+	// failover, if not nil, reroutes the last allowed attempt to a backup
+	// cluster, see WithContainerFailover.
+	failover FailoverFunc
 	sync.RWMutex
 	errMsg string
 }
 
+func (r *failureRetryer) setFailover(f FailoverFunc) {
+	r.Lock()
+	r.failover = f
+	r.Unlock()
+}
+
 // ShouldRetry to check if retry request can be called, it is checked in retryer.Do.
 // If not satisfy will return the reason message
 func (r *failureRetryer) ShouldRetry(ctx context.Context, err error, callTimes int, req interface{}, cbKey string) (string, bool) {
@@ -83,6 +94,8 @@ func (r *failureRetryer) Do(ctx context.Context, rpcCall RPCCallFunc, firstRI rp
 		maxDuration = time.Duration(r.policy.StopPolicy.MaxDurationMS) * time.Millisecond
 	}
 	retryTimes := r.policy.StopPolicy.MaxRetryTimes
+	attemptPercents := r.policy.AttemptDeadlinePercents
+	failover := r.failover
 	r.RUnlock()
 
 	var callTimes int32
@@ -95,12 +108,20 @@ func (r *failureRetryer) Do(ctx context.Context, rpcCall RPCCallFunc, firstRI rp
 		}
 	}()
 	startTime := time.Now()
+	var hintCtx context.Context
 	for i := 0; i <= retryTimes; i++ {
 		var resp interface{}
 		var callStart time.Time
 		if i == 0 {
 			callStart = startTime
 		} else if i > 0 {
+			if stop, retryAfter := recvRetryHint(hintCtx); stop {
+				appendErrMsg(err, fmt.Sprintf("retried %d, stopped by server retry hint", i-1))
+				break
+			} else if retryAfter > 0 {
+				// the server asked to hold back, wait in addition to the policy backoff
+				time.Sleep(retryAfter)
+			}
 			if ret, e := isExceedMaxDuration(ctx, startTime, maxDuration, callTimes); ret {
 				err = e
 				break
@@ -123,7 +144,21 @@ func (r *failureRetryer) Do(ctx context.Context, rpcCall RPCCallFunc, firstRI rp
 			// record stat before call since requests may be slow, making the limiter more accurate
 			recordRetryStat(cbKey, r.cbContainer.cbPanel, callTimes)
 		}
-		cRI, resp, err = rpcCall(ctx, r)
+		attemptCtx, attemptCancel := attemptContext(ctx, firstRI, attemptPercents, startTime, i)
+		if failover != nil && i > 0 && i == retryTimes {
+			// all same-cluster attempts failed, route the last one to the backup cluster
+			if fctx := failover(attemptCtx, firstRI); fctx != nil {
+				attemptCtx = fctx
+			}
+		}
+		// let the codec deliver the server retry hint of this attempt, no-op
+		// when the caller already enabled receiving backward values
+		attemptCtx = metainfo.WithBackwardValues(attemptCtx)
+		hintCtx = attemptCtx
+		cRI, resp, err = rpcCall(attemptCtx, r)
+		if attemptCancel != nil {
+			attemptCancel()
+		}
 		callCosts.WriteString(strconv.FormatInt(time.Since(callStart).Microseconds(), 10))
 
 		if !r.cbContainer.enablePercentageLimit && r.cbContainer.cbStat {
@@ -253,6 +288,9 @@ func (r *failureCommon) shouldRetry(ctx context.Context, callTimes int, req inte
 	if stop, msg := ddlStop(ctx, fp.StopPolicy); stop {
 		return msg, false
 	}
+	if stop, msg := budgetStop(r.cbContainer.budget); stop {
+		return msg, false
+	}
 	r.backOff.Wait(callTimes)
 	return "", true
 }
@@ -313,12 +351,59 @@ func initBackOff(policy *BackOffPolicy) (bo BackOff, err error) {
 			return
 		}
 		bo = newRandomBackOff(minMSInt, maxMSInt)
+	case ExponentialBackOffType:
+		if policy.CfgItems == nil {
+			return bo, errors.New("invalid ExponentialBackOff, CfgItems is nil")
+		}
+		initialMS := policy.CfgItems[InitialMSBackOffCfgKey]
+		maxMS := policy.CfgItems[MaxMSBackOffCfgKey]
+		multiplier := policy.CfgItems[MultiplierBackOffCfgKey]
+		jitter := policy.CfgItems[JitterBackOffCfgKey]
+		initialMSInt, _ := strconv.Atoi(fmt.Sprintf("%1.0f", initialMS))
+		maxMSInt, _ := strconv.Atoi(fmt.Sprintf("%1.0f", maxMS))
+		if err = checkExponentialBackOff(initialMSInt, multiplier, maxMSInt, jitter); err != nil {
+			return
+		}
+		bo = newExponentialBackOff(initialMSInt, multiplier, maxMSInt, jitter)
 	default:
 		return bo, fmt.Errorf("invalid backoffType=%v", policy.BackOffType)
 	}
 	return
 }
 
+// attemptContext caps the deadline of the i-th attempt (0-based) according to
+// AttemptDeadlinePercents: the attempt may run until start plus the summed
+// percents of the attempts so far, within the overall deadline window. The
+// window is taken from the context deadline, falling back to the configured
+// rpc timeout. It returns the unchanged ctx and a nil cancel func when no cap
+// applies.
+func attemptContext(ctx context.Context, ri rpcinfo.RPCInfo, percents []float64, start time.Time, i int) (context.Context, context.CancelFunc) {
+	if i >= len(percents) {
+		return ctx, nil
+	}
+	ddl, ok := ctx.Deadline()
+	if !ok {
+		if timeout := ri.Config().RPCTimeout(); timeout > 0 {
+			ddl = start.Add(timeout)
+		} else {
+			return ctx, nil
+		}
+	}
+	total := ddl.Sub(start)
+	if total <= 0 {
+		return ctx, nil
+	}
+	var cum float64
+	for j := 0; j <= i; j++ {
+		cum += percents[j]
+	}
+	attemptDDL := start.Add(time.Duration(cum * float64(total)))
+	if !attemptDDL.Before(ddl) {
+		return ctx, nil
+	}
+	return context.WithDeadline(ctx, attemptDDL)
+}
+
 func isExceedMaxDuration(ctx context.Context, start time.Time, maxDuration time.Duration, callTimes int32) (bool, error) {
 	if maxDuration > 0 && time.Since(start) > maxDuration {
 		return true, makeRetryErr(ctx, fmt.Sprintf("exceed max duration[%v]", maxDuration), callTimes)
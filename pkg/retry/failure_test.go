@@ -18,6 +18,7 @@ package retry
 
 import (
 	"context"
+	"encoding/json"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -797,3 +798,90 @@ func TestNewRetryer4FailureRetry(t *testing.T) {
 		test.Assert(t, r.(*failureRetryer).enable)
 	})
 }
+
+func TestExponentialBackOff(t *testing.T) {
+	fp := NewFailurePolicy()
+	fp.WithExponentialBackOff(10, 2.0, 100, NoneJitter)
+	test.Assert(t, fp.BackOffPolicy.BackOffType == ExponentialBackOffType)
+	test.Assert(t, fp.BackOffPolicy.CfgItems[InitialMSBackOffCfgKey] == 10)
+	test.Assert(t, fp.BackOffPolicy.CfgItems[MultiplierBackOffCfgKey] == 2.0)
+	test.Assert(t, fp.BackOffPolicy.CfgItems[MaxMSBackOffCfgKey] == 100)
+	test.Assert(t, fp.BackOffPolicy.CfgItems[JitterBackOffCfgKey] == NoneJitter)
+
+	// the policy serializes and restores through the same CfgItems structure
+	jsonRet, err := json.Marshal(fp)
+	test.Assert(t, err == nil, err)
+	var deserialize FailurePolicy
+	err = json.Unmarshal(jsonRet, &deserialize)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, fp.Equals(&deserialize))
+
+	bo, err := initBackOff(fp.BackOffPolicy)
+	test.Assert(t, err == nil, err)
+	ebo, ok := bo.(*exponentialBackOff)
+	test.Assert(t, ok)
+	test.Assert(t, ebo.backOffDuration(1) == 10*time.Millisecond)
+	test.Assert(t, ebo.backOffDuration(2) == 10*time.Millisecond)
+	test.Assert(t, ebo.backOffDuration(3) == 20*time.Millisecond)
+	test.Assert(t, ebo.backOffDuration(4) == 40*time.Millisecond)
+	// capped
+	test.Assert(t, ebo.backOffDuration(10) == 100*time.Millisecond)
+
+	// full jitter stays within (0, computed]
+	fullJitter := newExponentialBackOff(10, 2.0, 100, FullJitter).(*exponentialBackOff)
+	for i := 0; i < 100; i++ {
+		d := fullJitter.backOffDuration(3)
+		test.Assert(t, d <= 20*time.Millisecond, d)
+	}
+	// equal jitter stays within [computed/2, computed]
+	equalJitter := newExponentialBackOff(10, 2.0, 100, EqualJitter).(*exponentialBackOff)
+	for i := 0; i < 100; i++ {
+		d := equalJitter.backOffDuration(3)
+		test.Assert(t, d >= 10*time.Millisecond && d <= 20*time.Millisecond, d)
+	}
+
+	// invalid configs panic
+	test.Panic(t, func() { fp.WithExponentialBackOff(0, 2.0, 100, NoneJitter) })
+	test.Panic(t, func() { fp.WithExponentialBackOff(10, 0.5, 100, NoneJitter) })
+	test.Panic(t, func() { fp.WithExponentialBackOff(10, 2.0, 5, NoneJitter) })
+	test.Panic(t, func() { fp.WithExponentialBackOff(10, 2.0, 100, 3) })
+}
+
+func TestAttemptDeadlinePercents(t *testing.T) {
+	fp := NewFailurePolicy()
+	fp.WithAttemptDeadlinePercents(0.6, 0.4)
+	test.DeepEqual(t, fp.AttemptDeadlinePercents, []float64{0.6, 0.4})
+	test.Assert(t, fp.Equals(fp.DeepCopy()))
+
+	// invalid configs panic
+	test.Panic(t, func() { fp.WithAttemptDeadlinePercents() })
+	test.Panic(t, func() { fp.WithAttemptDeadlinePercents(-0.1, 0.5) })
+	test.Panic(t, func() { fp.WithAttemptDeadlinePercents(0.8, 0.4) })
+
+	// the first attempt is capped at 60% of the deadline window
+	start := time.Now()
+	ctx, cancel := context.WithDeadline(context.Background(), start.Add(time.Second))
+	defer cancel()
+	actx, acancel := attemptContext(ctx, genRPCInfo(), fp.AttemptDeadlinePercents, start, 0)
+	test.Assert(t, acancel != nil)
+	ddl, ok := actx.Deadline()
+	test.Assert(t, ok)
+	gap := ddl.Sub(start) - 600*time.Millisecond
+	test.Assert(t, gap >= -time.Millisecond && gap <= time.Millisecond, gap)
+	acancel()
+
+	// the last configured attempt keeps the full remaining deadline
+	actx, acancel = attemptContext(ctx, genRPCInfo(), fp.AttemptDeadlinePercents, start, 1)
+	test.Assert(t, acancel == nil)
+	test.Assert(t, actx == ctx)
+
+	// attempts beyond the configured percents inherit the deadline
+	actx, acancel = attemptContext(ctx, genRPCInfo(), fp.AttemptDeadlinePercents, start, 2)
+	test.Assert(t, acancel == nil)
+	test.Assert(t, actx == ctx)
+
+	// without any deadline the cap is a no-op
+	actx, acancel = attemptContext(context.Background(), genRPCInfo(), fp.AttemptDeadlinePercents, start, 0)
+	test.Assert(t, acancel == nil)
+	test.Assert(t, actx == context.Background())
+}
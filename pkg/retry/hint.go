@@ -0,0 +1,67 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retry
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/bytedance/gopkg/cloud/metainfo"
+)
+
+const (
+	// RetryHintKey is the backward metainfo key by which a server hints the
+	// client's failure retry, carried in the TTHeader str info or the gRPC
+	// metadata like other backward values. The value is either
+	// noRetryHintValue to forbid retrying the failed call, or a positive
+	// integer asking the client to wait that many milliseconds before the
+	// next attempt (e.g. when the server is overloaded).
+	RetryHintKey = "retry-hint"
+
+	noRetryHintValue = "stop"
+)
+
+// SendNoRetryHint is called on the server side to tell the client not to
+// retry the current call on failure. It reports whether the hint is set,
+// false means the request carries no backward metainfo channel.
+func SendNoRetryHint(ctx context.Context) bool {
+	return metainfo.SendBackwardValue(ctx, RetryHintKey, noRetryHintValue)
+}
+
+// SendRetryAfterHint is called on the server side to ask the client to wait
+// for the given duration before the next retry attempt of the current call.
+// It reports whether the hint is set.
+func SendRetryAfterHint(ctx context.Context, delay time.Duration) bool {
+	return metainfo.SendBackwardValue(ctx, RetryHintKey, strconv.FormatInt(delay.Milliseconds(), 10))
+}
+
+// recvRetryHint parses the retry hint received on the attempt context,
+// returning whether retry is forbidden and the server-asked wait duration.
+func recvRetryHint(ctx context.Context) (stop bool, retryAfter time.Duration) {
+	v, ok := metainfo.RecvBackwardValue(ctx, RetryHintKey)
+	if !ok {
+		return
+	}
+	if v == noRetryHintValue {
+		return true, 0
+	}
+	if ms, err := strconv.Atoi(v); err == nil && ms > 0 {
+		return false, time.Duration(ms) * time.Millisecond
+	}
+	return
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bytedance/gopkg/cloud/metainfo"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+func TestServerRetryHint(t *testing.T) {
+	rc := NewRetryContainer()
+	defer rc.Close()
+	fp := NewFailurePolicy()
+	fp.WithMaxRetryTimes(2)
+	err := rc.Init(map[string]Policy{Wildcard: BuildFailurePolicy(fp)}, nil)
+	test.Assert(t, err == nil, err)
+	firstRI := genRPCInfo()
+	ctx := rpcinfo.NewCtxWithRPCInfo(context.Background(), firstRI)
+
+	// "stop" hint forbids any further attempt
+	var callTimes int32
+	_, _, err = rc.WithRetryIfNeeded(ctx, &Policy{}, func(ctx context.Context, r Retryer) (rpcinfo.RPCInfo, interface{}, error) {
+		atomic.AddInt32(&callTimes, 1)
+		// emulate the codec delivering the hint sent by the server
+		metainfo.SetBackwardValuesFromMap(ctx, map[string]string{RetryHintKey: noRetryHintValue})
+		return firstRI, nil, kerrors.ErrRPCTimeout.WithCause(errors.New("mock"))
+	}, firstRI, nil)
+	test.Assert(t, err != nil)
+	test.Assert(t, atomic.LoadInt32(&callTimes) == 1, callTimes)
+	test.Assert(t, strings.Contains(err.Error(), "server retry hint"), err)
+
+	// "retry after" hint delays the next attempt without stopping it
+	atomic.StoreInt32(&callTimes, 0)
+	start := time.Now()
+	_, _, err = rc.WithRetryIfNeeded(ctx, &Policy{}, func(ctx context.Context, r Retryer) (rpcinfo.RPCInfo, interface{}, error) {
+		if atomic.AddInt32(&callTimes, 1) == 1 {
+			metainfo.SetBackwardValuesFromMap(ctx, map[string]string{RetryHintKey: "30"})
+			return firstRI, nil, kerrors.ErrRPCTimeout
+		}
+		return genRPCInfoWithFirstStats(firstRI), nil, nil
+	}, firstRI, nil)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, atomic.LoadInt32(&callTimes) == 2, callTimes)
+	test.Assert(t, time.Since(start) >= 30*time.Millisecond)
+
+	// malformed hints are ignored
+	atomic.StoreInt32(&callTimes, 0)
+	_, _, err = rc.WithRetryIfNeeded(ctx, &Policy{}, func(ctx context.Context, r Retryer) (rpcinfo.RPCInfo, interface{}, error) {
+		atomic.AddInt32(&callTimes, 1)
+		metainfo.SetBackwardValuesFromMap(ctx, map[string]string{RetryHintKey: "not-a-number"})
+		return firstRI, nil, kerrors.ErrRPCTimeout
+	}, firstRI, nil)
+	test.Assert(t, err != nil)
+	test.Assert(t, atomic.LoadInt32(&callTimes) == 3, callTimes)
+}
+
+func TestSendRetryHint(t *testing.T) {
+	// without a backward channel the hint cannot be set
+	test.Assert(t, !SendNoRetryHint(context.Background()))
+
+	ctx := metainfo.WithBackwardValuesToSend(context.Background())
+	test.Assert(t, SendNoRetryHint(ctx))
+	v, ok := metainfo.GetBackwardValueToSend(ctx, RetryHintKey)
+	test.Assert(t, ok && v == noRetryHintValue, v)
+
+	test.Assert(t, SendRetryAfterHint(ctx, 50*time.Millisecond))
+	v, ok = metainfo.GetBackwardValueToSend(ctx, RetryHintKey)
+	test.Assert(t, ok && v == "50", v)
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retry
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+// CallInfo describes how the retry layer executed a call, so upper layers can
+// log and bill retried/hedged traffic correctly.
+type CallInfo struct {
+	// Attempts is the number of attempts actually executed, 1 means no retry.
+	Attempts int
+	// WinningAddr is the address of the instance that served the winning
+	// response, may be nil when no instance was resolved.
+	WinningAddr net.Addr
+	// TotalBackOff is the total time spent waiting between attempts.
+	TotalBackOff time.Duration
+}
+
+type lastCallCtxKey struct{}
+
+// WithLastCallInfo returns a context on which the retry layer records how the
+// call issued with it was executed; fetch the result with LastCallInfo after
+// the call returns. The recording is per context, so a context prepared with
+// WithLastCallInfo should not be shared by concurrent calls.
+func WithLastCallInfo(ctx context.Context) context.Context {
+	return context.WithValue(ctx, lastCallCtxKey{}, new(atomic.Value))
+}
+
+// LastCallInfo returns the CallInfo of the last call issued with ctx, or nil
+// when ctx was not prepared with WithLastCallInfo or no call finished yet.
+func LastCallInfo(ctx context.Context) *CallInfo {
+	if h, ok := ctx.Value(lastCallCtxKey{}).(*atomic.Value); ok {
+		if info, ok := h.Load().(*CallInfo); ok {
+			return info
+		}
+	}
+	return nil
+}
+
+// recordLastCall wraps rpcCall to count attempts and the wait time between
+// them; the returned done func stores the CallInfo into holder once the whole
+// call finished with lastRI.
+func recordLastCall(rpcCall RPCCallFunc, holder *atomic.Value) (RPCCallFunc, func(lastRI rpcinfo.RPCInfo)) {
+	var mu sync.Mutex
+	var attempts int
+	var backOff time.Duration
+	var lastEnd time.Time
+	wrapped := func(ctx context.Context, r Retryer) (rpcinfo.RPCInfo, interface{}, error) {
+		start := time.Now()
+		mu.Lock()
+		attempts++
+		if !lastEnd.IsZero() {
+			if gap := start.Sub(lastEnd); gap > 0 {
+				backOff += gap
+			}
+		}
+		mu.Unlock()
+		cRI, resp, err := rpcCall(ctx, r)
+		mu.Lock()
+		lastEnd = time.Now()
+		mu.Unlock()
+		return cRI, resp, err
+	}
+	done := func(lastRI rpcinfo.RPCInfo) {
+		mu.Lock()
+		info := &CallInfo{Attempts: attempts, TotalBackOff: backOff}
+		mu.Unlock()
+		if lastRI != nil && lastRI.To() != nil {
+			info.WinningAddr = lastRI.To().Address()
+		}
+		holder.Store(info)
+	}
+	return wrapped, done
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retry
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+func TestLastCallInfo(t *testing.T) {
+	rc := NewRetryContainer()
+	defer rc.Close()
+	fp := NewFailurePolicy()
+	fp.WithMaxRetryTimes(2)
+	fp.WithFixedBackOff(10)
+	err := rc.Init(map[string]Policy{Wildcard: BuildFailurePolicy(fp)}, nil)
+	test.Assert(t, err == nil, err)
+
+	// a context without WithLastCallInfo records nothing
+	firstRI := genRPCInfo()
+	ctx := rpcinfo.NewCtxWithRPCInfo(context.Background(), firstRI)
+	test.Assert(t, LastCallInfo(ctx) == nil)
+
+	ctx = WithLastCallInfo(ctx)
+	test.Assert(t, LastCallInfo(ctx) == nil)
+	var callTimes int32
+	lastRI, _, err := rc.WithRetryIfNeeded(ctx, &Policy{}, retryCall(&callTimes, firstRI, false), firstRI, nil)
+	test.Assert(t, err == nil, err)
+	info := LastCallInfo(ctx)
+	test.Assert(t, info != nil)
+	test.Assert(t, info.Attempts == 2, info.Attempts)
+	test.Assert(t, info.TotalBackOff >= 10*time.Millisecond, info.TotalBackOff)
+	test.Assert(t, info.WinningAddr != nil && info.WinningAddr.String() == lastRI.To().Address().String())
+
+	// a call without retry reports a single attempt with no backoff
+	ctx = WithLastCallInfo(rpcinfo.NewCtxWithRPCInfo(context.Background(), firstRI))
+	_, _, err = rc.WithRetryIfNeeded(ctx, &Policy{}, func(ctx context.Context, r Retryer) (rpcinfo.RPCInfo, interface{}, error) {
+		return firstRI, nil, nil
+	}, firstRI, nil)
+	test.Assert(t, err == nil, err)
+	info = LastCallInfo(ctx)
+	test.Assert(t, info != nil && info.Attempts == 1, info)
+	test.Assert(t, info.TotalBackOff == 0, info.TotalBackOff)
+
+	// failed calls are recorded as well
+	ctx = WithLastCallInfo(rpcinfo.NewCtxWithRPCInfo(context.Background(), firstRI))
+	atomic.StoreInt32(&callTimes, 0)
+	_, _, err = rc.WithRetryIfNeeded(ctx, &Policy{}, func(ctx context.Context, r Retryer) (rpcinfo.RPCInfo, interface{}, error) {
+		atomic.AddInt32(&callTimes, 1)
+		return firstRI, nil, kerrors.ErrRPCTimeout
+	}, firstRI, nil)
+	test.Assert(t, err != nil)
+	info = LastCallInfo(ctx)
+	test.Assert(t, info != nil && info.Attempts == 3, info)
+}
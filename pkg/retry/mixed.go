@@ -48,6 +48,35 @@ func NewMixedPolicyWithResultRetry(delayMS uint32, rr *ShouldResultRetry) *Mixed
 	return fp
 }
 
+// BuildCombinedPolicy combines a failure retry policy and a backup request
+// policy configured on the same method into one mixed retry policy: backup
+// requests fire on latency and failure retry fires on error, coordinated
+// through one retry budget. The combined policy keeps the failure policy's
+// backoff and result-retry rules, takes the larger MaxRetryTimes of the two
+// (capped at maxMixRetryTimes) and the stricter circuit breaker error rate.
+func BuildCombinedPolicy(fp *FailurePolicy, bp *BackupPolicy) Policy {
+	if fp == nil {
+		return BuildBackupRequest(bp)
+	}
+	if bp == nil {
+		return BuildFailurePolicy(fp)
+	}
+	mp := &MixedPolicy{
+		RetryDelayMS:  bp.RetryDelayMS,
+		FailurePolicy: *fp.DeepCopy(),
+	}
+	if bp.StopPolicy.MaxRetryTimes > mp.StopPolicy.MaxRetryTimes {
+		mp.StopPolicy.MaxRetryTimes = bp.StopPolicy.MaxRetryTimes
+	}
+	if mp.StopPolicy.MaxRetryTimes > maxMixRetryTimes {
+		mp.StopPolicy.MaxRetryTimes = maxMixRetryTimes
+	}
+	if bp.StopPolicy.CBPolicy.ErrorRate < mp.StopPolicy.CBPolicy.ErrorRate {
+		mp.StopPolicy.CBPolicy.ErrorRate = bp.StopPolicy.CBPolicy.ErrorRate
+	}
+	return BuildMixedPolicy(mp)
+}
+
 // String is used to print human readable debug info.
 func (p *MixedPolicy) String() string {
 	return fmt.Sprintf("{RetryDelayMS:%+v StopPolicy:%+v BackOffPolicy:%+v RetrySameNode:%+v "+
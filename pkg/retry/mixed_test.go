@@ -728,3 +728,27 @@ func TestNewRetryer4MixedRetry(t *testing.T) {
 		test.Assert(t, r.(*mixedRetryer).enable)
 	})
 }
+
+func TestBuildCombinedPolicy(t *testing.T) {
+	fp := NewFailurePolicyWithResultRetry(AllErrorRetry())
+	fp.WithMaxRetryTimes(1)
+	bp := NewBackupPolicy(20)
+	bp.WithMaxRetryTimes(2)
+	bp.WithRetryBreaker(0.05)
+
+	p := BuildCombinedPolicy(fp, bp)
+	test.Assert(t, p.Enable)
+	test.Assert(t, p.Type == MixedType)
+	test.Assert(t, p.MixedPolicy != nil)
+	test.Assert(t, p.MixedPolicy.RetryDelayMS == 20)
+	// larger retry times of the two
+	test.Assert(t, p.MixedPolicy.StopPolicy.MaxRetryTimes == 2)
+	// stricter error rate of the two
+	test.Assert(t, p.MixedPolicy.StopPolicy.CBPolicy.ErrorRate == 0.05)
+	// result-retry rules of the failure policy are kept
+	test.Assert(t, p.MixedPolicy.ShouldResultRetry == fp.ShouldResultRetry)
+
+	// degenerate cases
+	test.Assert(t, BuildCombinedPolicy(fp, nil).Type == FailureType)
+	test.Assert(t, BuildCombinedPolicy(nil, bp).Type == BackupType)
+}
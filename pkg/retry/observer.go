@@ -0,0 +1,90 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retry
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+// AttemptInfo describes one attempt of a possibly retried call, so tracing
+// systems can render retried calls as child spans instead of one opaque call.
+type AttemptInfo struct {
+	// Attempt numbers the attempts of one call starting from 1. Attempts are
+	// numbered in completion order, which may differ from firing order when
+	// backup requests run in parallel.
+	Attempt int
+	// Target is the address of the instance the attempt was sent to,
+	// nil if the call failed before an instance was picked.
+	Target net.Addr
+	// Latency is the duration of this attempt.
+	Latency time.Duration
+	// Error is the error of this attempt, nil on success.
+	Error error
+	// ResultUsed reports whether this attempt produced the result that was
+	// returned to the caller.
+	ResultUsed bool
+}
+
+// WithAttemptObserver registers f to be invoked once per attempt after the
+// whole call finishes, including calls that were not retried. f must not
+// block; it runs on the request goroutine.
+func WithAttemptObserver(f func(AttemptInfo)) ContainerOption {
+	return func(rc *Container) {
+		rc.attemptObserver = f
+	}
+}
+
+type attemptRecord struct {
+	ri   rpcinfo.RPCInfo
+	info AttemptInfo
+}
+
+// observeAttempts wraps rpcCall to record per-attempt info, and returns a
+// flush func that reports all attempts to the observer once the final result
+// of the call is known.
+func (rc *Container) observeAttempts(rpcCall RPCCallFunc) (wrapped RPCCallFunc, flush func(lastRI rpcinfo.RPCInfo)) {
+	var mu sync.Mutex
+	var attempts []attemptRecord
+	wrapped = func(ctx context.Context, r Retryer) (rpcinfo.RPCInfo, interface{}, error) {
+		start := time.Now()
+		cRI, resp, err := rpcCall(ctx, r)
+		rec := attemptRecord{ri: cRI, info: AttemptInfo{Latency: time.Since(start), Error: err}}
+		if cRI != nil && cRI.To() != nil {
+			rec.info.Target = cRI.To().Address()
+		}
+		mu.Lock()
+		attempts = append(attempts, rec)
+		attempts[len(attempts)-1].info.Attempt = len(attempts)
+		mu.Unlock()
+		return cRI, resp, err
+	}
+	flush = func(lastRI rpcinfo.RPCInfo) {
+		mu.Lock()
+		defer mu.Unlock()
+		for i := range attempts {
+			a := &attempts[i]
+			a.info.ResultUsed = a.ri != nil && a.ri == lastRI
+			rc.attemptObserver(a.info)
+		}
+	}
+	return
+}
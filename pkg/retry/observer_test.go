@@ -0,0 +1,70 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retry
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+func TestWithAttemptObserver(t *testing.T) {
+	var observed []AttemptInfo
+	rc := NewRetryContainer(WithAttemptObserver(func(info AttemptInfo) {
+		observed = append(observed, info)
+	}))
+	defer rc.Close()
+	err := rc.Init(map[string]Policy{Wildcard: BuildFailurePolicy(NewFailurePolicy())}, nil)
+	test.Assert(t, err == nil, err)
+
+	// first attempt times out, the retry succeeds
+	firstRI := genRPCInfo()
+	secondRI := genRPCInfo()
+	var callTimes int32
+	ctx := rpcinfo.NewCtxWithRPCInfo(context.Background(), firstRI)
+	lastRI, _, err := rc.WithRetryIfNeeded(ctx, &Policy{}, func(ctx context.Context, r Retryer) (rpcinfo.RPCInfo, interface{}, error) {
+		if atomic.AddInt32(&callTimes, 1) == 1 {
+			return firstRI, nil, kerrors.ErrRPCTimeout
+		}
+		return secondRI, nil, nil
+	}, firstRI, nil)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, lastRI == secondRI)
+	test.Assert(t, len(observed) == 2, observed)
+	test.Assert(t, observed[0].Attempt == 1)
+	test.Assert(t, kerrors.IsTimeoutError(observed[0].Error))
+	test.Assert(t, !observed[0].ResultUsed)
+	test.Assert(t, observed[1].Attempt == 2)
+	test.Assert(t, observed[1].Error == nil)
+	test.Assert(t, observed[1].ResultUsed)
+
+	// non-retried calls report a single used attempt
+	observed = nil
+	ri := genRPCInfo()
+	ctx = rpcinfo.NewCtxWithRPCInfo(context.Background(), ri)
+	_, _, err = rc.WithRetryIfNeeded(ctx, &Policy{}, func(ctx context.Context, r Retryer) (rpcinfo.RPCInfo, interface{}, error) {
+		return ri, nil, nil
+	}, ri, nil)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, len(observed) == 1, observed)
+	test.Assert(t, observed[0].Attempt == 1)
+	test.Assert(t, observed[0].ResultUsed)
+}
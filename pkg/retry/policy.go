@@ -104,6 +104,11 @@ type FailurePolicy struct {
 	RetrySameNode     bool               `json:"retry_same_node"`
 	ShouldResultRetry *ShouldResultRetry `json:"-"`
 
+	// AttemptDeadlinePercents splits the overall rpc deadline across attempts
+	// instead of each attempt inheriting the full remaining deadline,
+	// see WithAttemptDeadlinePercents.
+	AttemptDeadlinePercents []float64 `json:"attempt_deadline_percents,omitempty"`
+
 	// Extra is not used directly by kitex. It's used for better integrating your own config source.
 	// After loading FailurePolicy from your config source, `Extra` can be decoded into a user-defined schema,
 	// with which, more complex strategies can be implemented, such as modifying the `ShouldResultRetry`.
@@ -116,6 +121,12 @@ type BackupPolicy struct {
 	RetryDelayMS  uint32     `json:"retry_delay_ms"`
 	StopPolicy    StopPolicy `json:"stop_policy"`
 	RetrySameNode bool       `json:"retry_same_node"`
+
+	// SuppressOnCBHalfOpen suppresses the backup request when the instance
+	// circuit breaker of the destination is half-open, so hedging doesn't
+	// fight the breaker's recovery trial. It takes effect only when the
+	// Container is built with WithContainerInstanceCBPanel.
+	SuppressOnCBHalfOpen bool `json:"suppress_on_cb_half_open,omitempty"`
 }
 
 // MixedPolicy for failure retry
@@ -156,9 +167,10 @@ type BackOffType string
 
 // all back off types
 const (
-	NoneBackOffType   BackOffType = "none"
-	FixedBackOffType  BackOffType = "fixed"
-	RandomBackOffType BackOffType = "random"
+	NoneBackOffType        BackOffType = "none"
+	FixedBackOffType       BackOffType = "fixed"
+	RandomBackOffType      BackOffType = "random"
+	ExponentialBackOffType BackOffType = "exponential"
 )
 
 // BackOffCfgKey represents the keys for BackOff.
@@ -171,6 +183,16 @@ const (
 	MaxMSBackOffCfgKey      BackOffCfgKey = "max_ms"
 	InitialMSBackOffCfgKey  BackOffCfgKey = "initial_ms"
 	MultiplierBackOffCfgKey BackOffCfgKey = "multiplier"
+	JitterBackOffCfgKey     BackOffCfgKey = "jitter"
+)
+
+// jitter modes of the exponential backoff, stored under JitterBackOffCfgKey.
+// The values are floats so the mode serializes into CfgItems like the other
+// backoff knobs and can be pushed by remote config systems.
+const (
+	NoneJitter  float64 = 0
+	FullJitter  float64 = 1
+	EqualJitter float64 = 2
 )
 
 // ShouldResultRetry is used for specifying which error or resp need to be retried
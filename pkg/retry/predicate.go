@@ -0,0 +1,75 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+// WithRetryPredicate wraps a per-error predicate into a ShouldResultRetry.
+// Unlike AllErrorRetry, the predicate fully decides which errors are retried:
+// the implicit retry-on-timeout of failure retry is disabled, so timeout
+// errors are only retried when the predicate says so. This keeps business
+// exceptions from being retried by accident.
+func WithRetryPredicate(f func(ctx context.Context, err error, ri rpcinfo.RPCInfo) bool) *ShouldResultRetry {
+	return &ShouldResultRetry{ErrorRetryWithCtx: f, NotRetryForTimeout: true}
+}
+
+// NetworkErrorRetry retries only network-level errors, such as failures to
+// get a connection or errors reported by the net package (connection refused,
+// connection reset and the like). Business exceptions and the server's own
+// errors are not retried.
+func NetworkErrorRetry() *ShouldResultRetry {
+	return WithRetryPredicate(func(ctx context.Context, err error, ri rpcinfo.RPCInfo) bool {
+		return isNetworkError(err)
+	})
+}
+
+// TimeoutErrorRetry retries only rpc timeout errors.
+func TimeoutErrorRetry() *ShouldResultRetry {
+	return WithRetryPredicate(func(ctx context.Context, err error, ri rpcinfo.RPCInfo) bool {
+		return kerrors.IsTimeoutError(err)
+	})
+}
+
+// ThriftExceptionRetry retries only remote exceptions whose type id is one of
+// typeIDs, e.g. remote.InternalError. Exceptions with other type ids and
+// local errors are not retried.
+func ThriftExceptionRetry(typeIDs ...int32) *ShouldResultRetry {
+	ids := make(map[int32]bool, len(typeIDs))
+	for _, id := range typeIDs {
+		ids[id] = true
+	}
+	return WithRetryPredicate(func(ctx context.Context, err error, ri rpcinfo.RPCInfo) bool {
+		var te *remote.TransError
+		return errors.As(err, &te) && ids[te.TypeID()]
+	})
+}
+
+func isNetworkError(err error) bool {
+	if errors.Is(err, kerrors.ErrGetConnection) {
+		return true
+	}
+	var ne net.Error
+	return errors.As(err, &ne)
+}
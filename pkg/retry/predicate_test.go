@@ -0,0 +1,74 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+func TestWithRetryPredicate(t *testing.T) {
+	mockErr := errors.New("mock")
+	rr := WithRetryPredicate(func(ctx context.Context, err error, ri rpcinfo.RPCInfo) bool {
+		return err == mockErr
+	})
+	test.Assert(t, rr.NotRetryForTimeout)
+	test.Assert(t, rr.ErrorRetryWithCtx(context.Background(), mockErr, nil))
+	test.Assert(t, !rr.ErrorRetryWithCtx(context.Background(), errors.New("other"), nil))
+}
+
+func TestNetworkErrorRetry(t *testing.T) {
+	rr := NetworkErrorRetry()
+	ctx := context.Background()
+
+	connErr := kerrors.ErrGetConnection.WithCause(errors.New("dial failed"))
+	test.Assert(t, rr.ErrorRetryWithCtx(ctx, connErr, nil))
+
+	opErr := &net.OpError{Op: "read", Err: errors.New("connection reset by peer")}
+	test.Assert(t, rr.ErrorRetryWithCtx(ctx, opErr, nil))
+
+	bizErr := kerrors.ErrBiz.WithCause(errors.New("business exception"))
+	test.Assert(t, !rr.ErrorRetryWithCtx(ctx, bizErr, nil))
+}
+
+func TestTimeoutErrorRetry(t *testing.T) {
+	rr := TimeoutErrorRetry()
+	ctx := context.Background()
+
+	test.Assert(t, rr.ErrorRetryWithCtx(ctx, kerrors.ErrRPCTimeout.WithCause(errors.New("timeout")), nil))
+	test.Assert(t, !rr.ErrorRetryWithCtx(ctx, errors.New("other"), nil))
+}
+
+func TestThriftExceptionRetry(t *testing.T) {
+	rr := ThriftExceptionRetry(remote.InternalError)
+	ctx := context.Background()
+
+	internalErr := remote.NewTransError(remote.InternalError, errors.New("internal"))
+	test.Assert(t, rr.ErrorRetryWithCtx(ctx, internalErr, nil))
+
+	unknownMethodErr := remote.NewTransError(remote.UnknownMethod, errors.New("unknown method"))
+	test.Assert(t, !rr.ErrorRetryWithCtx(ctx, unknownMethodErr, nil))
+
+	test.Assert(t, !rr.ErrorRetryWithCtx(ctx, errors.New("local"), nil))
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retry
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+	"github.com/cloudwego/kitex/pkg/utils"
+)
+
+// BusinessCodeRetry builds a ShouldResultRetry that retries when the business
+// status code of the decoded response is one of codes, so soft failures
+// reported inside a successful rpc (e.g. "please retry" codes) can be retried
+// like transport errors. path locates the code field starting from the method
+// response with fields separated by dots, e.g. "BaseResp.StatusCode"; the
+// generated XXXResult wrapper is unwrapped automatically. Responses where the
+// path cannot be resolved are not retried. For rules beyond a code list, set
+// ShouldResultRetry.RespRetryWithCtx directly.
+func BusinessCodeRetry(path string, codes ...int32) *ShouldResultRetry {
+	fields := strings.Split(path, ".")
+	ids := make(map[int64]bool, len(codes))
+	for _, code := range codes {
+		ids[int64(code)] = true
+	}
+	return &ShouldResultRetry{RespRetryWithCtx: func(ctx context.Context, resp interface{}, ri rpcinfo.RPCInfo) bool {
+		code, ok := extractBusinessCode(resp, fields)
+		return ok && ids[code]
+	}}
+}
+
+// extractBusinessCode walks the field path from the real response and returns
+// the integer code it ends at.
+func extractBusinessCode(resp interface{}, fields []string) (int64, bool) {
+	if r, ok := resp.(utils.KitexResult); ok {
+		resp = r.GetResult()
+	}
+	v := reflect.ValueOf(resp)
+	for _, f := range fields {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return 0, false
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return 0, false
+		}
+		v = v.FieldByName(f)
+		if !v.IsValid() {
+			return 0, false
+		}
+	}
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return 0, false
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint()), true
+	}
+	return 0, false
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+)
+
+type mockBaseResp struct {
+	StatusCode int32
+}
+
+type mockBizResp struct {
+	BaseResp *mockBaseResp
+}
+
+// mockBizResult mimics a generated XXXResult wrapper.
+type mockBizResult struct {
+	Success *mockBizResp
+}
+
+func (r *mockBizResult) GetResult() interface{} { return r.Success }
+
+func (r *mockBizResult) SetSuccess(v interface{}) { r.Success = v.(*mockBizResp) }
+
+func TestBusinessCodeRetry(t *testing.T) {
+	rr := BusinessCodeRetry("BaseResp.StatusCode", 1001, 1002)
+	ctx := context.Background()
+
+	result := &mockBizResult{Success: &mockBizResp{BaseResp: &mockBaseResp{StatusCode: 1001}}}
+	test.Assert(t, rr.RespRetryWithCtx(ctx, result, nil))
+
+	result.Success.BaseResp.StatusCode = 0
+	test.Assert(t, !rr.RespRetryWithCtx(ctx, result, nil))
+
+	// the raw response works as well as the generated wrapper
+	test.Assert(t, rr.RespRetryWithCtx(ctx, &mockBizResp{BaseResp: &mockBaseResp{StatusCode: 1002}}, nil))
+
+	// unresolvable paths and nil fields are not retried
+	test.Assert(t, !rr.RespRetryWithCtx(ctx, &mockBizResp{}, nil))
+	test.Assert(t, !rr.RespRetryWithCtx(ctx, "not a struct", nil))
+	test.Assert(t, !rr.RespRetryWithCtx(ctx, nil, nil))
+	missing := BusinessCodeRetry("NoSuchField", 1001)
+	test.Assert(t, !missing.RespRetryWithCtx(ctx, result, nil))
+
+	// works as a failure retry policy rule
+	fp := NewFailurePolicyWithResultRetry(rr)
+	test.Assert(t, fp.ShouldResultRetry == rr)
+}
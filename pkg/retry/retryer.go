@@ -21,6 +21,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/bytedance/gopkg/cloud/circuitbreaker"
@@ -131,6 +132,15 @@ func WithContainerCBPanel(panel circuitbreaker.Panel) ContainerOption {
 	}
 }
 
+// WithContainerInstanceCBPanel provides the instance level circuitbreaker.Panel
+// (e.g. circuitbreak.CBSuite.InstancePanel()) so retryers can coordinate with
+// the instance circuit breaker, see BackupPolicy.SuppressOnCBHalfOpen.
+func WithContainerInstanceCBPanel(panel circuitbreaker.Panel) ContainerOption {
+	return func(rc *Container) {
+		rc.cbContainer.instCBPanel = panel
+	}
+}
+
 // WithContainerCBStat instructs the circuitbreak.RecordStat is called within the retryer
 func WithContainerCBStat() ContainerOption {
 	return func(rc *Container) {
@@ -145,6 +155,16 @@ func WithContainerEnablePercentageLimit() ContainerOption {
 	}
 }
 
+// WithContainerRetryBudget sets a token-bucket retry budget shared across all
+// methods of the container: every request deposits `ratio` tokens and every
+// retry attempt costs one token, so at most the given ratio of recent traffic
+// can be retries. eg: ratio=0.2 means at most 20% of requests may be retries.
+func WithContainerRetryBudget(ratio float64) ContainerOption {
+	return func(rc *Container) {
+		rc.budgetRatio = ratio
+	}
+}
+
 // NewRetryContainer build Container that need to build circuit breaker and do circuit breaker statistic.
 // The caller is responsible for calling Container.Close() to release resources referenced.
 func NewRetryContainer(opts ...ContainerOption) *Container {
@@ -152,8 +172,8 @@ func NewRetryContainer(opts ...ContainerOption) *Container {
 		cbContainer: &cbContainer{
 			cbSuite: nil,
 		},
-		retryerMap: sync.Map{},
 	}
+	rc.retryerMap.Store(&sync.Map{})
 	for _, opt := range opts {
 		opt(rc)
 	}
@@ -164,6 +184,7 @@ func NewRetryContainer(opts ...ContainerOption) *Container {
 			enablePercentageLimit: true,
 			cbSuite:               newCBSuite(rc.cbContainer.cbSuiteOptions),
 			cbSuiteOptions:        rc.cbContainer.cbSuiteOptions,
+			instCBPanel:           rc.cbContainer.instCBPanel,
 		}
 	}
 
@@ -179,6 +200,10 @@ func NewRetryContainer(opts ...ContainerOption) *Container {
 	if !container.IsValid() {
 		panic("KITEX: invalid container")
 	}
+	if rc.budgetRatio > 0 {
+		// set after the option loop since enablePercentageLimit rebuilds cbContainer
+		rc.cbContainer.budget = NewBudget(rc.budgetRatio)
+	}
 	return rc
 }
 
@@ -189,15 +214,30 @@ func defaultGenRetryKey(_ context.Context, rpcInfo rpcinfo.RPCInfo) string {
 // Container is a wrapper for Retryer.
 type Container struct {
 	hasCodeCfg  bool
-	retryerMap  sync.Map // <method: retryer>
+	retryerMap  atomic.Value // stores *sync.Map <method: retryer>, swapped wholesale by NotifyPolicyBatchChange
 	cbContainer *cbContainer
 	msg         string
 	sync.RWMutex
 
 	genRetryKey GenRetryKeyFunc
 
+	// budgetRatio > 0 enables the shared retry budget, see WithContainerRetryBudget.
+	budgetRatio float64
+
 	// shouldResultRetry is only used with FailureRetry
 	shouldResultRetry *ShouldResultRetry
+
+	// policyVersion is the version of the last applied policy batch,
+	// see NotifyPolicyBatchChange.
+	policyVersion int64
+
+	// attemptObserver, if not nil, is reported once per attempt after the
+	// call finishes, see WithAttemptObserver.
+	attemptObserver func(AttemptInfo)
+
+	// failover, if not nil, reroutes the last failure retry attempt to a
+	// backup cluster, see WithContainerFailover.
+	failover FailoverFunc
 }
 
 // Recommended usage: NewRetryContainerWithPercentageLimit()
@@ -223,6 +263,15 @@ type cbContainer struct {
 
 	// for creating CBSuite inside NewRetryContainer
 	cbSuiteOptions []circuitbreak.CBSuiteOption
+
+	// budget, if not nil, is the retry budget shared across all retryers of
+	// the container, see WithContainerRetryBudget.
+	budget *Budget
+
+	// instCBPanel, if not nil, is the instance level circuit breaker panel
+	// used to coordinate hedging with instance recovery,
+	// see WithContainerInstanceCBPanel.
+	instCBPanel circuitbreaker.Panel
 }
 
 // IsValid returns true when both cbCtl & cbPanel are not nil
@@ -243,7 +292,7 @@ func (rc *Container) InitWithPolicies(methodPolicies map[string]Policy) error {
 	for m := range methodPolicies {
 		if methodPolicies[m].Enable {
 			inited = true
-			if _, ok := rc.retryerMap.Load(m); ok {
+			if _, ok := rc.retryers().Load(m); ok {
 				// NotifyPolicyChange may happen before
 				continue
 			}
@@ -266,9 +315,9 @@ func (rc *Container) DeletePolicy(key string) {
 		// the priority of user setup code policy is higher than remote config
 		return
 	}
-	_, ok := rc.retryerMap.Load(key)
+	_, ok := rc.retryers().Load(key)
 	if ok {
-		rc.retryerMap.Delete(key)
+		rc.retryers().Delete(key)
 		rc.msg = fmt.Sprintf("delete retryer[%s] at %s", key, time.Now())
 	}
 }
@@ -282,7 +331,7 @@ func (rc *Container) NotifyPolicyChange(key string, p Policy) {
 		// the priority of user setup code policy is higher than remote config
 		return
 	}
-	r, ok := rc.retryerMap.Load(key)
+	r, ok := rc.retryers().Load(key)
 	if ok && r != nil {
 		retryer, ok := r.(Retryer)
 		if ok {
@@ -291,13 +340,59 @@ func (rc *Container) NotifyPolicyChange(key string, p Policy) {
 				rc.msg = fmt.Sprintf("update retryer[%s-%s] at %s", key, retryer.Type(), time.Now())
 				return
 			}
-			rc.retryerMap.Delete(key)
+			rc.retryers().Delete(key)
 			rc.msg = fmt.Sprintf("delete retryer[%s-%s] at %s", key, retryer.Type(), time.Now())
 		}
 	}
 	rc.initRetryer(key, p)
 }
 
+// retryers returns the current retryer map; the pointer is swapped wholesale
+// by NotifyPolicyBatchChange, so callers must not cache it across requests.
+func (rc *Container) retryers() *sync.Map {
+	if m, ok := rc.retryerMap.Load().(*sync.Map); ok {
+		return m
+	}
+	// the Container may be constructed directly instead of via NewRetryContainer
+	rc.retryerMap.CompareAndSwap(nil, &sync.Map{})
+	return rc.retryerMap.Load().(*sync.Map)
+}
+
+// NotifyPolicyBatchChange applies a whole set of method policies in one shot:
+// the container's retryer map is rebuilt and swapped atomically, so lookups
+// never observe a mix of old and new policies and methods absent from
+// policies lose their retryer. version guards against out-of-order pushes
+// from config centers; a batch whose version is not greater than the last
+// applied one is ignored. If building any retryer fails, the whole batch is
+// rejected and the old policies stay in effect. Like NotifyPolicyChange, it
+// is a no-op when policies were set up by code.
+func (rc *Container) NotifyPolicyBatchChange(version int64, policies map[string]Policy) error {
+	rc.Lock()
+	defer rc.Unlock()
+	if rc.hasCodeCfg {
+		// the priority of user setup code policy is higher than remote config
+		return nil
+	}
+	if version <= rc.policyVersion {
+		return fmt.Errorf("stale retry policy batch, version=%d, current=%d", version, rc.policyVersion)
+	}
+	newMap := &sync.Map{}
+	for key := range policies {
+		retryer, err := NewRetryer(policies[key], rc.shouldResultRetry, rc.cbContainer)
+		if err != nil {
+			rc.msg = fmt.Sprintf("reject retry policy batch version=%d, retryer[%s-%s] failed, err=%s, at %s",
+				version, key, policies[key].Type, err.Error(), time.Now())
+			klog.Warnf("KITEX: %s", rc.msg)
+			return err
+		}
+		newMap.Store(key, rc.applyFailover(retryer))
+	}
+	rc.retryerMap.Store(newMap)
+	rc.policyVersion = version
+	rc.msg = fmt.Sprintf("batch update %d retryers to version %d at %s", len(policies), version, time.Now())
+	return nil
+}
+
 // Init to build Retryer with code config.
 func (rc *Container) Init(mp map[string]Policy, rr *ShouldResultRetry) (err error) {
 	// NotifyPolicyChange func may execute before Init func.
@@ -346,11 +441,26 @@ func PrepareRetryContext(ctx context.Context) context.Context {
 // WithRetryIfNeeded to check if there is a retryer can be used and if current call can retry.
 // When the retry condition is satisfied, use retryer to call
 func (rc *Container) WithRetryIfNeeded(ctx context.Context, callOptRetry *Policy, rpcCall RPCCallFunc, ri rpcinfo.RPCInfo, request interface{}) (lastRI rpcinfo.RPCInfo, recycleRI bool, err error) {
+	if rc.cbContainer.budget != nil {
+		rc.cbContainer.budget.OnRequest()
+	}
+	if rc.attemptObserver != nil {
+		var flush func(lastRI rpcinfo.RPCInfo)
+		rpcCall, flush = rc.observeAttempts(rpcCall)
+		defer func() { flush(lastRI) }()
+	}
+	if holder, ok := ctx.Value(lastCallCtxKey{}).(*atomic.Value); ok {
+		var done func(lastRI rpcinfo.RPCInfo)
+		rpcCall, done = recordLastCall(rpcCall, holder)
+		defer func() { done(lastRI) }()
+	}
 	var retryer Retryer
 	if callOptRetry != nil && callOptRetry.Enable {
 		// build retryer for call level if retry policy is set up with callopt
 		if retryer, err = NewRetryer(*callOptRetry, nil, rc.cbContainer); err != nil {
 			klog.Warnf("KITEX: new callopt retryer[%s] failed, err=%w", callOptRetry.Type, err)
+		} else {
+			rc.applyFailover(retryer)
 		}
 	} else {
 		retryer = rc.getRetryer(ctx, ri)
@@ -400,11 +510,11 @@ func (rc *Container) getRetryer(ctx context.Context, ri rpcinfo.RPCInfo) Retryer
 		keyFunc = rc.genRetryKey
 	}
 	// the priority of specific method is high
-	r, ok := rc.retryerMap.Load(keyFunc(ctx, ri))
+	r, ok := rc.retryers().Load(keyFunc(ctx, ri))
 	if ok {
 		return r.(Retryer)
 	}
-	r, ok = rc.retryerMap.Load(Wildcard)
+	r, ok = rc.retryers().Load(Wildcard)
 	if ok {
 		return r.(Retryer)
 	}
@@ -416,7 +526,10 @@ func (rc *Container) Dump() interface{} {
 	rc.RLock()
 	dm := make(map[string]interface{})
 	dm["has_code_cfg"] = rc.hasCodeCfg
-	rc.retryerMap.Range(func(key, value interface{}) bool {
+	if rc.policyVersion > 0 {
+		dm["policy_version"] = rc.policyVersion
+	}
+	rc.retryers().Range(func(key, value interface{}) bool {
 		if r, ok := value.(Retryer); ok {
 			dm[key.(string)] = r.Dump()
 		}
@@ -438,7 +551,7 @@ func (rc *Container) initRetryer(method string, p Policy) error {
 		return err
 	}
 
-	rc.retryerMap.Store(method, retryer)
+	rc.retryers().Store(method, rc.applyFailover(retryer))
 	if p.Enable {
 		rc.msg = fmt.Sprintf("new retryer[%s-%s] at %s", method, retryer.Type(), time.Now())
 	} else {
@@ -453,7 +566,7 @@ func (rc *Container) updateRetryer(rr *ShouldResultRetry) {
 
 	rc.shouldResultRetry = rr
 	if rc.shouldResultRetry != nil {
-		rc.retryerMap.Range(func(key, value interface{}) bool {
+		rc.retryers().Range(func(key, value interface{}) bool {
 			switch r := value.(type) {
 			case *failureRetryer:
 				r.setSpecifiedResultRetryIfNeeded(rc.shouldResultRetry, r.policy)
@@ -892,3 +892,50 @@ func TestBugfix4InvalidRetry(t *testing.T) {
 		test.Assert(t, strings.Contains(err.Error(), "FailurePolicy is nil or retry type not match"))
 	})
 }
+
+func TestNotifyPolicyBatchChange(t *testing.T) {
+	rc := NewRetryContainer()
+	defer rc.Close()
+
+	fp := BuildFailurePolicy(NewFailurePolicy())
+	bp := BuildBackupRequest(NewBackupPolicy(20))
+	err := rc.NotifyPolicyBatchChange(1, map[string]Policy{"mock1": fp, "mock2": bp})
+	test.Assert(t, err == nil, err)
+	_, ok := rc.retryers().Load("mock1")
+	test.Assert(t, ok)
+	_, ok = rc.retryers().Load("mock2")
+	test.Assert(t, ok)
+
+	// a newer batch replaces the whole map: mock2 is gone
+	err = rc.NotifyPolicyBatchChange(2, map[string]Policy{"mock1": bp})
+	test.Assert(t, err == nil, err)
+	r, ok := rc.retryers().Load("mock1")
+	test.Assert(t, ok)
+	test.Assert(t, r.(Retryer).Type() == BackupType)
+	_, ok = rc.retryers().Load("mock2")
+	test.Assert(t, !ok)
+
+	// stale or replayed versions are rejected
+	err = rc.NotifyPolicyBatchChange(2, map[string]Policy{"mock2": fp})
+	test.Assert(t, err != nil)
+	_, ok = rc.retryers().Load("mock2")
+	test.Assert(t, !ok)
+
+	// an invalid policy rejects the whole batch, old policies stay in effect
+	invalid := Policy{Enable: true, Type: BackupType}
+	err = rc.NotifyPolicyBatchChange(3, map[string]Policy{"mock1": fp, "mock3": invalid})
+	test.Assert(t, err != nil)
+	r, ok = rc.retryers().Load("mock1")
+	test.Assert(t, ok)
+	test.Assert(t, r.(Retryer).Type() == BackupType)
+
+	// code config keeps priority over remote batches
+	rcCode := NewRetryContainer()
+	defer rcCode.Close()
+	err = rcCode.Init(map[string]Policy{Wildcard: fp}, nil)
+	test.Assert(t, err == nil, err)
+	err = rcCode.NotifyPolicyBatchChange(1, map[string]Policy{})
+	test.Assert(t, err == nil, err)
+	_, ok = rcCode.retryers().Load(Wildcard)
+	test.Assert(t, ok)
+}
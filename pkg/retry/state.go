@@ -0,0 +1,150 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retry
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/bytedance/gopkg/cloud/circuitbreaker"
+)
+
+// maxRestoreSamples caps the number of circuit breaker samples replayed per
+// key by LoadState; larger dumped counts are scaled down proportionally so
+// the restored error rate stays the same.
+const maxRestoreSamples = 1000
+
+// ContainerState is the serializable snapshot of a Container produced by
+// DumpState and consumed by LoadState.
+type ContainerState struct {
+	PolicyVersion int64             `json:"policy_version,omitempty"`
+	Policies      map[string]Policy `json:"policies,omitempty"`
+	CBStats       map[string]CBStat `json:"cb_stats,omitempty"`
+}
+
+// CBStat is the dumped sample counts of one retry circuit breaker key.
+type CBStat struct {
+	Successes int64 `json:"successes"`
+	Failures  int64 `json:"failures"`
+	Timeouts  int64 `json:"timeouts"`
+}
+
+// DumpState serializes the per-method retry policies and the retry circuit
+// breaker sample counts of the container, so a hot-restarted process (see
+// server.WithHotRestart) can hand them to its successor instead of letting it
+// start with cold retry circuit breakers. Policies of user-implemented
+// retryers cannot be dumped and are skipped.
+func (rc *Container) DumpState() ([]byte, error) {
+	s := &ContainerState{
+		Policies: make(map[string]Policy),
+		CBStats:  make(map[string]CBStat),
+	}
+	rc.RLock()
+	s.PolicyVersion = rc.policyVersion
+	rc.retryers().Range(func(key, value interface{}) bool {
+		if r, ok := value.(Retryer); ok {
+			if p, ok := retryerPolicy(r); ok {
+				s.Policies[key.(string)] = p
+			}
+		}
+		return true
+	})
+	rc.RUnlock()
+	for key, b := range rc.cbContainer.cbPanel.DumpBreakers() {
+		successes, failures, timeouts := b.Metricer().Counts()
+		if successes+failures+timeouts > 0 {
+			s.CBStats[key] = CBStat{Successes: successes, Failures: failures, Timeouts: timeouts}
+		}
+	}
+	return json.Marshal(s)
+}
+
+// LoadState restores a snapshot dumped by DumpState of the previous process.
+// Policies are applied like remote config updates, so they never override
+// policies set up by code, and a stale PolicyVersion cannot roll back a newer
+// batch. Circuit breaker counts are replayed into the panel with their error
+// rate preserved, capped at maxRestoreSamples samples per key since the
+// original time distribution cannot be reproduced.
+func (rc *Container) LoadState(data []byte) error {
+	s := &ContainerState{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return fmt.Errorf("unmarshal retry container state failed: %w", err)
+	}
+	for key, p := range s.Policies {
+		rc.NotifyPolicyChange(key, p)
+	}
+	if s.PolicyVersion > 0 {
+		rc.Lock()
+		if s.PolicyVersion > rc.policyVersion {
+			rc.policyVersion = s.PolicyVersion
+		}
+		rc.Unlock()
+	}
+	for key, st := range s.CBStats {
+		replayCBStat(rc.cbContainer.cbPanel, key, st)
+	}
+	return nil
+}
+
+// retryerPolicy rebuilds the Policy a builtin retryer was configured with.
+func retryerPolicy(r Retryer) (Policy, bool) {
+	switch v := r.(type) {
+	case *failureRetryer:
+		v.RLock()
+		defer v.RUnlock()
+		if v.policy == nil {
+			return Policy{}, false
+		}
+		return Policy{Enable: v.enable, Type: FailureType, FailurePolicy: v.policy.DeepCopy()}, true
+	case *backupRetryer:
+		v.RLock()
+		defer v.RUnlock()
+		if v.policy == nil {
+			return Policy{}, false
+		}
+		return Policy{Enable: v.enable, Type: BackupType, BackupPolicy: v.policy.DeepCopy()}, true
+	case *mixedRetryer:
+		v.RLock()
+		defer v.RUnlock()
+		if v.policy == nil {
+			return Policy{}, false
+		}
+		return Policy{Enable: v.enable, Type: MixedType, MixedPolicy: v.policy.DeepCopy()}, true
+	}
+	return Policy{}, false
+}
+
+// replayCBStat feeds the dumped sample counts back into the panel.
+func replayCBStat(panel circuitbreaker.Panel, key string, st CBStat) {
+	total := st.Successes + st.Failures + st.Timeouts
+	if total <= 0 {
+		return
+	}
+	scale := 1.0
+	if total > maxRestoreSamples {
+		scale = float64(maxRestoreSamples) / float64(total)
+	}
+	for i := int64(0); i < int64(float64(st.Successes)*scale); i++ {
+		panel.Succeed(key)
+	}
+	for i := int64(0); i < int64(float64(st.Failures)*scale); i++ {
+		panel.Fail(key)
+	}
+	for i := int64(0); i < int64(float64(st.Timeouts)*scale); i++ {
+		panel.Timeout(key)
+	}
+}
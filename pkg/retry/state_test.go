@@ -0,0 +1,95 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+	"github.com/cloudwego/kitex/pkg/rpcinfo/remoteinfo"
+)
+
+func genRPCInfoWithMethod(m string) rpcinfo.RPCInfo {
+	to := remoteinfo.NewRemoteInfo(&rpcinfo.EndpointBasicInfo{Method: m}, m).ImmutableView()
+	return rpcinfo.NewRPCInfo(to, to, rpcinfo.NewInvocation("", m), rpcinfo.NewRPCConfig(), rpcinfo.NewRPCStats())
+}
+
+func TestDumpLoadState(t *testing.T) {
+	rc := NewRetryContainer()
+	defer rc.Close()
+	fp := NewFailurePolicy()
+	fp.WithMaxRetryTimes(2)
+	bp := NewBackupPolicy(20)
+	err := rc.NotifyPolicyBatchChange(7, map[string]Policy{
+		"mock1": BuildFailurePolicy(fp),
+		"mock2": BuildBackupRequest(bp),
+	})
+	test.Assert(t, err == nil, err)
+	// warm up the retry circuit breaker of mock1
+	cbKey := "mock//mock1"
+	for i := 0; i < 80; i++ {
+		rc.cbContainer.cbPanel.Succeed(cbKey)
+	}
+	for i := 0; i < 20; i++ {
+		rc.cbContainer.cbPanel.Fail(cbKey)
+	}
+
+	data, err := rc.DumpState()
+	test.Assert(t, err == nil, err)
+
+	// the successor starts cold and picks the state up
+	rc2 := NewRetryContainer()
+	defer rc2.Close()
+	err = rc2.LoadState(data)
+	test.Assert(t, err == nil, err)
+	r := rc2.getRetryer(context.Background(), genRPCInfoWithMethod("mock1"))
+	test.Assert(t, r != nil && r.Type() == FailureType, r)
+	fr, ok := r.(*failureRetryer)
+	test.Assert(t, ok)
+	test.Assert(t, fr.policy.Equals(fp), fr.policy)
+	r = rc2.getRetryer(context.Background(), genRPCInfoWithMethod("mock2"))
+	test.Assert(t, r != nil && r.Type() == BackupType, r)
+	test.Assert(t, rc2.policyVersion == 7, rc2.policyVersion)
+
+	// the error rate of the breaker survives the restart
+	successes, failures, _ := rc2.cbContainer.cbPanel.GetMetricer(cbKey).Counts()
+	test.Assert(t, successes == 80, successes)
+	test.Assert(t, failures == 20, failures)
+
+	// counts above the cap are scaled down with the rate preserved
+	rc3 := NewRetryContainer()
+	defer rc3.Close()
+	replayCBStat(rc3.cbContainer.cbPanel, cbKey, CBStat{Successes: 8000, Failures: 2000})
+	successes, failures, _ = rc3.cbContainer.cbPanel.GetMetricer(cbKey).Counts()
+	test.Assert(t, successes == 800, successes)
+	test.Assert(t, failures == 200, failures)
+
+	// code-configured policies are not overridden by a loaded snapshot
+	rc4 := NewRetryContainer()
+	defer rc4.Close()
+	err = rc4.Init(map[string]Policy{"mock1": BuildBackupRequest(bp)}, nil)
+	test.Assert(t, err == nil, err)
+	err = rc4.LoadState(data)
+	test.Assert(t, err == nil, err)
+	r = rc4.getRetryer(context.Background(), genRPCInfoWithMethod("mock1"))
+	test.Assert(t, r != nil && r.Type() == BackupType, r)
+
+	// garbage input is rejected
+	test.Assert(t, rc2.LoadState([]byte("not json")) != nil)
+}
@@ -31,6 +31,7 @@ const (
 	RetryPrevInstTag = "prev_inst"
 	ShmIPCTag        = "shmipc"
 	RemoteClosedTag  = "remote_closed"
+	StatsNamespace   = "stats_namespace"
 )
 
 // client HTTP
@@ -28,6 +28,7 @@ const (
 	Thrift PayloadCodec = iota
 	Protobuf
 	Hessian2
+	JSON
 )
 
 const (
@@ -197,6 +198,8 @@ func (p PayloadCodec) String() string {
 		return "Protobuf"
 	case Hessian2:
 		return "Hessian2"
+	case JSON:
+		return "JSON"
 	}
 	panic("unknown payload type")
 }
@@ -0,0 +1,112 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package shedding implements priority-based server load shedding.
+// While the server is overloaded, requests below a configurable priority are
+// rejected with ErrServerOverloaded before reaching the business handler.
+package shedding
+
+import (
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudwego/kitex/pkg/kerrors"
+)
+
+// PriorityHeaderKey is the TTHeader transient key carrying the request
+// priority, a non-negative integer where higher means more important.
+// Requests without the key get DefaultPriority.
+const PriorityHeaderKey = "rpc-priority"
+
+// DefaultPriority is assumed for requests which don't carry a priority.
+const DefaultPriority = 100
+
+// ErrServerOverloaded is returned for requests shed due to overload.
+var ErrServerOverloaded = kerrors.ErrOverlimit.WithCause(errors.New("server overloaded, request shed"))
+
+// Policy configures load shedding.
+type Policy struct {
+	// MaxQueueDelay is the queueing delay (between a request being read off
+	// the connection and its decoding) beyond which the server is considered
+	// overloaded. Zero disables shedding.
+	MaxQueueDelay time.Duration
+
+	// ShedBelowPriority: while overloaded, only requests whose priority is
+	// strictly below this value are shed. Defaults to DefaultPriority, which
+	// sheds requests explicitly marked as low-priority.
+	ShedBelowPriority int
+}
+
+// A Shedder measures queueing delay and decides whether a request of a given
+// priority should be shed. It is safe for concurrent use.
+type Shedder struct {
+	policy Policy
+
+	// ewmaDelayNs holds an exponentially weighted moving average of the
+	// observed queueing delay, in nanoseconds.
+	ewmaDelayNs int64
+}
+
+// ewmaDecay is the weight of a new sample in the moving average.
+const ewmaDecay = 0.2
+
+// NewShedder creates a Shedder with the given policy.
+func NewShedder(p Policy) *Shedder {
+	if p.ShedBelowPriority == 0 {
+		p.ShedBelowPriority = DefaultPriority
+	}
+	return &Shedder{policy: p}
+}
+
+// Observe records one queueing delay sample.
+func (s *Shedder) Observe(queueDelay time.Duration) {
+	for {
+		old := atomic.LoadInt64(&s.ewmaDelayNs)
+		ewma := int64(float64(old)*(1-ewmaDecay) + float64(queueDelay)*ewmaDecay)
+		if atomic.CompareAndSwapInt64(&s.ewmaDelayNs, old, ewma) {
+			return
+		}
+	}
+}
+
+// Overloaded reports whether the observed queueing delay exceeds the policy.
+func (s *Shedder) Overloaded() bool {
+	return s.policy.MaxQueueDelay > 0 &&
+		atomic.LoadInt64(&s.ewmaDelayNs) > int64(s.policy.MaxQueueDelay)
+}
+
+// Allow decides whether a request of the given priority may proceed.
+func (s *Shedder) Allow(priority int) bool {
+	if !s.Overloaded() {
+		return true
+	}
+	return priority >= s.policy.ShedBelowPriority
+}
+
+// ParsePriority parses the value of PriorityHeaderKey, falling back to
+// DefaultPriority for absent or malformed values.
+func ParsePriority(v string) int {
+	if v == "" {
+		return DefaultPriority
+	}
+	p, err := strconv.Atoi(v)
+	if err != nil || p < 0 {
+		return DefaultPriority
+	}
+	return p
+}
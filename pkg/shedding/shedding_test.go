@@ -0,0 +1,61 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package shedding
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudwego/kitex/internal/test"
+)
+
+func TestShedderAllow(t *testing.T) {
+	s := NewShedder(Policy{MaxQueueDelay: 10 * time.Millisecond})
+	// not overloaded: everything passes
+	test.Assert(t, s.Allow(0))
+
+	// drive the moving average far beyond the threshold
+	for i := 0; i < 50; i++ {
+		s.Observe(time.Second)
+	}
+	test.Assert(t, s.Overloaded())
+	test.Assert(t, !s.Allow(DefaultPriority-1))
+	test.Assert(t, s.Allow(DefaultPriority))
+
+	// recover
+	for i := 0; i < 200; i++ {
+		s.Observe(0)
+	}
+	test.Assert(t, !s.Overloaded())
+	test.Assert(t, s.Allow(0))
+}
+
+func TestShedderDisabled(t *testing.T) {
+	s := NewShedder(Policy{})
+	for i := 0; i < 50; i++ {
+		s.Observe(time.Second)
+	}
+	test.Assert(t, !s.Overloaded())
+	test.Assert(t, s.Allow(0))
+}
+
+func TestParsePriority(t *testing.T) {
+	test.Assert(t, ParsePriority("") == DefaultPriority)
+	test.Assert(t, ParsePriority("abc") == DefaultPriority)
+	test.Assert(t, ParsePriority("-1") == DefaultPriority)
+	test.Assert(t, ParsePriority("7") == 7)
+}
@@ -0,0 +1,166 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"sync"
+
+	internal_server "github.com/cloudwego/kitex/internal/server"
+	"github.com/cloudwego/kitex/pkg/diagnosis"
+	"github.com/cloudwego/kitex/pkg/klog"
+	"github.com/cloudwego/kitex/pkg/utils"
+)
+
+// WithAdminServer starts an admin HTTP server on addr when the server runs.
+// It exposes the registered diagnosis probes (/diagnosis), limiter state
+// (/limits), registered services and methods (/services) and pprof
+// (/debug/pprof/), so users no longer need to wire their own debug server.
+// If WithDiagnosisService is also used, it must be applied before this option,
+// otherwise the admin server cannot see the probes.
+func WithAdminServer(addr string) Option {
+	return Option{F: func(o *internal_server.Options, di *utils.Slice) {
+		di.Push(fmt.Sprintf("WithAdminServer(%s)", addr))
+
+		o.AdminAddr = addr
+		o.DebugService = newAdminService(o.DebugService)
+	}}
+}
+
+// adminService records diagnosis probes for the admin server and forwards
+// registrations to the diagnosis service configured before it.
+type adminService struct {
+	delegate diagnosis.Service
+
+	mu     sync.RWMutex
+	probes map[diagnosis.ProbeName]diagnosis.ProbeFunc
+}
+
+func newAdminService(delegate diagnosis.Service) *adminService {
+	return &adminService{
+		delegate: delegate,
+		probes:   make(map[diagnosis.ProbeName]diagnosis.ProbeFunc),
+	}
+}
+
+// RegisterProbeFunc implements the diagnosis.Service interface.
+func (a *adminService) RegisterProbeFunc(name diagnosis.ProbeName, pf diagnosis.ProbeFunc) {
+	a.mu.Lock()
+	a.probes[name] = pf
+	a.mu.Unlock()
+	diagnosis.RegisterProbeFunc(a.delegate, name, pf)
+}
+
+func (a *adminService) dump() map[string]json.RawMessage {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	m := make(map[string]json.RawMessage, len(a.probes))
+	for name, pf := range a.probes {
+		v := pf()
+		b, err := json.Marshal(v)
+		if err != nil {
+			b, _ = json.Marshal(fmt.Sprintf("%+v", v))
+		}
+		m[string(name)] = b
+	}
+	return m
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(b)
+}
+
+func (s *server) newAdminMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc("/diagnosis", func(w http.ResponseWriter, r *http.Request) {
+		if adm, ok := s.opt.DebugService.(*adminService); ok {
+			writeJSON(w, adm.dump())
+			return
+		}
+		http.Error(w, "diagnosis probes unavailable", http.StatusNotFound)
+	})
+	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		svcs := make(map[string][]string, len(s.svcs.svcMap))
+		for name, svc := range s.svcs.svcMap {
+			methods := make([]string, 0, len(svc.svcInfo.Methods))
+			for method := range svc.svcInfo.Methods {
+				methods = append(methods, method)
+			}
+			svcs[name] = methods
+		}
+		writeJSON(w, svcs)
+	})
+	mux.HandleFunc("/limits", func(w http.ResponseWriter, r *http.Request) {
+		type limitStatus struct {
+			Limit    int `json:"limit"`
+			Occupied int `json:"occupied"`
+		}
+		status := make(map[string]limitStatus, 2)
+		if lim := s.opt.Limit.ConLimit; lim != nil {
+			limit, occupied := lim.Status(r.Context())
+			status["connections"] = limitStatus{Limit: limit, Occupied: occupied}
+		}
+		if lim := s.opt.Limit.QPSLimit; lim != nil {
+			max, current, _ := lim.Status(r.Context())
+			status["qps"] = limitStatus{Limit: max, Occupied: current}
+		}
+		writeJSON(w, status)
+	})
+	return mux
+}
+
+// startAdminServer serves the admin endpoints on opt.AdminAddr.
+// It returns an error if the address cannot be bound.
+func (s *server) startAdminServer() error {
+	ln, err := net.Listen("tcp", s.opt.AdminAddr)
+	if err != nil {
+		return fmt.Errorf("admin server listen on %s failed: %w", s.opt.AdminAddr, err)
+	}
+	adminSvr := &http.Server{Handler: s.newAdminMux()}
+	go func() {
+		if err := adminSvr.Serve(ln); err != nil && err != http.ErrServerClosed {
+			klog.Errorf("KITEX: admin server error: error=%s", err.Error())
+		}
+	}()
+	s.Lock()
+	s.adminSvr = adminSvr
+	s.Unlock()
+	return nil
+}
+
+func (s *server) stopAdminServer() {
+	if s.adminSvr != nil {
+		_ = s.adminSvr.Shutdown(context.Background())
+		s.adminSvr = nil
+	}
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/mocks"
+	internal_server "github.com/cloudwego/kitex/internal/server"
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/diagnosis"
+	"github.com/cloudwego/kitex/pkg/limiter"
+)
+
+func TestWithAdminServer(t *testing.T) {
+	o := internal_server.NewOptions([]internal_server.Option{WithAdminServer("localhost:0")})
+	test.Assert(t, o.AdminAddr == "localhost:0")
+	adm, ok := o.DebugService.(*adminService)
+	test.Assert(t, ok)
+
+	adm.RegisterProbeFunc(diagnosis.ChangeEventsKey, diagnosis.WrapAsProbeFunc("ev"))
+	dump := adm.dump()
+	test.Assert(t, string(dump[string(diagnosis.ChangeEventsKey)]) == `"ev"`)
+}
+
+func TestAdminMux(t *testing.T) {
+	s := &server{
+		opt:  internal_server.NewOptions([]internal_server.Option{WithAdminServer("localhost:0")}),
+		svcs: newServices(),
+	}
+	err := s.svcs.addService(mocks.ServiceInfo(), mocks.MyServiceHandler(), &internal_server.RegisterOptions{})
+	test.Assert(t, err == nil, err)
+	s.opt.Limit.ConLimit = limiter.NewConnectionLimiter(100)
+	diagnosis.RegisterProbeFunc(s.opt.DebugService, diagnosis.ServiceInfosKey, diagnosis.WrapAsProbeFunc(s.svcs.getSvcInfoMap()))
+	mux := s.newAdminMux()
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest("GET", "/services", nil))
+	test.Assert(t, rec.Code == 200, rec.Code)
+	svcs := map[string][]string{}
+	test.Assert(t, json.Unmarshal(rec.Body.Bytes(), &svcs) == nil)
+	test.Assert(t, len(svcs[mocks.ServiceInfo().ServiceName]) > 0)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest("GET", "/limits", nil))
+	test.Assert(t, rec.Code == 200, rec.Code)
+	limits := map[string]struct{ Limit, Occupied int }{}
+	test.Assert(t, json.Unmarshal(rec.Body.Bytes(), &limits) == nil)
+	test.Assert(t, limits["connections"].Limit == 100, limits)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest("GET", "/diagnosis", nil))
+	test.Assert(t, rec.Code == 200, rec.Code)
+	probes := map[string]json.RawMessage{}
+	test.Assert(t, json.Unmarshal(rec.Body.Bytes(), &probes) == nil)
+	_, ok := probes[string(diagnosis.ServiceInfosKey)]
+	test.Assert(t, ok, probes)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest("GET", "/debug/pprof/cmdline", nil))
+	test.Assert(t, rec.Code == 200, rec.Code)
+}
+
+func TestAdminServerStartStop(t *testing.T) {
+	s := &server{
+		opt:  internal_server.NewOptions([]internal_server.Option{WithAdminServer("localhost:0")}),
+		svcs: newServices(),
+	}
+	err := s.startAdminServer()
+	test.Assert(t, err == nil, err)
+	test.Assert(t, s.adminSvr != nil)
+	s.stopAdminServer()
+	test.Assert(t, s.adminSvr == nil)
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	internal_server "github.com/cloudwego/kitex/internal/server"
+	"github.com/cloudwego/kitex/pkg/endpoint"
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+	"github.com/cloudwego/kitex/pkg/utils"
+)
+
+// WithErrorMapper maps errors returned by handlers before they reach the
+// transport, so handlers can return plain Go errors and still produce
+// protocol-correct exception payloads. If the mapped error implements
+// kerrors.BizStatusErrorIface, its status code is carried through the
+// TTHeader/gRPC biz-status mechanism. Returning nil from f keeps the
+// original error. See also ErrorMapper for a type-based registry.
+func WithErrorMapper(f func(ctx context.Context, err error) error) Option {
+	return Option{F: func(o *internal_server.Options, di *utils.Slice) {
+		di.Push(fmt.Sprintf("WithErrorMapper(%+v)", utils.GetFuncName(f)))
+
+		if f == nil {
+			panic(fmt.Errorf("invalid ErrorMapper: nil"))
+		}
+		o.MWBs = append(o.MWBs, func(ctx context.Context) endpoint.Middleware {
+			return newErrorMapperMW(f)
+		})
+	}}
+}
+
+// newErrorMapperMW builds the middleware applying the error mapping function.
+func newErrorMapperMW(f func(ctx context.Context, err error) error) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, req, resp interface{}) error {
+			err := next(ctx, req, resp)
+			if err == nil {
+				return nil
+			}
+			mapped := f(ctx, err)
+			if mapped == nil {
+				return err
+			}
+			if bizErr, ok := kerrors.FromBizStatusError(mapped); ok {
+				if ri := rpcinfo.GetRPCInfo(ctx); ri != nil {
+					if setter, ok := ri.Invocation().(rpcinfo.InvocationSetter); ok {
+						setter.SetBizStatusErr(bizErr)
+						return nil
+					}
+				}
+			}
+			return mapped
+		}
+	}
+}
+
+// ErrorMapper is a registry from Go error values to biz status errors, to be
+// used with WithErrorMapper:
+//
+//	em := server.NewErrorMapper()
+//	em.Register(biz.ErrNotFound, 404)
+//	svr := xxx.NewServer(handler, server.WithErrorMapper(em.Map))
+//
+// Errors are matched with errors.Is in registration order; unmatched errors
+// pass through unchanged.
+type ErrorMapper struct {
+	entries []errorMapping
+}
+
+type errorMapping struct {
+	target error
+	code   int32
+}
+
+// NewErrorMapper creates an empty ErrorMapper.
+func NewErrorMapper() *ErrorMapper {
+	return &ErrorMapper{}
+}
+
+// Register maps errors matching target (per errors.Is) to a biz status error
+// with the given code; the message is taken from the handler error.
+// It is not safe to call concurrently with Map.
+func (m *ErrorMapper) Register(target error, code int32) {
+	m.entries = append(m.entries, errorMapping{target: target, code: code})
+}
+
+// Map implements the mapping function of WithErrorMapper.
+func (m *ErrorMapper) Map(ctx context.Context, err error) error {
+	// biz errors were already typed by the handler
+	if _, ok := kerrors.FromBizStatusError(err); ok {
+		return nil
+	}
+	// the framework wraps handler errors with ErrBiz; match against the cause
+	cause := err
+	var de *kerrors.DetailedError
+	if errors.As(err, &de) {
+		cause = de.Unwrap()
+	}
+	for _, e := range m.entries {
+		if errors.Is(cause, e.target) {
+			return kerrors.NewBizStatusError(e.code, cause.Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+func TestErrorMapperRegistry(t *testing.T) {
+	errNotFound := errors.New("not found")
+	em := NewErrorMapper()
+	em.Register(errNotFound, 404)
+
+	// the framework-wrapped handler error is matched against its cause
+	mapped := em.Map(context.Background(), kerrors.ErrBiz.WithCause(errNotFound))
+	bizErr, ok := kerrors.FromBizStatusError(mapped)
+	test.Assert(t, ok)
+	test.Assert(t, bizErr.BizStatusCode() == 404)
+	test.Assert(t, bizErr.BizMessage() == "not found")
+
+	// unregistered errors pass through unchanged
+	test.Assert(t, em.Map(context.Background(), errors.New("other")) == nil)
+
+	// errors already typed by the handler are left alone
+	test.Assert(t, em.Map(context.Background(), kerrors.NewBizStatusError(500, "typed")) == nil)
+}
+
+func TestErrorMapperMW(t *testing.T) {
+	errNotFound := errors.New("not found")
+	em := NewErrorMapper()
+	em.Register(errNotFound, 404)
+	mw := newErrorMapperMW(em.Map)
+
+	ivk := rpcinfo.NewInvocation("mock.svc", "mock")
+	ri := rpcinfo.NewRPCInfo(nil, nil, ivk, nil, rpcinfo.NewRPCStats())
+	ctx := rpcinfo.NewCtxWithRPCInfo(context.Background(), ri)
+
+	// a mapped biz error is recorded on the invocation, not returned
+	ep := mw(func(ctx context.Context, req, resp interface{}) error {
+		return errNotFound
+	})
+	test.Assert(t, ep(ctx, nil, nil) == nil)
+	test.Assert(t, ivk.BizStatusErr() != nil)
+	test.Assert(t, ivk.BizStatusErr().BizStatusCode() == 404)
+
+	// unmapped errors keep flowing to the transport
+	other := errors.New("other")
+	ep = mw(func(ctx context.Context, req, resp interface{}) error {
+		return other
+	})
+	test.Assert(t, ep(ctx, nil, nil) == other)
+
+	// success path is untouched
+	ep = mw(func(ctx context.Context, req, resp interface{}) error {
+		return nil
+	})
+	test.Assert(t, ep(ctx, nil, nil) == nil)
+}
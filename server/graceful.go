@@ -0,0 +1,105 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"fmt"
+	"time"
+
+	internal_server "github.com/cloudwego/kitex/internal/server"
+	"github.com/cloudwego/kitex/pkg/event"
+	"github.com/cloudwego/kitex/pkg/utils"
+)
+
+// ShutdownStage identifies a stage of the graceful shutdown sequence,
+// see WithGracefulShutdown.
+type ShutdownStage = internal_server.ShutdownStage
+
+const (
+	// StageDeregister removes the instance from the registry.
+	StageDeregister = internal_server.StageDeregister
+	// StageWaitPropagation waits for resolver caches to drop the instance.
+	StageWaitPropagation = internal_server.StageWaitPropagation
+	// StageDrain stops accepting and drains inflight requests; the transport
+	// applies its own semantics (gRPC GOAWAY, waiting out mux connections).
+	StageDrain = internal_server.StageDrain
+	// StageExitHooks runs the hooks registered with RegisterShutdownHook.
+	StageExitHooks = internal_server.StageExitHooks
+)
+
+// GracefulShutdownPolicy configures the ordered shutdown sequence,
+// see WithGracefulShutdown.
+type GracefulShutdownPolicy = internal_server.GracefulShutdownPolicy
+
+// WithGracefulShutdown makes Stop run a draining-aware sequence: deregister
+// from the registry first, wait PropagationDelay so resolvers stop routing
+// new calls here, then stop accepting and drain inflight requests, and run
+// shutdown hooks last. Each stage is reported to policy.OnStage (if set) and
+// recorded as an event for diagnosis.
+func WithGracefulShutdown(policy GracefulShutdownPolicy) Option {
+	return Option{F: func(o *internal_server.Options, di *utils.Slice) {
+		di.Push(fmt.Sprintf("WithGracefulShutdown(%+v)", policy))
+
+		o.GracefulShutdown = &policy
+	}}
+}
+
+// enterShutdownStage reports the stage to the policy hook and the event queue.
+func (s *server) enterShutdownStage(policy *GracefulShutdownPolicy, stage ShutdownStage) {
+	s.opt.Events.Push(&event.Event{
+		Name:   "shutdown",
+		Time:   time.Now(),
+		Detail: stage.String(),
+	})
+	if policy.OnStage != nil {
+		policy.OnStage(stage)
+	}
+}
+
+// gracefulStop implements the ordered shutdown sequence of Stop when
+// WithGracefulShutdown is configured.
+func (s *server) gracefulStop(policy *GracefulShutdownPolicy) (err error) {
+	s.enterShutdownStage(policy, StageDeregister)
+	if s.opt.RegistryInfo != nil {
+		err = s.opt.Registry.Deregister(s.opt.RegistryInfo)
+		s.opt.RegistryInfo = nil
+	}
+
+	s.enterShutdownStage(policy, StageWaitPropagation)
+	if policy.PropagationDelay > 0 {
+		time.Sleep(policy.PropagationDelay)
+	}
+
+	s.enterShutdownStage(policy, StageDrain)
+	if s.svr != nil {
+		if e := s.svr.Stop(); e != nil {
+			err = e
+		}
+		s.svr = nil
+	}
+	if e := s.stopExtraListeners(); e != nil && err == nil {
+		err = e
+	}
+
+	s.enterShutdownStage(policy, StageExitHooks)
+	muShutdownHooks.Lock()
+	for i := range onShutdown {
+		onShutdown[i]()
+	}
+	muShutdownHooks.Unlock()
+	return err
+}
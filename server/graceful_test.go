@@ -0,0 +1,71 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	internal_server "github.com/cloudwego/kitex/internal/server"
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/registry"
+)
+
+type mockDeregisterRegistry struct {
+	registry.Registry
+	deregistered chan *registry.Info
+}
+
+func (m *mockDeregisterRegistry) Deregister(info *registry.Info) error {
+	m.deregistered <- info
+	return nil
+}
+
+func TestGracefulStop(t *testing.T) {
+	var stages []ShutdownStage
+	reg := &mockDeregisterRegistry{deregistered: make(chan *registry.Info, 1)}
+	policy := GracefulShutdownPolicy{
+		PropagationDelay: 10 * time.Millisecond,
+		OnStage: func(stage ShutdownStage) {
+			stages = append(stages, stage)
+			if stage == StageWaitPropagation {
+				// the registry must be clean before waiting for propagation
+				test.Assert(t, len(reg.deregistered) == 1)
+			}
+		},
+	}
+	s := &server{
+		opt:  internal_server.NewOptions([]internal_server.Option{WithRegistry(reg), WithGracefulShutdown(policy)}),
+		svcs: newServices(),
+	}
+	s.opt.RegistryInfo = &registry.Info{ServiceName: "svc"}
+
+	start := time.Now()
+	err := s.Stop()
+	test.Assert(t, err == nil, err)
+	test.Assert(t, time.Since(start) >= 10*time.Millisecond)
+	test.Assert(t, s.opt.RegistryInfo == nil)
+	test.DeepEqual(t, stages, []ShutdownStage{StageDeregister, StageWaitPropagation, StageDrain, StageExitHooks})
+}
+
+func TestShutdownStageString(t *testing.T) {
+	test.Assert(t, StageDeregister.String() == "deregister")
+	test.Assert(t, StageWaitPropagation.String() == "wait_propagation")
+	test.Assert(t, StageDrain.String() == "drain")
+	test.Assert(t, StageExitHooks.String() == "exit_hooks")
+	test.Assert(t, ShutdownStage(100).String() == "unknown")
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+//go:build !windows
+
+package server
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	internal_server "github.com/cloudwego/kitex/internal/server"
+	"github.com/cloudwego/kitex/pkg/klog"
+	"github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/utils"
+)
+
+// hotRestartFDEnv tells a freshly exec'd child which inherited FD carries the
+// listener. The FD number follows stdin/stdout/stderr, see exec.Cmd.ExtraFiles.
+const hotRestartFDEnv = "KITEX_HOT_RESTART_FD"
+
+// WithHotRestart enables seamless binary upgrade. On SIGUSR2 the server
+// re-execs its own binary with the listener FD inherited, then stops
+// gracefully, draining old connections while the child accepts new ones.
+// A process started by the handover picks the listener up from the
+// inherited FD instead of binding the address again.
+func WithHotRestart() Option {
+	return Option{F: func(o *internal_server.Options, di *utils.Slice) {
+		di.Push("WithHotRestart()")
+
+		ln, err := inheritedListener()
+		if err != nil {
+			panic(fmt.Errorf("WithHotRestart: restore inherited listener failed: %w", err))
+		}
+		if ln != nil {
+			o.RemoteOpt.Listener = ln
+		}
+		o.EnableHotRestart = true
+	}}
+}
+
+// inheritedListener rebuilds the listener from the FD passed by the parent
+// process, or returns nil if this process was not started by a handover.
+func inheritedListener() (net.Listener, error) {
+	v := os.Getenv(hotRestartFDEnv)
+	if v == "" {
+		return nil, nil
+	}
+	os.Unsetenv(hotRestartFDEnv)
+	fd, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s=%q", hotRestartFDEnv, v)
+	}
+	f := os.NewFile(uintptr(fd), "listener")
+	defer f.Close()
+	return net.FileListener(f)
+}
+
+// setupHotRestart makes sure the listener is created by us (rather than deep
+// inside the transport server) so its FD can be passed on later.
+func (s *server) setupHotRestart(svrCfg *remote.ServerOption) (err error) {
+	if svrCfg.Listener != nil {
+		return nil
+	}
+	addr := svrCfg.Address
+	svrCfg.Listener, err = net.Listen(addr.Network(), addr.String())
+	return err
+}
+
+// waitHotRestart blocks until SIGUSR2, then forks the child with the listener
+// FD inherited and stops this server gracefully.
+func (s *server) waitHotRestart() {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGUSR2)
+	<-ch
+	signal.Stop(ch)
+	if err := forkHotRestartChild(s.opt.RemoteOpt.Listener); err != nil {
+		klog.Errorf("KITEX: hot restart failed to start child process: error=%s", err.Error())
+		return
+	}
+	klog.Info("KITEX: hot restart handover done, stopping the old server")
+	if err := s.Stop(); err != nil {
+		klog.Errorf("KITEX: hot restart stop server error=%s", err.Error())
+	}
+}
+
+func forkHotRestartChild(ln net.Listener) error {
+	filer, ok := ln.(interface{ File() (*os.File, error) })
+	if !ok {
+		return fmt.Errorf("listener %T does not expose its FD", ln)
+	}
+	f, err := filer.File()
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	cmd := exec.Command(os.Args[0], os.Args[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = []*os.File{f}
+	// the single ExtraFiles entry lands on FD 3 in the child
+	cmd.Env = append(os.Environ(), hotRestartFDEnv+"=3")
+	return cmd.Start()
+}
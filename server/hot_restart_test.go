@@ -0,0 +1,53 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+//go:build !windows
+
+package server
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/cloudwego/kitex/internal/test"
+)
+
+func TestInheritedListenerAbsent(t *testing.T) {
+	os.Unsetenv(hotRestartFDEnv)
+	ln, err := inheritedListener()
+	test.Assert(t, err == nil, err)
+	test.Assert(t, ln == nil)
+}
+
+func TestInheritedListener(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	test.Assert(t, err == nil, err)
+	defer ln.Close()
+	f, err := ln.(*net.TCPListener).File()
+	test.Assert(t, err == nil, err)
+	defer f.Close()
+
+	os.Setenv(hotRestartFDEnv, strconv.Itoa(int(f.Fd())))
+	got, err := inheritedListener()
+	test.Assert(t, err == nil, err)
+	test.Assert(t, got != nil)
+	defer got.Close()
+	test.Assert(t, got.Addr().String() == ln.Addr().String())
+	// the env must not leak into grandchildren
+	test.Assert(t, os.Getenv(hotRestartFDEnv) == "")
+}
@@ -0,0 +1,33 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+//go:build windows
+
+package server
+
+import (
+	"github.com/cloudwego/kitex/pkg/remote"
+)
+
+// WithHotRestart is not supported on windows, which lacks SIGUSR2 and
+// listener FD inheritance.
+func WithHotRestart() Option {
+	panic("WithHotRestart is not supported on windows")
+}
+
+func (s *server) setupHotRestart(svrCfg *remote.ServerOption) error { return nil }
+
+func (s *server) waitHotRestart() {}
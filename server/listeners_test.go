@@ -0,0 +1,76 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/kitex/internal/mocks"
+	internal_server "github.com/cloudwego/kitex/internal/server"
+	"github.com/cloudwego/kitex/internal/test"
+)
+
+func TestWithListenersOption(t *testing.T) {
+	ln1, err := net.Listen("tcp", "127.0.0.1:0")
+	test.Assert(t, err == nil, err)
+	defer ln1.Close()
+	ln2, err := net.Listen("tcp", "127.0.0.1:0")
+	test.Assert(t, err == nil, err)
+	defer ln2.Close()
+
+	o := internal_server.NewOptions([]internal_server.Option{WithListeners(ln1, ln2)})
+	test.Assert(t, o.RemoteOpt.Listener == ln1)
+	test.Assert(t, len(o.ExtraListeners) == 1 && o.ExtraListeners[0] == ln2)
+
+	test.Panic(t, func() {
+		NewServer(WithListeners())
+	})
+}
+
+func TestMultiListenerServe(t *testing.T) {
+	ln1, err := net.Listen("tcp", "127.0.0.1:0")
+	test.Assert(t, err == nil, err)
+	ln2, err := net.Listen("tcp", "127.0.0.1:0")
+	test.Assert(t, err == nil, err)
+
+	svr := NewServer(WithListeners(ln1, ln2), WithExitWaitTime(time.Millisecond))
+	err = svr.RegisterService(mocks.ServiceInfo(), mocks.MyServiceHandler())
+	test.Assert(t, err == nil, err)
+
+	runDone := make(chan error, 1)
+	go func() { runDone <- svr.Run() }()
+	WaitServer(svr)
+	test.Assert(t, len(svr.(*server).extraSvrs) == 1)
+
+	// both addresses accept connections
+	for _, addr := range []string{ln1.Addr().String(), ln2.Addr().String()} {
+		conn, err := net.DialTimeout("tcp", addr, time.Second)
+		test.Assert(t, err == nil, err)
+		conn.Close()
+	}
+
+	err = svr.Stop()
+	test.Assert(t, err == nil, err)
+	select {
+	case err = <-runDone:
+		test.Assert(t, err == nil, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not exit")
+	}
+}
@@ -23,6 +23,20 @@ import (
 	"github.com/cloudwego/kitex/pkg/rpcinfo"
 )
 
+// newSelectiveMW wraps mw so that it only takes effect for requests matched
+// by the given func; other requests go straight to the next endpoint.
+func newSelectiveMW(match func(ri rpcinfo.RPCInfo) bool, mw endpoint.Middleware) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		wrapped := mw(next)
+		return func(ctx context.Context, request, response interface{}) error {
+			if ri := rpcinfo.GetRPCInfo(ctx); ri != nil && match(ri) {
+				return wrapped(ctx, request, response)
+			}
+			return next(ctx, request, response)
+		}
+	}
+}
+
 func serverTimeoutMW(next endpoint.Endpoint) endpoint.Endpoint {
 	return func(ctx context.Context, request, response interface{}) (err error) {
 		// Regardless of the underlying protocol, only by checking the RPCTimeout
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/endpoint"
 	"github.com/cloudwego/kitex/pkg/rpcinfo"
 )
 
@@ -194,3 +195,34 @@ func Test_serverTimeoutMW(t *testing.T) {
 		<-waitFinish
 	})
 }
+
+func Test_newSelectiveMW(t *testing.T) {
+	to := rpcinfo.NewEndpointInfo("to_service", "to_method", nil, nil)
+	ri := rpcinfo.NewRPCInfo(nil, to, nil, rpcinfo.NewRPCConfig(), nil)
+	ctx := rpcinfo.NewCtxWithRPCInfo(context.Background(), ri)
+
+	var called int
+	mw := func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, req, resp interface{}) error {
+			called++
+			return next(ctx, req, resp)
+		}
+	}
+	noop := func(ctx context.Context, req, resp interface{}) error { return nil }
+
+	matched := newSelectiveMW(func(ri rpcinfo.RPCInfo) bool {
+		return ri.To().Method() == "to_method"
+	}, mw)(noop)
+	test.Assert(t, matched(ctx, nil, nil) == nil)
+	test.Assert(t, called == 1, called)
+
+	unmatched := newSelectiveMW(func(ri rpcinfo.RPCInfo) bool {
+		return ri.To().Method() == "other_method"
+	}, mw)(noop)
+	test.Assert(t, unmatched(ctx, nil, nil) == nil)
+	test.Assert(t, called == 1, called)
+
+	// no rpcinfo in ctx: middleware is skipped
+	test.Assert(t, matched(context.Background(), nil, nil) == nil)
+	test.Assert(t, called == 1, called)
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/bytedance/gopkg/lang/fastrand"
+
+	"github.com/cloudwego/kitex/client"
+	internal_server "github.com/cloudwego/kitex/internal/server"
+	"github.com/cloudwego/kitex/pkg/endpoint"
+	"github.com/cloudwego/kitex/pkg/gofunc"
+	"github.com/cloudwego/kitex/pkg/klog"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+	"github.com/cloudwego/kitex/pkg/serviceinfo"
+	"github.com/cloudwego/kitex/pkg/streaming"
+	"github.com/cloudwego/kitex/pkg/utils"
+)
+
+// WithTrafficMirror asynchronously re-sends a copy of sampled incoming
+// requests to the shadow cluster at target ("host:port"), discarding the
+// responses, so a new version can be validated with production traffic.
+// sampleRate is the sampled fraction in (0, 1]. Streaming requests are not
+// mirrored. The mirrored request object is shared with the original call,
+// so handlers must not mutate their request.
+func WithTrafficMirror(target string, sampleRate float64) Option {
+	return Option{F: func(o *internal_server.Options, di *utils.Slice) {
+		di.Push(fmt.Sprintf("WithTrafficMirror(%s,%v)", target, sampleRate))
+
+		if target == "" {
+			panic(fmt.Errorf("invalid TrafficMirror target: empty"))
+		}
+		if sampleRate <= 0 || sampleRate > 1 {
+			panic(fmt.Errorf("invalid TrafficMirror sample rate: %v", sampleRate))
+		}
+		o.TrafficMirror = &internal_server.TrafficMirror{Target: target, SampleRate: sampleRate}
+	}}
+}
+
+// trafficMirror holds the lazily built clients towards the shadow cluster,
+// one per registered service.
+type trafficMirror struct {
+	target     string
+	sampleRate float64
+
+	mu      sync.Mutex
+	clients map[string]client.Client
+}
+
+func newTrafficMirror(cfg *internal_server.TrafficMirror) *trafficMirror {
+	return &trafficMirror{
+		target:     cfg.Target,
+		sampleRate: cfg.SampleRate,
+		clients:    make(map[string]client.Client),
+	}
+}
+
+func (m *trafficMirror) getClient(svcInfo *serviceinfo.ServiceInfo) (client.Client, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if cli, ok := m.clients[svcInfo.ServiceName]; ok {
+		return cli, nil
+	}
+	cli, err := client.NewClient(svcInfo, client.WithHostPorts(m.target))
+	if err != nil {
+		return nil, err
+	}
+	m.clients[svcInfo.ServiceName] = cli
+	return cli, nil
+}
+
+func (m *trafficMirror) mirror(svcInfo *serviceinfo.ServiceInfo, methodName string, request interface{}) {
+	cli, err := m.getClient(svcInfo)
+	if err != nil {
+		klog.Warnf("KITEX: traffic mirror build client failed, service=%s error=%s", svcInfo.ServiceName, err.Error())
+		return
+	}
+	minfo := svcInfo.MethodInfo(methodName)
+	if minfo == nil {
+		return
+	}
+	gofunc.GoFunc(context.Background(), func() {
+		if err := cli.Call(context.Background(), methodName, request, minfo.NewResult()); err != nil {
+			klog.Debugf("KITEX: traffic mirror call failed, method=%s error=%s", methodName, err.Error())
+		}
+	})
+}
+
+func (s *server) newTrafficMirrorMW() endpoint.Middleware {
+	return newTrafficMirrorMW(newTrafficMirror(s.opt.TrafficMirror), s.svcs)
+}
+
+// newTrafficMirrorMW samples unary requests after they complete and forwards
+// a copy to the shadow cluster.
+func newTrafficMirrorMW(m *trafficMirror, svcs *services) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, req, resp interface{}) error {
+			err := next(ctx, req, resp)
+			if _, ok := req.(*streaming.Args); ok {
+				return err
+			}
+			if fastrand.Float64() >= m.sampleRate {
+				return err
+			}
+			ri := rpcinfo.GetRPCInfo(ctx)
+			if ri == nil {
+				return err
+			}
+			if svc, ok := svcs.svcMap[ri.Invocation().ServiceName()]; ok {
+				m.mirror(svc.svcInfo, ri.Invocation().MethodName(), req)
+			}
+			return err
+		}
+	}
+}
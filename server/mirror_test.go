@@ -0,0 +1,76 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/kitex/internal/mocks"
+	internal_server "github.com/cloudwego/kitex/internal/server"
+	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+type mockMirrorClient struct {
+	calls chan string
+}
+
+func (c *mockMirrorClient) Call(ctx context.Context, method string, request, response interface{}) error {
+	c.calls <- method
+	return nil
+}
+
+func TestWithTrafficMirror(t *testing.T) {
+	o := internal_server.NewOptions([]internal_server.Option{WithTrafficMirror("127.0.0.1:8888", 0.5)})
+	test.Assert(t, o.TrafficMirror.Target == "127.0.0.1:8888")
+	test.Assert(t, o.TrafficMirror.SampleRate == 0.5)
+
+	test.Panic(t, func() { NewServer(WithTrafficMirror("", 1)) })
+	test.Panic(t, func() { NewServer(WithTrafficMirror("127.0.0.1:8888", 0)) })
+	test.Panic(t, func() { NewServer(WithTrafficMirror("127.0.0.1:8888", 1.5)) })
+}
+
+func TestTrafficMirrorMW(t *testing.T) {
+	callMethod := "mock"
+	svcs := newServices()
+	err := svcs.addService(svcInfo, mocks.MyServiceHandler(), &RegisterOptions{})
+	test.Assert(t, err == nil, err)
+
+	cli := &mockMirrorClient{calls: make(chan string, 1)}
+	m := newTrafficMirror(&internal_server.TrafficMirror{Target: "127.0.0.1:8888", SampleRate: 1})
+	m.clients[svcInfo.ServiceName] = cli
+
+	handled := false
+	ep := newTrafficMirrorMW(m, svcs)(func(ctx context.Context, req, resp interface{}) error {
+		handled = true
+		return nil
+	})
+	ri := rpcinfo.NewRPCInfo(nil, nil, rpcinfo.NewInvocation(svcInfo.ServiceName, callMethod), nil, nil)
+	ctx := rpcinfo.NewCtxWithRPCInfo(context.Background(), ri)
+	err = ep(ctx, svcInfo.MethodInfo(callMethod).NewArgs(), svcInfo.MethodInfo(callMethod).NewResult())
+	test.Assert(t, err == nil, err)
+	test.Assert(t, handled)
+
+	select {
+	case method := <-cli.calls:
+		test.Assert(t, method == callMethod, method)
+	case <-time.After(time.Second):
+		t.Fatal("mirror call not issued")
+	}
+}
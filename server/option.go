@@ -31,6 +31,7 @@ import (
 	"github.com/cloudwego/kitex/pkg/limiter"
 	"github.com/cloudwego/kitex/pkg/registry"
 	"github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/remote/codec"
 	"github.com/cloudwego/kitex/pkg/remote/codec/protobuf"
 	"github.com/cloudwego/kitex/pkg/remote/codec/thrift"
 	"github.com/cloudwego/kitex/pkg/remote/trans/netpollmux"
@@ -101,6 +102,28 @@ func WithMiddleware(mw endpoint.Middleware) Option {
 	}}
 }
 
+// WithMethodMiddleware adds middleware for server which only takes effect for
+// the given method, so expensive middlewares can be limited to the methods
+// that need them.
+func WithMethodMiddleware(method string, mw endpoint.Middleware) Option {
+	return WithMatchedMiddleware(func(ri rpcinfo.RPCInfo) bool {
+		return ri.To().Method() == method
+	}, mw)
+}
+
+// WithMatchedMiddleware adds middleware for server which only takes effect
+// for requests matched by the given func.
+func WithMatchedMiddleware(match func(ri rpcinfo.RPCInfo) bool, mw endpoint.Middleware) Option {
+	selective := newSelectiveMW(match, mw)
+	mwb := func(ctx context.Context) endpoint.Middleware {
+		return selective
+	}
+	return Option{F: func(o *internal_server.Options, di *utils.Slice) {
+		di.Push(fmt.Sprintf("WithMatchedMiddleware(%+v)", utils.GetFuncName(mw)))
+		o.MWBs = append(o.MWBs, mwb)
+	}}
+}
+
 // WithMiddlewareBuilder adds middleware that depend on context for server to handle request
 func WithMiddlewareBuilder(mwb endpoint.MiddlewareBuilder, funcName ...string) Option {
 	return Option{F: func(o *internal_server.Options, di *utils.Slice) {
@@ -228,6 +251,11 @@ func WithPayloadCodec(c remote.PayloadCodec) Option {
 		} else if protobuf.IsProtobufCodec(c) {
 			di.Push(fmt.Sprintf("ResetProtobufPayloadCodec(%+v)", c))
 			remote.PutPayloadCode(serviceinfo.Protobuf, c)
+		} else if codec.IsJSONCodec(c) {
+			// the JSON codec has been registered by default,
+			// keep the registration so other payload types still work on the same port
+			di.Push(fmt.Sprintf("ResetJSONPayloadCodec(%+v)", c))
+			remote.PutPayloadCode(serviceinfo.JSON, c)
 		} else {
 			di.Push(fmt.Sprintf("WithPayloadCodec(%+v)", c))
 			// if specify RemoteOpt.PayloadCodec, then the priority is highest, all payload decode will use this one
@@ -236,6 +264,18 @@ func WithPayloadCodec(c remote.PayloadCodec) Option {
 	}}
 }
 
+// WithFrugal enables frugal, the JIT-based thrift codec, for both encoding and decoding
+// by replacing the registered thrift codec; other payload types are untouched.
+// Types frugal cannot handle, e.g. ones generated without frugal tags, automatically
+// fall back to the fastCodec or Apache codec. SkipDecoder is enabled so frugal decoding
+// also works for the Buffered transport where the payload size is unknown.
+func WithFrugal() Option {
+	return Option{F: func(o *internal_server.Options, di *utils.Slice) {
+		di.Push("WithFrugal()")
+		remote.PutPayloadCode(serviceinfo.Thrift, thrift.NewThriftCodecWithConfig(thrift.FrugalReadWrite|thrift.EnableSkipDecoder))
+	}}
+}
+
 // WithRegistry to set a Registry to register service
 func WithRegistry(r registry.Registry) Option {
 	return Option{F: func(o *internal_server.Options, di *utils.Slice) {
@@ -380,6 +420,26 @@ func WithContextBackup(enable, async bool) Option {
 	}}
 }
 
+// WithHealthCheck registers the built-in health service, whose protocol is
+// compatible with grpc.health.v1.Health, see the healthcheck package.
+// Use healthcheck.SetServingStatus to flip readiness during warm-up or drain.
+func WithHealthCheck() Option {
+	return Option{F: func(o *internal_server.Options, di *utils.Slice) {
+		di.Push("WithHealthCheck()")
+		o.EnableHealthCheck = true
+	}}
+}
+
+// WithReflectionService registers the built-in reflection service, which lists
+// the registered services and methods and serves their IDL sources, see the
+// reflection package. Use reflection.RegisterIDL to publish IDL sources.
+func WithReflectionService() Option {
+	return Option{F: func(o *internal_server.Options, di *utils.Slice) {
+		di.Push("WithReflectionService()")
+		o.EnableReflection = true
+	}}
+}
+
 // WithRefuseTrafficWithoutServiceName returns an Option that only accepts traffics with service name.
 // This is used for a server with multi services and is one of the options to avoid a server startup error
 // when having conflicting method names between services without specifying a fallback service for the method.
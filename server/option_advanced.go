@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"time"
 
 	internal_server "github.com/cloudwego/kitex/internal/server"
 	"github.com/cloudwego/kitex/pkg/acl"
@@ -35,7 +36,10 @@ import (
 	"github.com/cloudwego/kitex/pkg/profiler"
 	"github.com/cloudwego/kitex/pkg/proxy"
 	"github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/remote/bound"
+	"github.com/cloudwego/kitex/pkg/remote/codec"
 	"github.com/cloudwego/kitex/pkg/rpcinfo"
+	"github.com/cloudwego/kitex/pkg/shedding"
 	"github.com/cloudwego/kitex/pkg/utils"
 )
 
@@ -82,6 +86,23 @@ func WithMetaHandler(h remote.MetaHandler) Option {
 	}}
 }
 
+// WithHeaderInspector adds an inspector that runs after the transport headers
+// of a request are decoded and before its payload is decoded, receiving the
+// method name and the header string key-value pairs. Returning an error
+// rejects the request without paying the payload decode cost, which makes it
+// a cheap first line of defense against junk traffic. Only protocols decoded
+// through the default codec (e.g. TTHeader) go through the inspectors.
+func WithHeaderInspector(f remote.HeaderInspector) Option {
+	return Option{F: func(o *internal_server.Options, di *utils.Slice) {
+		di.Push(fmt.Sprintf("WithHeaderInspector(%+v)", utils.GetFuncName(f)))
+
+		if f == nil {
+			panic(fmt.Errorf("invalid HeaderInspector: nil"))
+		}
+		o.RemoteOpt.HeaderInspectors = append(o.RemoteOpt.HeaderInspectors, f)
+	}}
+}
+
 // WithProxy sets the backward Proxy for server.
 func WithProxy(p proxy.ReverseProxy) Option {
 	return Option{F: func(o *internal_server.Options, di *utils.Slice) {
@@ -148,6 +169,112 @@ func WithErrorHandler(f func(context.Context, error) error) Option {
 	}}
 }
 
+// WithLoadShedding enables priority-based load shedding: while the measured
+// queueing delay exceeds the policy, requests whose TTHeader priority is below
+// the configured threshold are rejected with shedding.ErrServerOverloaded
+// before reaching the business handler.
+func WithLoadShedding(policy shedding.Policy) Option {
+	return Option{F: func(o *internal_server.Options, di *utils.Slice) {
+		di.Push(fmt.Sprintf("WithLoadShedding(%+v)", policy))
+
+		doAddBoundHandler(bound.NewServerSheddingHandler(shedding.NewShedder(policy)), o.RemoteOpt)
+	}}
+}
+
+// WithConnStore attaches a remote.ConnStore to every accepted connection, a
+// key-value store scoped to the connection's lifetime. Handlers and
+// middlewares reach it via remote.GetConnStore(ctx), so auth handshakes or
+// other stateful protocols can cache per-connection data (e.g. a verified
+// identity) across requests on mux and long connections.
+func WithConnStore() Option {
+	return Option{F: func(o *internal_server.Options, di *utils.Slice) {
+		di.Push("WithConnStore()")
+
+		doAddBoundHandler(bound.NewConnStoreInboundHandler(), o.RemoteOpt)
+	}}
+}
+
+// WithMaxConnectionAge closes server connections after maxAge (with up to 10%
+// jitter) plus the grace drain window, so long-lived connections don't pin
+// traffic to old instances after a scale-out.
+func WithMaxConnectionAge(maxAge, grace time.Duration) Option {
+	return Option{F: func(o *internal_server.Options, di *utils.Slice) {
+		di.Push(fmt.Sprintf("WithMaxConnectionAge(%v,%v)", maxAge, grace))
+
+		if maxAge <= 0 {
+			panic(fmt.Errorf("invalid MaxConnectionAge: %v", maxAge))
+		}
+		doAddBoundHandler(bound.NewMaxConnectionAgeHandler(maxAge, grace), o.RemoteOpt)
+	}}
+}
+
+// WithMaxRequestSize limits the payload size of a single request to size bytes.
+// Oversized frames are rejected at the codec layer with a protocol error
+// response instead of being buffered in full, so a misbehaving client cannot
+// exhaust server memory. It replaces the server codec with the default codec
+// configured with the size limit, so it cannot be combined with WithCodec.
+func WithMaxRequestSize(size int) Option {
+	return Option{F: func(o *internal_server.Options, di *utils.Slice) {
+		di.Push(fmt.Sprintf("WithMaxRequestSize(%d)", size))
+
+		if size <= 0 {
+			panic(fmt.Errorf("invalid MaxRequestSize: %d", size))
+		}
+		o.RemoteOpt.Codec = codec.NewDefaultCodecWithSizeLimit(size)
+	}}
+}
+
+// SockOpts is the set of socket options used by WithSocketOptions.
+type SockOpts = remote.SockOpts
+
+// WithSocketOptions tunes the kernel socket options of the server listener,
+// such as SO_SNDBUF/SO_RCVBUF, TCP_NODELAY, keep-alive and TCP_DEFER_ACCEPT.
+// The options take effect on both the netpoll and gonet transports; see
+// remote.SockOpts for per-field behavior.
+func WithSocketOptions(so SockOpts) Option {
+	return Option{F: func(o *internal_server.Options, di *utils.Slice) {
+		di.Push(fmt.Sprintf("WithSocketOptions(%+v)", so))
+
+		o.RemoteOpt.SocketOptions = &so
+	}}
+}
+
+// WithPanicHandler sets a hook invoked when a biz handler panics. It receives
+// the recovered panic value and the stack, and the error it returns replaces
+// the default kerrors.ErrPanic error, so panics can be mapped to typed
+// business exceptions or reported to custom metrics. Returning nil keeps the
+// default error.
+func WithPanicHandler(f func(ctx context.Context, panicInfo interface{}, stack string) error) Option {
+	return Option{F: func(o *internal_server.Options, di *utils.Slice) {
+		di.Push(fmt.Sprintf("WithPanicHandler(%+v)", utils.GetFuncName(f)))
+
+		if f == nil {
+			panic(fmt.Errorf("invalid PanicHandler: nil"))
+		}
+		o.PanicHandler = f
+	}}
+}
+
+// WithSyncHandler processes requests inline on the I/O goroutine instead of
+// spawning a goroutine per request, which saves the goroutine hop for tiny
+// handlers. The tradeoff is that a slow handler blocks every other request
+// multiplexed on the same connection; a watchdog logs handlers running longer
+// than watchdog (0 disables it), and handler duration remains visible through
+// the usual rpc stats. It only takes effect on the mux transport
+// (WithMuxTransport); the other transports already process inline per
+// connection.
+func WithSyncHandler(watchdog time.Duration) Option {
+	return Option{F: func(o *internal_server.Options, di *utils.Slice) {
+		di.Push(fmt.Sprintf("WithSyncHandler(%v)", watchdog))
+
+		if watchdog < 0 {
+			panic(fmt.Errorf("invalid SyncHandler watchdog: %v", watchdog))
+		}
+		o.RemoteOpt.SyncHandler = true
+		o.RemoteOpt.SyncHandlerWatchdog = watchdog
+	}}
+}
+
 // WithBoundHandler adds remote.BoundHandler for server.
 func WithBoundHandler(h remote.BoundHandler) Option {
 	return Option{F: func(o *internal_server.Options, di *utils.Slice) {
@@ -196,6 +323,21 @@ func WithListener(ln net.Listener) Option {
 	}}
 }
 
+// WithListeners makes the server serve several listeners with one handler,
+// e.g. TCP plus a unix domain socket, or IPv4 plus IPv6. The first listener
+// is the primary one: its address is the one reported to the registry.
+func WithListeners(lns ...net.Listener) Option {
+	return Option{F: func(o *internal_server.Options, di *utils.Slice) {
+		di.Push(fmt.Sprintf("WithListeners(%+v)", lns))
+
+		if len(lns) == 0 {
+			panic(fmt.Errorf("invalid Listeners: empty"))
+		}
+		o.RemoteOpt.Listener = lns[0]
+		o.ExtraListeners = append(o.ExtraListeners, lns[1:]...)
+	}}
+}
+
 // WithReusePort sets SO_REUSEPORT on listener, it is only used with Option `WithServiceAddr`.
 // It won't take effect when listener is specified by WithListener.
 func WithReusePort(reuse bool) Option {
@@ -69,6 +69,16 @@ func TestACLRulesOption(t *testing.T) {
 	test.Assert(t, iSvr.opt.ACLRules[1] != nil)
 }
 
+// TestMaxRequestSizeOption tests the creation of a server with MaxRequestSize option
+func TestMaxRequestSizeOption(t *testing.T) {
+	o := internal_server.NewOptions([]internal_server.Option{WithMaxRequestSize(1024)})
+	test.Assert(t, o.RemoteOpt.Codec != nil)
+
+	test.Panic(t, func() {
+		NewServer(WithMaxRequestSize(0))
+	})
+}
+
 // TestProxyOptionPanic tests the creation of a server with Proxy option,if the proxy is not nil, should panic
 func TestProxyOptionPanic(t *testing.T) {
 	o := internal_server.NewOptions(nil)
@@ -19,10 +19,13 @@ package server
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 
 	internal_server "github.com/cloudwego/kitex/internal/server"
 	"github.com/cloudwego/kitex/pkg/endpoint"
 	"github.com/cloudwego/kitex/pkg/endpoint/sep"
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/cloudwego/kitex/pkg/streaming"
 	"github.com/cloudwego/kitex/pkg/utils"
 )
 
@@ -40,6 +43,39 @@ func WithStreamOptions(opts ...StreamOption) Option {
 	}}
 }
 
+// WithMaxConcurrentStreams limits the number of streams handled concurrently
+// by the whole server, counting both gRPC and TTHeader streaming calls.
+// Streams over the limit are rejected with kerrors.ErrStreamOverLimit.
+// For gRPC it additionally applies the HTTP/2 per-transport limit, like
+// WithGRPCMaxConcurrentStreams.
+func WithMaxConcurrentStreams(n int) Option {
+	return Option{F: func(o *internal_server.Options, di *utils.Slice) {
+		di.Push(fmt.Sprintf("WithMaxConcurrentStreams(%d)", n))
+
+		if n <= 0 {
+			panic(fmt.Errorf("invalid MaxConcurrentStreams: %d", n))
+		}
+		o.RemoteOpt.GRPCCfg.MaxStreams = uint32(n)
+		o.StreamOptions.StreamMiddlewares = append(o.StreamOptions.StreamMiddlewares, newMaxStreamsMW(int32(n)))
+	}}
+}
+
+// newMaxStreamsMW builds the stream middleware counting the active streams of
+// the server against the given limit.
+func newMaxStreamsMW(limit int32) sep.StreamMiddleware {
+	var active int32
+	return func(next sep.StreamEndpoint) sep.StreamEndpoint {
+		return func(ctx context.Context, st streaming.ServerStream) (err error) {
+			if atomic.AddInt32(&active, 1) > limit {
+				atomic.AddInt32(&active, -1)
+				return kerrors.ErrStreamOverLimit
+			}
+			defer atomic.AddInt32(&active, -1)
+			return next(ctx, st)
+		}
+	}
+}
+
 // WithStreamMiddleware add middleware for stream.
 func WithStreamMiddleware(mw sep.StreamMiddleware) StreamOption {
 	return StreamOption{F: func(o *StreamOptions, di *utils.Slice) {
@@ -340,6 +340,31 @@ func TestPayloadCodecOption(t *testing.T) {
 		test.Assert(t, protobuf.IsProtobufCodec(pc))
 	})
 
+	t.Run("WithFrugal", func(t *testing.T) {
+		svr, _ := NewTestServer(WithFrugal())
+		err := svr.RegisterService(mocks.ServiceInfo(), mocks.MyServiceHandler())
+		test.Assert(t, err == nil, err)
+		goWaitAndStop(t, svr)
+		err = svr.Run()
+		test.Assert(t, err == nil, err)
+		iSvr := svr.(*server)
+		// WithFrugal replaces the registered thrift codec, RemoteOpt.PayloadCodec stays nil
+		test.Assert(t, iSvr.opt.RemoteOpt.PayloadCodec == nil)
+
+		tRecvMsg := NewRemoteMsgWithPayloadType(serviceinfo.Thrift)
+		tRecvMsg.SetPayloadCodec(iSvr.opt.RemoteOpt.PayloadCodec)
+		pc, err := remote.GetPayloadCodec(tRecvMsg)
+		test.Assert(t, err == nil)
+		test.Assert(t, thrift.IsThriftCodec(pc))
+		test.Assert(t, reflect.DeepEqual(pc, thrift.NewThriftCodecWithConfig(thrift.FrugalReadWrite|thrift.EnableSkipDecoder)))
+
+		pRecvMsg := NewRemoteMsgWithPayloadType(serviceinfo.Protobuf)
+		pRecvMsg.SetPayloadCodec(iSvr.opt.RemoteOpt.PayloadCodec)
+		pc, err = remote.GetPayloadCodec(pRecvMsg)
+		test.Assert(t, err == nil)
+		test.Assert(t, protobuf.IsProtobufCodec(pc))
+	})
+
 	t.Run("SetNonPreRegisteredCodec", func(t *testing.T) {
 		// generic.BinaryThriftGeneric().PayloadCodec() is not the pre registered codec, RemoteOpt.PayloadCodec won't be nil
 		binaryThriftCodec := generic.BinaryThriftGeneric().PayloadCodec()
@@ -17,10 +17,21 @@
 package server
 
 import (
+	"context"
+	"errors"
+	"time"
+
 	internal_server "github.com/cloudwego/kitex/internal/server"
 	"github.com/cloudwego/kitex/pkg/endpoint"
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/cloudwego/kitex/pkg/limiter"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
 )
 
+// errServiceConcurrencyLimit is returned when a per-service concurrency limit
+// set by WithServiceConcurrencyLimit is exceeded.
+var errServiceConcurrencyLimit = kerrors.ErrOverlimit.WithCause(errors.New("service concurrency over limit"))
+
 // RegisterOption is the only way to config service registration.
 type RegisterOption = internal_server.RegisterOption
 
@@ -43,3 +54,51 @@ func WithFallbackService() RegisterOption {
 		o.IsFallbackService = true
 	}}
 }
+
+// WithServiceConcurrencyLimit limits the number of concurrent requests served
+// by a single service of a multi-service server, leaving the other services
+// unaffected. Requests over the limit fail with kerrors.ErrOverlimit.
+func WithServiceConcurrencyLimit(limit int) RegisterOption {
+	concurrencyLimit := limiter.NewConnectionLimiter(limit)
+	return WithServiceMiddleware(func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, req, resp interface{}) error {
+			if !concurrencyLimit.Acquire(ctx) {
+				concurrencyLimit.Release(ctx)
+				return errServiceConcurrencyLimit
+			}
+			defer concurrencyLimit.Release(ctx)
+			return next(ctx, req, resp)
+		}
+	})
+}
+
+// WithServiceQPSLimit limits the request rate of a single service of a
+// multi-service server to limit per interval. Requests over the limit fail
+// with kerrors.ErrQPSOverLimit.
+func WithServiceQPSLimit(interval time.Duration, limit int) RegisterOption {
+	qpsLimit := limiter.NewQPSLimiter(interval, limit)
+	return WithServiceMiddleware(func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, req, resp interface{}) error {
+			if !qpsLimit.Acquire(ctx) {
+				return kerrors.ErrQPSOverLimit
+			}
+			return next(ctx, req, resp)
+		}
+	})
+}
+
+// WithServiceStatsNamespace tags every request of the service with the given
+// stats namespace, so tracers can report multi-service servers under separate
+// namespaces. The value is stored in the endpoint tag rpcinfo.StatsNamespace.
+func WithServiceStatsNamespace(namespace string) RegisterOption {
+	return WithServiceMiddleware(func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, req, resp interface{}) error {
+			if ri := rpcinfo.GetRPCInfo(ctx); ri != nil {
+				if to := rpcinfo.AsMutableEndpointInfo(ri.To()); to != nil {
+					_ = to.SetTag(rpcinfo.StatsNamespace, namespace)
+				}
+			}
+			return next(ctx, req, resp)
+		}
+	})
+}
@@ -17,10 +17,16 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 
 	internal_server "github.com/cloudwego/kitex/internal/server"
 	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/endpoint"
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
 )
 
 func TestWithFallbackService(t *testing.T) {
@@ -28,3 +34,54 @@ func TestWithFallbackService(t *testing.T) {
 	registerOpts := internal_server.NewRegisterOptions(opts)
 	test.Assert(t, registerOpts.IsFallbackService)
 }
+
+func applyRegisterMW(t *testing.T, opt RegisterOption, next endpoint.Endpoint) endpoint.Endpoint {
+	t.Helper()
+	registerOpts := internal_server.NewRegisterOptions([]RegisterOption{opt})
+	test.Assert(t, len(registerOpts.Middlewares) == 1)
+	return registerOpts.Middlewares[0](next)
+}
+
+func TestWithServiceConcurrencyLimit(t *testing.T) {
+	blockCh, releaseCh := make(chan struct{}, 2), make(chan struct{})
+	ep := applyRegisterMW(t, WithServiceConcurrencyLimit(1), func(ctx context.Context, req, resp interface{}) error {
+		blockCh <- struct{}{}
+		<-releaseCh
+		return nil
+	})
+	doneCh := make(chan struct{})
+	go func() {
+		ep(context.Background(), nil, nil)
+		close(doneCh)
+	}()
+	<-blockCh
+
+	err := ep(context.Background(), nil, nil)
+	test.Assert(t, errors.Is(err, kerrors.ErrOverlimit), err)
+
+	close(releaseCh)
+	<-doneCh
+	err = ep(context.Background(), nil, nil)
+	test.Assert(t, err == nil, err)
+}
+
+func TestWithServiceQPSLimit(t *testing.T) {
+	ep := applyRegisterMW(t, WithServiceQPSLimit(time.Second, 1), func(ctx context.Context, req, resp interface{}) error {
+		return nil
+	})
+	test.Assert(t, ep(context.Background(), nil, nil) == nil)
+	err := ep(context.Background(), nil, nil)
+	test.Assert(t, errors.Is(err, kerrors.ErrQPSOverLimit), err)
+}
+
+func TestWithServiceStatsNamespace(t *testing.T) {
+	ep := applyRegisterMW(t, WithServiceStatsNamespace("ns1"), func(ctx context.Context, req, resp interface{}) error {
+		return nil
+	})
+	to := rpcinfo.NewEndpointInfo("svc", "method", nil, nil)
+	ri := rpcinfo.NewRPCInfo(nil, to, nil, nil, nil)
+	ctx := rpcinfo.NewCtxWithRPCInfo(context.Background(), ri)
+	test.Assert(t, ep(ctx, nil, nil) == nil)
+	ns, ok := to.Tag(rpcinfo.StatsNamespace)
+	test.Assert(t, ok && ns == "ns1", ns)
+}
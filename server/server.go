@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"reflect"
 	"runtime/debug"
 	"sync"
@@ -36,9 +37,11 @@ import (
 	"github.com/cloudwego/kitex/pkg/endpoint"
 	"github.com/cloudwego/kitex/pkg/endpoint/sep"
 	"github.com/cloudwego/kitex/pkg/gofunc"
+	"github.com/cloudwego/kitex/pkg/healthcheck"
 	"github.com/cloudwego/kitex/pkg/kerrors"
 	"github.com/cloudwego/kitex/pkg/klog"
 	"github.com/cloudwego/kitex/pkg/limiter"
+	"github.com/cloudwego/kitex/pkg/reflection"
 	"github.com/cloudwego/kitex/pkg/registry"
 	"github.com/cloudwego/kitex/pkg/remote"
 	"github.com/cloudwego/kitex/pkg/remote/bound"
@@ -64,9 +67,11 @@ type server struct {
 	targetSvcInfo *serviceinfo.ServiceInfo
 
 	// actual rpc service implement of biz
-	eps     endpoint.Endpoint
-	svr     remotesvr.Server
-	stopped sync.Once
+	eps       endpoint.Endpoint
+	svr       remotesvr.Server
+	extraSvrs []remotesvr.Server
+	adminSvr  *http.Server
+	stopped   sync.Once
 	isInit  bool
 	isRun   bool
 
@@ -79,6 +84,14 @@ func NewServer(ops ...Option) Server {
 		opt:  internal_server.NewOptions(ops),
 		svcs: newServices(),
 	}
+	if s.opt.EnableHealthCheck {
+		_ = s.RegisterService(healthcheck.NewServiceInfo(), healthcheck.NewHandler())
+	}
+	if s.opt.EnableReflection {
+		// the handler reads the service map lazily, so it also covers
+		// services registered afterwards
+		_ = s.RegisterService(reflection.NewServiceInfo(), reflection.NewHandler(s))
+	}
 	return s
 }
 
@@ -168,6 +181,10 @@ func (s *server) buildMiddlewares(ctx context.Context) []endpoint.Middleware {
 			mws = append(mws, mw)
 		}
 	}
+	// register traffic mirror middleware
+	if s.opt.TrafficMirror != nil {
+		mws = append(mws, s.newTrafficMirrorMW())
+	}
 	// register services middlewares
 	if mw := s.buildServiceMiddleware(); mw != nil {
 		mws = append(mws, mw)
@@ -235,6 +252,12 @@ func (s *server) Run() (err error) {
 		}
 	}
 
+	if s.opt.EnableHotRestart {
+		if err = s.setupHotRestart(svrCfg); err != nil {
+			return err
+		}
+	}
+
 	s.fillMoreServiceInfo(s.opt.RemoteOpt.Address)
 	s.richRemoteOption()
 	transHdlr, err := s.newSvrTransHandler()
@@ -249,6 +272,12 @@ func (s *server) Run() (err error) {
 	s.svr = svr
 	s.Unlock()
 
+	if s.opt.AdminAddr != "" {
+		if err = s.startAdminServer(); err != nil {
+			return err
+		}
+	}
+
 	// start profiler
 	if s.opt.RemoteOpt.Profiler != nil {
 		gofunc.GoFunc(context.Background(), func() {
@@ -267,11 +296,18 @@ func (s *server) Run() (err error) {
 		return err
 	default:
 	}
+	if err = s.startExtraListeners(transHdlr, errCh); err != nil {
+		klog.Errorf("KITEX: server start error: error=%s", err.Error())
+		return err
+	}
 	muStartHooks.Lock()
 	for i := range onServerStart {
 		go onServerStart[i]()
 	}
 	muStartHooks.Unlock()
+	if s.opt.EnableHotRestart {
+		go s.waitHotRestart()
+	}
 	s.Lock()
 	s.buildRegistryInfo(svr.Address())
 	s.Unlock()
@@ -286,12 +322,59 @@ func (s *server) Run() (err error) {
 	return
 }
 
+// startExtraListeners starts one more remote server per listener registered
+// with WithListeners, sharing the trans handler of the primary server.
+// Errors of the extra servers are forwarded to errCh.
+func (s *server) startExtraListeners(transHdlr remote.ServerTransHandler, errCh chan error) error {
+	for _, ln := range s.opt.ExtraListeners {
+		opt := *s.opt.RemoteOpt
+		opt.Listener = ln
+		opt.Address = ln.Addr()
+		svr, err := remotesvr.NewServer(&opt, transHdlr)
+		if err != nil {
+			return err
+		}
+		extraErrCh := svr.Start()
+		select {
+		case err = <-extraErrCh:
+			return err
+		default:
+		}
+		gofunc.GoFunc(context.Background(), func() {
+			if err := <-extraErrCh; err != nil {
+				errCh <- err
+			}
+		})
+		s.Lock()
+		s.extraSvrs = append(s.extraSvrs, svr)
+		s.Unlock()
+	}
+	return nil
+}
+
+// stopExtraListeners stops the remote servers of the extra listeners.
+func (s *server) stopExtraListeners() (err error) {
+	for _, svr := range s.extraSvrs {
+		if e := svr.Stop(); e != nil {
+			err = e
+		}
+	}
+	s.extraSvrs = nil
+	return err
+}
+
 // Stop stops the server gracefully.
 func (s *server) Stop() (err error) {
 	s.stopped.Do(func() {
 		s.Lock()
 		defer s.Unlock()
 
+		if policy := s.opt.GracefulShutdown; policy != nil {
+			err = s.gracefulStop(policy)
+			s.stopAdminServer()
+			return
+		}
+
 		muShutdownHooks.Lock()
 		for i := range onShutdown {
 			onShutdown[i]()
@@ -308,6 +391,10 @@ func (s *server) Stop() (err error) {
 			}
 			s.svr = nil
 		}
+		if e := s.stopExtraListeners(); e != nil && err == nil {
+			err = e
+		}
+		s.stopAdminServer()
 	})
 	return
 }
@@ -389,11 +476,17 @@ func (s *server) invokeHandleEndpoint() endpoint.UnaryEndpoint {
 		}
 		defer func() {
 			if handlerErr := recover(); handlerErr != nil {
+				stack := string(debug.Stack())
 				err = kerrors.ErrPanic.WithCauseAndStack(
 					fmt.Errorf(
 						"[happened in biz handler, method=%s.%s, please check the panic at the server side] %s",
 						svcInfo.ServiceName, methodName, handlerErr),
-					string(debug.Stack()))
+					stack)
+				if s.opt.PanicHandler != nil {
+					if bizErr := s.opt.PanicHandler(ctx, handlerErr, stack); bizErr != nil {
+						err = bizErr
+					}
+				}
 				rpcStats := rpcinfo.AsMutableRPCStats(ri.Stats())
 				rpcStats.SetPanicked(err)
 			}
@@ -432,11 +525,17 @@ func (s *server) streamHandleEndpoint() sep.StreamEndpoint {
 		}
 		defer func() {
 			if handlerErr := recover(); handlerErr != nil {
+				stack := string(debug.Stack())
 				err = kerrors.ErrPanic.WithCauseAndStack(
 					fmt.Errorf(
 						"[happened in biz handler, method=%s.%s, please check the panic at the server side] %s",
 						svcInfo.ServiceName, methodName, handlerErr),
-					string(debug.Stack()))
+					stack)
+				if s.opt.PanicHandler != nil {
+					if bizErr := s.opt.PanicHandler(ctx, handlerErr, stack); bizErr != nil {
+						err = bizErr
+					}
+				}
 				rpcStats := rpcinfo.AsMutableRPCStats(ri.Stats())
 				rpcStats.SetPanicked(err)
 			}
@@ -978,6 +978,34 @@ func TestInvokeHandlerPanic(t *testing.T) {
 	test.Assert(t, serviceHandler)
 }
 
+func TestInvokeHandlerPanicHandler(t *testing.T) {
+	callMethod := "mock"
+	customErr := errors.New("mapped panic")
+	var gotPanic interface{}
+	var gotStack string
+	s := &server{
+		opt: internal_server.NewOptions([]internal_server.Option{WithPanicHandler(func(ctx context.Context, panicInfo interface{}, stack string) error {
+			gotPanic, gotStack = panicInfo, stack
+			return customErr
+		})}),
+		svcs: newServices(),
+	}
+	err := s.svcs.addService(svcInfo, mocks.MockFuncHandler(func(ctx context.Context, req *mocks.MyRequest) (r *mocks.MyResponse, err error) {
+		panic("test")
+	}), &RegisterOptions{})
+	test.Assert(t, err == nil, err)
+
+	ri := rpcinfo.NewRPCInfo(nil, nil, rpcinfo.NewInvocation(svcInfo.ServiceName, callMethod), nil, rpcinfo.NewRPCStats())
+	ctx := rpcinfo.NewCtxWithRPCInfo(context.Background(), ri)
+	err = s.invokeHandleEndpoint()(ctx, svcInfo.MethodInfo(callMethod).NewArgs(), svcInfo.MethodInfo(callMethod).NewResult())
+	test.Assert(t, err == customErr, err)
+	test.Assert(t, gotPanic == "test", gotPanic)
+	test.Assert(t, strings.Contains(gotStack, "goroutine"))
+	hasPanicked, panicErr := ri.Stats().Panicked()
+	test.Assert(t, hasPanicked)
+	test.Assert(t, panicErr == customErr, panicErr)
+}
+
 func TestRegisterService(t *testing.T) {
 	{
 		svr := NewServer()
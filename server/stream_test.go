@@ -22,8 +22,10 @@ import (
 
 	internal_server "github.com/cloudwego/kitex/internal/server"
 	"github.com/cloudwego/kitex/internal/test"
+	"github.com/cloudwego/kitex/pkg/kerrors"
 	"github.com/cloudwego/kitex/pkg/rpcinfo"
 	"github.com/cloudwego/kitex/pkg/stats"
+	"github.com/cloudwego/kitex/pkg/streaming"
 )
 
 type mockTracer struct {
@@ -45,3 +47,34 @@ func Test_server_initStreamMiddlewares(t *testing.T) {
 	test.Assert(t, len(s.opt.Streaming.RecvMiddlewares) == 0, "init middlewares failed")
 	test.Assert(t, len(s.opt.Streaming.SendMiddlewares) == 0, "init middlewares failed")
 }
+
+func TestWithMaxConcurrentStreams(t *testing.T) {
+	o := internal_server.NewOptions([]internal_server.Option{WithMaxConcurrentStreams(1)})
+	test.Assert(t, o.RemoteOpt.GRPCCfg.MaxStreams == 1)
+	test.Assert(t, len(o.StreamOptions.StreamMiddlewares) == 1)
+
+	blockCh, releaseCh := make(chan struct{}, 2), make(chan struct{})
+	ep := o.StreamOptions.StreamMiddlewares[0](func(ctx context.Context, st streaming.ServerStream) error {
+		blockCh <- struct{}{}
+		<-releaseCh
+		return nil
+	})
+	doneCh := make(chan struct{})
+	go func() {
+		ep(context.Background(), nil)
+		close(doneCh)
+	}()
+	<-blockCh
+
+	err := ep(context.Background(), nil)
+	test.Assert(t, err == kerrors.ErrStreamOverLimit, err)
+
+	close(releaseCh)
+	<-doneCh
+	err = ep(context.Background(), nil)
+	test.Assert(t, err == nil, err)
+
+	test.Panic(t, func() {
+		NewServer(WithMaxConcurrentStreams(0))
+	})
+}
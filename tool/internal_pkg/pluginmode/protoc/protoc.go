@@ -112,6 +112,11 @@ func GenKitex(req *pluginpb.CodeGeneratorRequest, opts protogen.Options) (*plugi
 		return nil, err
 	}
 	pp.process(gen)
+	// advertise the language features supported by protoc-gen-go, notably
+	// proto3 optional fields. Editions syntax additionally needs protogen to
+	// negotiate the supported edition range, which the pinned
+	// google.golang.org/protobuf does not expose yet, so protoc declines
+	// editions files until the dependency is bumped.
 	gen.SupportedFeatures = gengo.SupportedFeatures
 
 	// FastPB is deprecated, so truncate all fastpb files